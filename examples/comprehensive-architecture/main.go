@@ -17,26 +17,14 @@ func main() {
 	// Setup router with all routes and middleware
 	appRouter := router.Setup()
 
-	// Setup OpenAPI generation
-	apiGenerator := openapi.NewGenerator(cfg)
-	spec, err := apiGenerator.Generate(appRouter)
-	if err != nil {
+	// Serve the OpenAPI documents and Swagger UI from the router, failing fast on a bad spec
+	docs := openapi.NewGenerator(cfg).Serve(appRouter)
+	if _, err := docs.Cache.Spec(); err != nil {
 		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
 	}
 
-	// Generate JSON and YAML specifications
-	jsonSpec, err := apiGenerator.GenerateJSON(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate JSON spec: %v", err)
-	}
-
-	yamlSpec, err := apiGenerator.GenerateYAML(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate YAML spec: %v", err)
-	}
-
-	// Create combined handler that serves both API routes and documentation
-	combinedHandler := createCombinedHandler(appRouter, jsonSpec, yamlSpec)
+	// Architecture guide
+	appRouter.Handle(http.MethodGet, "/{$}", http.HandlerFunc(architectureGuide))
 
 	// Start server
 	fmt.Println("🚀 Comprehensive TypedHTTP Architecture Example")
@@ -55,69 +43,12 @@ func main() {
 	fmt.Println("   ✓ Production-ready patterns")
 
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Fatal(http.ListenAndServe(serverAddr, combinedHandler))
+	log.Fatal(http.ListenAndServe(serverAddr, appRouter))
 }
 
-// createCombinedHandler creates a handler that serves both API routes and documentation
-func createCombinedHandler(apiRouter http.Handler, jsonSpec, yamlSpec []byte) http.Handler {
-	// Create a new mux for documentation routes
-	docMux := http.NewServeMux()
-	
-	// Register documentation routes
-	docMux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(jsonSpec)
-	})
-
-	docMux.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/x-yaml")
-		w.Write(yamlSpec)
-	})
-
-	// Documentation UI
-	docMux.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
-		html := `<!DOCTYPE html>
-<html>
-<head>
-    <title>E-commerce API Documentation</title>
-    <meta charset="UTF-8">
-    <link rel="stylesheet" type="text/css" href="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui.css" />
-    <style>
-        html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
-        *, *:before, *:after { box-sizing: inherit; }
-        body { margin:0; background: #fafafa; }
-    </style>
-</head>
-<body>
-    <div id="swagger-ui"></div>
-    <script src="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui-bundle.js"></script>
-    <script src="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui-standalone-preset.js"></script>
-    <script>
-        window.onload = function() {
-            SwaggerUIBundle({
-                url: '/openapi.json',
-                dom_id: '#swagger-ui',
-                deepLinking: true,
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIStandalonePreset
-                ],
-                plugins: [
-                    SwaggerUIBundle.plugins.DownloadUrl
-                ],
-                layout: "StandaloneLayout"
-            });
-        };
-    </script>
-</body>
-</html>`
-		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
-		w.Write([]byte(html))
-	})
-
-	// Architecture guide
-	docMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		html := `<!DOCTYPE html>
+// architectureGuide serves the home page describing the example
+func architectureGuide(w http.ResponseWriter, r *http.Request) {
+	html := `<!DOCTYPE html>
 <html>
 <head>
     <title>Comprehensive TypedHTTP Architecture Example</title>
@@ -221,19 +152,6 @@ Priority  10: Audit Logging Middleware</div>
     </div>
 </body>
 </html>`
-		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
-		w.Write([]byte(html))
-	})
-
-	// Return a handler that routes between documentation and API
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Route documentation paths to the documentation mux
-		if r.URL.Path == "/" || r.URL.Path == "/docs" || r.URL.Path == "/openapi.json" || r.URL.Path == "/openapi.yaml" {
-			docMux.ServeHTTP(w, r)
-			return
-		}
-		
-		// Route all other paths to the API router
-		apiRouter.ServeHTTP(w, r)
-	})
-}
\ No newline at end of file
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.Write([]byte(html))
+}
@@ -35,26 +35,14 @@ func main() {
 		approach = "Traditional Handlers (OLD)"
 	}
 
-	// Setup OpenAPI generation
-	apiGenerator := openapi.NewGenerator(cfg)
-	spec, err := apiGenerator.Generate(appRouter)
-	if err != nil {
+	// Serve the OpenAPI documents and Swagger UI from the router, failing fast on a bad spec
+	docs := openapi.NewGenerator(cfg).Serve(appRouter)
+	if _, err := docs.Cache.Spec(); err != nil {
 		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
 	}
 
-	// Generate JSON and YAML specifications
-	jsonSpec, err := apiGenerator.GenerateJSON(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate JSON spec: %v", err)
-	}
-
-	yamlSpec, err := apiGenerator.GenerateYAML(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate YAML spec: %v", err)
-	}
-
-	// Create combined handler that serves both API routes and documentation
-	combinedHandler := createCombinedHandlerForDemo(appRouter, jsonSpec, yamlSpec, approach)
+	// Architecture comparison page
+	appRouter.Handle(http.MethodGet, "/{$}", comparisonPage(approach))
 
 	// Start server
 	fmt.Println("🚀 TypedHTTP Boilerplate Reduction Demo")
@@ -71,79 +59,12 @@ func main() {
 	fmt.Printf("📊 Code Reduction: %s\n", getCodeStats(*useResources))
 
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Fatal(http.ListenAndServe(serverAddr, combinedHandler))
+	log.Fatal(http.ListenAndServe(serverAddr, appRouter))
 }
 
-// createCombinedHandlerForDemo creates a handler that serves both API routes and documentation
-func createCombinedHandlerForDemo(apiRouter http.Handler, jsonSpec, yamlSpec []byte, approach string) http.Handler {
-	// Create a new mux for documentation routes
-	docMux := http.NewServeMux()
-
-	// Register documentation routes
-	docMux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(jsonSpec)
-	})
-
-	docMux.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/x-yaml")
-		w.Write(yamlSpec)
-	})
-
-	// Enhanced documentation UI showing the comparison
-	docMux.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
-		html := fmt.Sprintf(`<!DOCTYPE html>
-<html>
-<head>
-    <title>TypedHTTP Boilerplate Reduction Demo</title>
-    <meta charset="UTF-8">
-    <link rel="stylesheet" type="text/css" href="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui.css" />
-    <style>
-        html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
-        *, *:before, *:after { box-sizing: inherit; }
-        body { margin:0; background: #fafafa; }
-        .approach-banner { 
-            background: %s; 
-            color: white; 
-            padding: 15px; 
-            text-align: center; 
-            font-weight: bold; 
-            font-size: 18px;
-        }
-    </style>
-</head>
-<body>
-    <div class="approach-banner">
-        🔧 Currently using: %s
-    </div>
-    <div id="swagger-ui"></div>
-    <script src="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui-bundle.js"></script>
-    <script src="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui-standalone-preset.js"></script>
-    <script>
-        window.onload = function() {
-            SwaggerUIBundle({
-                url: '/openapi.json',
-                dom_id: '#swagger-ui',
-                deepLinking: true,
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIStandalonePreset
-                ],
-                plugins: [
-                    SwaggerUIBundle.plugins.DownloadUrl
-                ],
-                layout: "StandaloneLayout"
-            });
-        };
-    </script>
-</body>
-</html>`, getBannerColor(approach), approach)
-		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
-		w.Write([]byte(html))
-	})
-
-	// Architecture comparison page
-	docMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+// comparisonPage serves the home page comparing the two approaches
+func comparisonPage(approach string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		html := fmt.Sprintf(`<!DOCTYPE html>
 <html>
 <head>
@@ -321,18 +242,6 @@ go run main_resource_demo.go
 		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
 		w.Write([]byte(html))
 	})
-
-	// Return a handler that routes between documentation and API
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Route documentation paths to the documentation mux
-		if r.URL.Path == "/" || r.URL.Path == "/docs" || r.URL.Path == "/openapi.json" || r.URL.Path == "/openapi.yaml" {
-			docMux.ServeHTTP(w, r)
-			return
-		}
-
-		// Route all other paths to the API router
-		apiRouter.ServeHTTP(w, r)
-	})
 }
 
 func getBannerColor(approach string) string {
@@ -115,8 +115,8 @@ func main() {
 		}
 	}
 
-	// Generate OpenAPI specification
-	generator := openapi.NewGenerator(&openapi.Config{
+	// Serve the OpenAPI documents and Swagger UI from the router
+	docs := openapi.ServeOpenAPI(router, &openapi.Config{
 		Info: openapi.Info{
 			Title:       "User Management API with Envelope Middleware",
 			Version:     "1.0.0",
@@ -129,76 +129,12 @@ func main() {
 			},
 		},
 	})
-
-	spec, err := generator.Generate(router)
-	if err != nil {
+	if _, err := docs.Cache.Spec(); err != nil {
 		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
 	}
 
-	// Generate JSON and YAML
-	jsonSpec, err := generator.GenerateJSON(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate JSON spec: %v", err)
-	}
-
-	yamlSpec, err := generator.GenerateYAML(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate YAML spec: %v", err)
-	}
-
-	// Serve OpenAPI endpoints
-	http.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(jsonSpec)
-	})
-
-	http.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/x-yaml")
-		w.Write(yamlSpec)
-	})
-
-	// Serve Swagger UI (simple redirect to petstore for demo)
-	http.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
-		html := `<!DOCTYPE html>
-<html>
-<head>
-    <title>API Documentation</title>
-    <link rel="stylesheet" type="text/css" href="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui.css" />
-    <style>
-        html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
-        *, *:before, *:after { box-sizing: inherit; }
-        body { margin:0; background: #fafafa; }
-    </style>
-</head>
-<body>
-    <div id="swagger-ui"></div>
-    <script src="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui-bundle.js"></script>
-    <script src="https://unpkg.com/swagger-ui-dist@3.52.5/swagger-ui-standalone-preset.js"></script>
-    <script>
-        window.onload = function() {
-            SwaggerUIBundle({
-                url: '/openapi.json',
-                dom_id: '#swagger-ui',
-                deepLinking: true,
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIStandalonePreset
-                ],
-                plugins: [
-                    SwaggerUIBundle.plugins.DownloadUrl
-                ],
-                layout: "StandaloneLayout"
-            });
-        };
-    </script>
-</body>
-</html>`
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(html))
-	})
-
 	// Info endpoint
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	router.Handle(http.MethodGet, "/{$}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		html := `<!DOCTYPE html>
 <html>
 <head>
@@ -270,7 +206,7 @@ func main() {
 </html>`
 		w.Header().Set("Content-Type", "text/html")
 		w.Write([]byte(html))
-	})
+	}))
 
 	fmt.Println("🚀 Server starting on http://localhost:8080")
 	fmt.Println("📚 Visit http://localhost:8080 for demo info")
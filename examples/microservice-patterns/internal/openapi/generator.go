@@ -34,4 +34,8 @@ func (g *Generator) GenerateJSON(spec *openapi3.T) ([]byte, error) {
 // GenerateYAML generates the OpenAPI specification as YAML
 func (g *Generator) GenerateYAML(spec *openapi3.T) ([]byte, error) {
 	return g.generator.GenerateYAML(spec)
+}
+// Serve registers the OpenAPI JSON and YAML documents and the Swagger UI on the router
+func (g *Generator) Serve(router *typedhttp.TypedRouter) *openapi.SpecRoutes {
+	return openapi.ServeOpenAPI(router, g.config.ToOpenAPIConfig())
 }
\ No newline at end of file
@@ -25,43 +25,28 @@ func main() {
 	// Setup router for public API
 	appRouter := router.Setup(models.PublicAPI)
 
-	// Generate OpenAPI spec
-	generator := openapi.NewGenerator(publicAPIConfig)
-	spec, err := generator.Generate(appRouter)
-	if err != nil {
+	// Serve the OpenAPI documents and Swagger UI from the router, failing fast on a bad spec
+	docs := openapi.NewGenerator(publicAPIConfig).Serve(appRouter)
+	if _, err := docs.Cache.Spec(); err != nil {
 		log.Fatalf("Failed to generate OpenAPI spec: %v", err)
 	}
 
-	jsonSpec, err := generator.GenerateJSON(spec)
-	if err != nil {
-		log.Fatalf("Failed to generate JSON spec: %v", err)
-	}
-
-	// Create combined handler that serves both API routes and documentation
-	combinedHandler := createCombinedHandler(appRouter, jsonSpec, services)
+	// Architecture documentation
+	appRouter.Handle(http.MethodGet, "/{$}", architectureGuide(services))
 
 	// Start server
 	fmt.Printf("📍 Public API Gateway running on: http://localhost:%s\n", publicAPIConfig.Port)
 	fmt.Printf("📄 OpenAPI Spec: http://localhost:%s/openapi.json\n", publicAPIConfig.Port)
+	fmt.Printf("📚 API Docs: http://localhost:%s/docs\n", publicAPIConfig.Port)
 	fmt.Println("\n🎯 This demonstrates how different service types can use different middleware strategies")
 
 	serverAddr := fmt.Sprintf(":%s", publicAPIConfig.Port)
-	log.Fatal(http.ListenAndServe(serverAddr, combinedHandler))
+	log.Fatal(http.ListenAndServe(serverAddr, appRouter))
 }
 
-// createCombinedHandler creates a handler that serves both API routes and documentation
-func createCombinedHandler(apiRouter http.Handler, jsonSpec []byte, services map[string]config.ServiceConfig) http.Handler {
-	// Create a new mux for documentation routes
-	docMux := http.NewServeMux()
-
-	// Register documentation routes
-	docMux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(jsonSpec)
-	})
-
-	// Architecture documentation
-	docMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+// architectureGuide serves the architecture documentation home page
+func architectureGuide(services map[string]config.ServiceConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		html := `<!DOCTYPE html>
 <html>
 <head>
@@ -83,6 +68,7 @@ func createCombinedHandler(apiRouter http.Handler, jsonSpec []byte, services map
     <div class="container">
         <div class="nav">
             <a href="/">🏠 Home</a>
+            <a href="/docs">📚 API Docs</a>
             <a href="/openapi.json">📄 OpenAPI JSON</a>
         </div>
 
@@ -178,18 +164,6 @@ func createCombinedHandler(apiRouter http.Handler, jsonSpec []byte, services map
 		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
 		w.Write([]byte(html))
 	})
-
-	// Return a handler that routes between documentation and API
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Route documentation paths to the documentation mux
-		if r.URL.Path == "/" || r.URL.Path == "/openapi.json" {
-			docMux.ServeHTTP(w, r)
-			return
-		}
-
-		// Route all other paths to the API router
-		apiRouter.ServeHTTP(w, r)
-	})
 }
 
 func getArchitectureDescription(serviceType models.ServiceType) string {
@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Request signature errors
+var (
+	ErrSignatureMissing  = errors.New("request signature missing")
+	ErrSignatureMismatch = errors.New("request signature mismatch")
+	ErrTimestampInvalid  = errors.New("request timestamp invalid")
+	ErrTimestampExpired  = errors.New("request timestamp outside the allowed window")
+	ErrBodyTooLarge      = errors.New("request body too large to verify")
+	ErrBodyUnreadable    = errors.New("failed to read request body")
+)
+
+// DefaultHMACMaxBodySize is the largest body HMAC middleware reads to verify a signature
+// unless WithMaxBodySize changes it.
+const DefaultHMACMaxBodySize = 1 << 20
+
+// HMACConfig holds request signature middleware configuration
+type HMACConfig struct {
+	// Secrets are the active shared secrets. A signature made with any of them is
+	// accepted, so a new secret can be rolled out before the old one is retired.
+	Secrets         [][]byte
+	SignatureHeader string
+	TimestampHeader string
+	// MaxClockSkew is how far the request timestamp may be from the server clock.
+	MaxClockSkew time.Duration
+	// MaxBodySize limits the body read before the signature is checked.
+	MaxBodySize int64
+	Now         func() time.Time
+}
+
+// HMACMiddleware verifies HMAC-SHA256 request signatures for server-to-server calls.
+//
+// The signature is the hex-encoded HMAC of the method, request URI, Unix timestamp
+// and body joined by newlines. Requests with a timestamp outside the clock skew
+// window are rejected, which limits replays of a captured request to that window;
+// the middleware does not track nonces, so make signed operations idempotent.
+type HMACMiddleware struct {
+	config HMACConfig
+}
+
+// HMACOption configures HMAC middleware
+type HMACOption func(*HMACConfig)
+
+// WithAdditionalSecrets accepts signatures made with other secrets, e.g. during rotation
+func WithAdditionalSecrets(secrets ...[]byte) HMACOption {
+	return func(c *HMACConfig) {
+		c.Secrets = append(c.Secrets, secrets...)
+	}
+}
+
+// WithSignatureHeader sets the header carrying the signature
+func WithSignatureHeader(header string) HMACOption {
+	return func(c *HMACConfig) {
+		c.SignatureHeader = header
+	}
+}
+
+// WithTimestampHeader sets the header carrying the Unix timestamp
+func WithTimestampHeader(header string) HMACOption {
+	return func(c *HMACConfig) {
+		c.TimestampHeader = header
+	}
+}
+
+// WithMaxClockSkew sets how old, or how far in the future, a timestamp may be
+func WithMaxClockSkew(skew time.Duration) HMACOption {
+	return func(c *HMACConfig) {
+		c.MaxClockSkew = skew
+	}
+}
+
+// WithMaxBodySize limits the size of bodies read to verify signatures. Larger bodies
+// are rejected with 413 before the signature is checked.
+func WithMaxBodySize(limit int64) HMACOption {
+	return func(c *HMACConfig) {
+		c.MaxBodySize = limit
+	}
+}
+
+// WithClock sets the time source used to check timestamps
+func WithClock(now func() time.Time) HMACOption {
+	return func(c *HMACConfig) {
+		c.Now = now
+	}
+}
+
+// NewHMACMiddleware creates a new request signature middleware with the given secret and options.
+// It panics if a secret is empty.
+func NewHMACMiddleware(secret []byte, opts ...HMACOption) *HMACMiddleware {
+	config := HMACConfig{
+		Secrets:         [][]byte{secret},
+		SignatureHeader: "X-Signature",
+		TimestampHeader: "X-Signature-Timestamp",
+		MaxClockSkew:    5 * time.Minute,
+		MaxBodySize:     DefaultHMACMaxBodySize,
+		Now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	for _, secret := range config.Secrets {
+		if len(secret) == 0 {
+			panic("auth: HMAC secrets must not be empty")
+		}
+	}
+
+	return &HMACMiddleware{config: config}
+}
+
+// GetConfig returns the middleware configuration
+func (m *HMACMiddleware) GetConfig() HMACConfig {
+	return m.config
+}
+
+// ComputeSignature returns the hex-encoded signature of a request.
+func ComputeSignature(secret []byte, method, requestURI, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + timestamp + "\n"))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest sets the signature and timestamp headers on an outgoing request,
+// using the default header names. The body is read and restored.
+func SignRequest(r *http.Request, secret []byte, at time.Time) error {
+	body, err := readBody(r, 0)
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	r.Header.Set("X-Signature-Timestamp", timestamp)
+	r.Header.Set("X-Signature", ComputeSignature(secret, r.Method, r.URL.RequestURI(), timestamp, body))
+
+	return nil
+}
+
+// Verify checks the signature and timestamp of a request. The body is read, up to
+// MaxBodySize, and restored.
+func (m *HMACMiddleware) Verify(r *http.Request) error {
+	signature := r.Header.Get(m.config.SignatureHeader)
+	timestamp := r.Header.Get(m.config.TimestampHeader)
+	if signature == "" || timestamp == "" {
+		return ErrSignatureMissing
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrTimestampInvalid, timestamp)
+	}
+	skew := m.config.Now().Sub(time.Unix(seconds, 0))
+	if skew > m.config.MaxClockSkew || skew < -m.config.MaxClockSkew {
+		return ErrTimestampExpired
+	}
+
+	body, err := readBody(r, m.config.MaxBodySize)
+	if err != nil {
+		return err
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrSignatureMismatch
+	}
+	for _, secret := range m.config.Secrets {
+		expected, _ := hex.DecodeString(ComputeSignature(secret, r.Method, r.URL.RequestURI(), timestamp, body))
+		if hmac.Equal(given, expected) {
+			return nil
+		}
+	}
+
+	return ErrSignatureMismatch
+}
+
+// HTTPMiddleware returns HTTP middleware function
+func (m *HMACMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := m.Verify(r); err != nil {
+				status := http.StatusUnauthorized
+				switch {
+				case errors.Is(err, ErrBodyTooLarge):
+					status = http.StatusRequestEntityTooLarge
+				case errors.Is(err, ErrBodyUnreadable):
+					status = http.StatusBadRequest
+				}
+				m.writeError(w, status, err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeError writes an error response
+func (m *HMACMiddleware) writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": message,
+	})
+}
+
+// readBody reads the request body and restores it for the next reader. A positive
+// limit caps the bytes read.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	reader := r.Body
+	if limit > 0 {
+		reader = http.MaxBytesReader(nil, r.Body, limit)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, tooLarge.Limit)
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrBodyUnreadable, err)
+	}
+	_ = r.Body.Close()
+
+	// Restore body for next handler
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	hmacSecret = []byte("current-secret")
+	hmacNow    = time.Unix(1_700_000_000, 0)
+)
+
+// echoBodyHandler writes the request body back, proving it was restored.
+func echoBodyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+}
+
+func newSignedRequest(t *testing.T, secret []byte, at time.Time, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/orders?source=billing", strings.NewReader(body))
+	require.NoError(t, SignRequest(req, secret, at))
+
+	return req
+}
+
+func TestHMACMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   func(t *testing.T) *http.Request
+		wantCode  int
+		wantError string
+	}{
+		{
+			name: "valid signature",
+			request: func(t *testing.T) *http.Request {
+				return newSignedRequest(t, hmacSecret, hmacNow.Add(-time.Minute), `{"id":"42"}`)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "previous secret during rotation",
+			request: func(t *testing.T) *http.Request {
+				return newSignedRequest(t, []byte("previous-secret"), hmacNow, `{"id":"42"}`)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "tampered body",
+			request: func(t *testing.T) *http.Request {
+				req := newSignedRequest(t, hmacSecret, hmacNow, `{"id":"42"}`)
+				req.Body = io.NopCloser(strings.NewReader(`{"id":"43"}`))
+
+				return req
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: ErrSignatureMismatch.Error(),
+		},
+		{
+			name: "tampered query",
+			request: func(t *testing.T) *http.Request {
+				req := newSignedRequest(t, hmacSecret, hmacNow, `{"id":"42"}`)
+				req.URL.RawQuery = "source=admin"
+
+				return req
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: ErrSignatureMismatch.Error(),
+		},
+		{
+			name: "unknown secret",
+			request: func(t *testing.T) *http.Request {
+				return newSignedRequest(t, []byte("other-secret"), hmacNow, `{"id":"42"}`)
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: ErrSignatureMismatch.Error(),
+		},
+		{
+			name: "expired timestamp",
+			request: func(t *testing.T) *http.Request {
+				return newSignedRequest(t, hmacSecret, hmacNow.Add(-10*time.Minute), `{"id":"42"}`)
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: ErrTimestampExpired.Error(),
+		},
+		{
+			name: "timestamp in the future",
+			request: func(t *testing.T) *http.Request {
+				return newSignedRequest(t, hmacSecret, hmacNow.Add(10*time.Minute), `{"id":"42"}`)
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: ErrTimestampExpired.Error(),
+		},
+		{
+			name: "missing signature",
+			request: func(*testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/webhooks/orders", strings.NewReader(`{"id":"42"}`))
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantError: ErrSignatureMissing.Error(),
+		},
+	}
+
+	middleware := NewHMACMiddleware(hmacSecret,
+		WithAdditionalSecrets([]byte("previous-secret")),
+		WithMaxClockSkew(5*time.Minute),
+		WithClock(func() time.Time { return hmacNow }),
+	)
+	handler := middleware.HTTPMiddleware()(echoBodyHandler())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.request(t))
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantError == "" {
+				assert.Equal(t, `{"id":"42"}`, w.Body.String(), "the handler reads the verified body")
+				return
+			}
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantError, body["error"])
+		})
+	}
+}
+
+func TestNewHMACMiddleware_Defaults(t *testing.T) {
+	config := NewHMACMiddleware(hmacSecret).GetConfig()
+
+	assert.Equal(t, "X-Signature", config.SignatureHeader)
+	assert.Equal(t, "X-Signature-Timestamp", config.TimestampHeader)
+	assert.Equal(t, 5*time.Minute, config.MaxClockSkew)
+	assert.Equal(t, int64(DefaultHMACMaxBodySize), config.MaxBodySize)
+	assert.Len(t, config.Secrets, 1)
+}
+
+func TestNewHMACMiddleware_RejectsEmptySecrets(t *testing.T) {
+	assert.Panics(t, func() { NewHMACMiddleware(nil) })
+	assert.Panics(t, func() { NewHMACMiddleware(hmacSecret, WithAdditionalSecrets([]byte{})) })
+}
+
+// failingReader fails every read.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestHMACMiddleware_BodyLimits(t *testing.T) {
+	middleware := NewHMACMiddleware(hmacSecret,
+		WithMaxBodySize(16),
+		WithClock(func() time.Time { return hmacNow }),
+	)
+	handler := middleware.HTTPMiddleware()(echoBodyHandler())
+
+	t.Run("too large", func(t *testing.T) {
+		req := newSignedRequest(t, hmacSecret, hmacNow, strings.Repeat("x", 17))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), ErrBodyTooLarge.Error())
+	})
+
+	t.Run("at the limit", func(t *testing.T) {
+		req := newSignedRequest(t, hmacSecret, hmacNow, strings.Repeat("x", 16))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unreadable", func(t *testing.T) {
+		req := newSignedRequest(t, hmacSecret, hmacNow, `{"id":"42"}`)
+		req.Body = io.NopCloser(failingReader{})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrBodyUnreadable.Error())
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
 )
 
 // Common errors
@@ -197,7 +198,9 @@ func (m *JWTMiddleware) ValidateToken(tokenString string) (jwt.MapClaims, error)
 	return claims, nil
 }
 
-// HTTPMiddleware returns HTTP middleware function
+// HTTPMiddleware returns HTTP middleware function. The authenticated user is stored under
+// UserContextKey and as the typedhttp principal, so request fields can bind it with the
+// principal tag, e.g. `principal:"id"`.
 func (m *JWTMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -222,8 +225,9 @@ func (m *JWTMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 				return
 			}
 
-			// Add user to context
+			// Add user to context, also as the principal bound to principal-tagged fields
 			ctx := context.WithValue(r.Context(), UserContextKey, user)
+			ctx = typedhttp.ContextWithPrincipal(ctx, user)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
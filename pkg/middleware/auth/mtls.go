@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// Client certificate errors
+var (
+	ErrClientCertMissing  = errors.New("verified client certificate missing")
+	ErrClientCertRejected = errors.New("client certificate not allowed")
+)
+
+// ClientIdentityContextKey is the context key of the verified ClientIdentity.
+const ClientIdentityContextKey contextKey = "client_identity"
+
+// ClientIdentity is the identity of a verified client certificate.
+type ClientIdentity struct {
+	CommonName     string            `json:"common_name"`
+	Organizations  []string          `json:"organizations,omitempty"`
+	Issuer         string            `json:"issuer"`
+	DNSNames       []string          `json:"dns_names,omitempty"`
+	EmailAddresses []string          `json:"email_addresses,omitempty"`
+	URIs           []string          `json:"uris,omitempty"`
+	Certificate    *x509.Certificate `json:"-"`
+}
+
+// ClientIdentityFromContext returns the identity stored by MTLSMiddleware.
+func ClientIdentityFromContext(ctx context.Context) (*ClientIdentity, bool) {
+	identity, ok := ctx.Value(ClientIdentityContextKey).(*ClientIdentity)
+
+	return identity, ok
+}
+
+// MTLSConfig holds client certificate policy configuration. Every non-empty list must
+// match: the certificate needs one of the allowed common names, one of the allowed
+// organizations, and so on.
+type MTLSConfig struct {
+	AllowedCommonNames   []string
+	AllowedOrganizations []string
+	// AllowedIssuers are the common names of the CAs that may issue client certificates.
+	AllowedIssuers []string
+	// AllowedSANs are the subject alternative names accepted: DNS names, email
+	// addresses or URIs. One match is enough.
+	AllowedSANs []string
+	// AllowInsecure lets plain HTTP requests pass through untouched, e.g. for health
+	// checks served on the same handler. By default they are rejected with 401.
+	AllowInsecure bool
+	// Verify applies additional checks to the verified client certificate.
+	Verify func(*x509.Certificate) error
+}
+
+// MTLSMiddleware authorizes requests by their client certificate.
+//
+// The certificate chain is verified by the TLS server, which must request client
+// certificates with tls.RequireAndVerifyClientCert or tls.VerifyClientCertIfGiven.
+// Plain HTTP requests and connections without a verified certificate are rejected with
+// 401 and certificates outside the policy with 403.
+type MTLSMiddleware struct {
+	config MTLSConfig
+}
+
+// MTLSOption configures mTLS middleware
+type MTLSOption func(*MTLSConfig)
+
+// WithAllowedCommonNames accepts certificates with one of the subject common names
+func WithAllowedCommonNames(names ...string) MTLSOption {
+	return func(c *MTLSConfig) {
+		c.AllowedCommonNames = append(c.AllowedCommonNames, names...)
+	}
+}
+
+// WithAllowedOrganizations accepts certificates with one of the subject organizations
+func WithAllowedOrganizations(organizations ...string) MTLSOption {
+	return func(c *MTLSConfig) {
+		c.AllowedOrganizations = append(c.AllowedOrganizations, organizations...)
+	}
+}
+
+// WithAllowedIssuers accepts certificates issued by a CA with one of the common names
+func WithAllowedIssuers(issuers ...string) MTLSOption {
+	return func(c *MTLSConfig) {
+		c.AllowedIssuers = append(c.AllowedIssuers, issuers...)
+	}
+}
+
+// WithAllowedSANs accepts certificates with one of the subject alternative names
+func WithAllowedSANs(names ...string) MTLSOption {
+	return func(c *MTLSConfig) {
+		c.AllowedSANs = append(c.AllowedSANs, names...)
+	}
+}
+
+// WithAllowInsecure lets requests that were not made over TLS through without a
+// client identity
+func WithAllowInsecure() MTLSOption {
+	return func(c *MTLSConfig) {
+		c.AllowInsecure = true
+	}
+}
+
+// WithCertificateVerifier applies additional checks to client certificates
+func WithCertificateVerifier(verify func(*x509.Certificate) error) MTLSOption {
+	return func(c *MTLSConfig) {
+		c.Verify = verify
+	}
+}
+
+// NewMTLSMiddleware creates a new client certificate middleware with the given options
+func NewMTLSMiddleware(opts ...MTLSOption) *MTLSMiddleware {
+	config := MTLSConfig{}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &MTLSMiddleware{config: config}
+}
+
+// GetConfig returns the middleware configuration
+func (m *MTLSMiddleware) GetConfig() MTLSConfig {
+	return m.config
+}
+
+// Authorize checks the verified client certificate of a TLS connection against the
+// policy and returns its identity.
+func (m *MTLSMiddleware) Authorize(state *tls.ConnectionState) (*ClientIdentity, error) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, ErrClientCertMissing
+	}
+
+	cert := state.VerifiedChains[0][0]
+	identity := newClientIdentity(cert)
+
+	if len(m.config.AllowedCommonNames) > 0 && !slices.Contains(m.config.AllowedCommonNames, identity.CommonName) {
+		return nil, fmt.Errorf("%w: common name %q", ErrClientCertRejected, identity.CommonName)
+	}
+	if len(m.config.AllowedOrganizations) > 0 && !containsAny(m.config.AllowedOrganizations, identity.Organizations) {
+		return nil, fmt.Errorf("%w: organization %v", ErrClientCertRejected, identity.Organizations)
+	}
+	if len(m.config.AllowedIssuers) > 0 && !slices.Contains(m.config.AllowedIssuers, identity.Issuer) {
+		return nil, fmt.Errorf("%w: issuer %q", ErrClientCertRejected, identity.Issuer)
+	}
+	if len(m.config.AllowedSANs) > 0 && !containsAny(m.config.AllowedSANs, identity.subjectAltNames()) {
+		return nil, fmt.Errorf("%w: subject alternative names %v", ErrClientCertRejected, identity.subjectAltNames())
+	}
+	if m.config.Verify != nil {
+		if err := m.config.Verify(cert); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrClientCertRejected, err)
+		}
+	}
+
+	return identity, nil
+}
+
+// HTTPMiddleware returns HTTP middleware function. The verified identity is stored under
+// ClientIdentityContextKey and as the typedhttp principal, so request fields can bind
+// it with the principal tag, e.g. `principal:"common_name"`.
+func (m *MTLSMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil && m.config.AllowInsecure {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			identity, err := m.Authorize(r.TLS)
+			if errors.Is(err, ErrClientCertMissing) {
+				m.writeError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+			if err != nil {
+				m.writeError(w, http.StatusForbidden, err.Error())
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ClientIdentityContextKey, identity)
+			ctx = typedhttp.ContextWithPrincipal(ctx, identity)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeError writes an error response
+func (m *MTLSMiddleware) writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": message,
+	})
+}
+
+// newClientIdentity extracts the identity of a certificate.
+func newClientIdentity(cert *x509.Certificate) *ClientIdentity {
+	identity := &ClientIdentity{
+		CommonName:     cert.Subject.CommonName,
+		Organizations:  cert.Subject.Organization,
+		Issuer:         cert.Issuer.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		Certificate:    cert,
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+
+	return identity
+}
+
+// subjectAltNames returns every subject alternative name of the identity.
+func (i *ClientIdentity) subjectAltNames() []string {
+	return slices.Concat(i.DNSNames, i.EmailAddresses, i.URIs)
+}
+
+// containsAny reports whether values contains one of allowed.
+func containsAny(allowed, values []string) bool {
+	for _, value := range values {
+		if slices.Contains(allowed, value) {
+			return true
+		}
+	}
+
+	return false
+}
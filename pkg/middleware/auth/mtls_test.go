@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clientCert returns a client certificate fixture issued by the named CA.
+func clientCert(commonName, issuer string, dnsNames ...string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:  pkix.Name{CommonName: commonName, Organization: []string{"Payments"}},
+		Issuer:   pkix.Name{CommonName: issuer},
+		DNSNames: dnsNames,
+		URIs:     []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/" + commonName}},
+	}
+}
+
+// verifiedState is the state of a TLS connection whose client certificate was verified.
+func verifiedState(cert *x509.Certificate) *tls.ConnectionState {
+	return &tls.ConnectionState{
+		HandshakeComplete: true,
+		PeerCertificates:  []*x509.Certificate{cert},
+		VerifiedChains:    [][]*x509.Certificate{{cert}},
+	}
+}
+
+// identityHandler writes the identity found in the context.
+func identityHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := ClientIdentityFromContext(r.Context())
+		if !ok {
+			_, _ = w.Write([]byte("anonymous"))
+			return
+		}
+		principal, _ := typedhttp.PrincipalFromContext(r.Context())
+		if principal != identity {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(identity.CommonName))
+	})
+}
+
+func TestMTLSMiddleware(t *testing.T) {
+	middleware := NewMTLSMiddleware(
+		WithAllowedIssuers("Internal CA"),
+		WithAllowedOrganizations("Payments"),
+		WithAllowedSANs("ledger.internal", "spiffe://example.org/settlement"),
+	)
+
+	tests := []struct {
+		name     string
+		state    *tls.ConnectionState
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "allowed DNS name",
+			state:    verifiedState(clientCert("ledger", "Internal CA", "ledger.internal")),
+			wantCode: http.StatusOK,
+			wantBody: "ledger",
+		},
+		{
+			name:     "allowed URI",
+			state:    verifiedState(clientCert("settlement", "Internal CA")),
+			wantCode: http.StatusOK,
+			wantBody: "settlement",
+		},
+		{
+			name:     "SAN not allowed",
+			state:    verifiedState(clientCert("reports", "Internal CA", "reports.internal")),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "untrusted issuer",
+			state:    verifiedState(clientCert("ledger", "Public CA", "ledger.internal")),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "no client certificate",
+			state:    &tls.ConnectionState{HandshakeComplete: true},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "unverified client certificate",
+			state: &tls.ConnectionState{
+				HandshakeComplete: true,
+				PeerCertificates:  []*x509.Certificate{clientCert("ledger", "Internal CA", "ledger.internal")},
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "plain HTTP",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/settlements", http.NoBody)
+			req.TLS = tt.state
+			w := httptest.NewRecorder()
+
+			middleware.HTTPMiddleware()(identityHandler()).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				var body map[string]string
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.NotEmpty(t, body["error"])
+
+				return
+			}
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestMTLSMiddleware_AllowInsecure(t *testing.T) {
+	middleware := NewMTLSMiddleware(WithAllowInsecure())
+
+	req := httptest.NewRequest(http.MethodGet, "/health", http.NoBody)
+	w := httptest.NewRecorder()
+	middleware.HTTPMiddleware()(identityHandler()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "anonymous", w.Body.String())
+
+	req.TLS = &tls.ConnectionState{HandshakeComplete: true}
+	w = httptest.NewRecorder()
+	middleware.HTTPMiddleware()(identityHandler()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "TLS requests still need a certificate")
+}
+
+func TestMTLSMiddleware_Authorize(t *testing.T) {
+	errRevoked := errors.New("certificate revoked")
+	middleware := NewMTLSMiddleware(
+		WithAllowedCommonNames("ledger"),
+		WithCertificateVerifier(func(cert *x509.Certificate) error {
+			if cert.Subject.CommonName == "ledger" && len(cert.DNSNames) == 0 {
+				return errRevoked
+			}
+
+			return nil
+		}),
+	)
+
+	identity, err := middleware.Authorize(verifiedState(clientCert("ledger", "Internal CA", "ledger.internal")))
+	require.NoError(t, err)
+	assert.Equal(t, "ledger", identity.CommonName)
+	assert.Equal(t, "Internal CA", identity.Issuer)
+	assert.Equal(t, []string{"ledger.internal"}, identity.DNSNames)
+	assert.Equal(t, []string{"spiffe://example.org/ledger"}, identity.URIs)
+
+	_, err = middleware.Authorize(verifiedState(clientCert("reports", "Internal CA")))
+	assert.ErrorIs(t, err, ErrClientCertRejected)
+
+	_, err = middleware.Authorize(verifiedState(clientCert("ledger", "Internal CA")))
+	assert.ErrorIs(t, err, ErrClientCertRejected)
+	assert.ErrorIs(t, err, errRevoked)
+
+	_, err = middleware.Authorize(nil)
+	assert.ErrorIs(t, err, ErrClientCertMissing)
+}
@@ -0,0 +1,327 @@
+// Package logging provides request logging middleware for operations tooling.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// AccessLogFormat is an Apache-style log template. Supported directives:
+//
+//	%h      client IP (first X-Forwarded-For entry when trusted)
+//	%l      remote logname, always "-"
+//	%u      user from the request URL or basic auth, "-" if none
+//	%t      request start time as [02/Jan/2006:15:04:05 -0700]
+//	%r      request line, e.g. GET /users?page=2 HTTP/1.1
+//	%m %U %q %H  method, path, query string (with "?") and protocol
+//	%s %>s  response status
+//	%b %B   response body bytes, %b writes "-" for zero
+//	%D %T   duration in microseconds and seconds
+//	%{Name}i %{Name}o  request and response header
+//	%%      a literal percent sign
+type AccessLogFormat string
+
+// Standard access log formats.
+const (
+	CommonLogFormat   AccessLogFormat = `%h %l %u %t "%r" %>s %b`
+	CombinedLogFormat AccessLogFormat = `%h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-Agent}i"`
+	// CombinedLogFormatWithDuration appends the duration in microseconds to CombinedLogFormat.
+	CombinedLogFormatWithDuration AccessLogFormat = CombinedLogFormat + ` %D`
+)
+
+// AccessLogConfig holds access log middleware configuration
+type AccessLogConfig struct {
+	Format AccessLogFormat
+	// TrustForwardedFor logs the first X-Forwarded-For entry as the client IP instead of
+	// the connection address. Only enable it behind a proxy that sets the header.
+	TrustForwardedFor bool
+	// Now returns the current time; tests replace it for stable output.
+	Now func() time.Time
+}
+
+// AccessLogMiddleware writes one line per request in an Apache-style log format
+type AccessLogMiddleware struct {
+	config   AccessLogConfig
+	segments []accessLogSegment
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// AccessLogOption configures access log middleware
+type AccessLogOption func(*AccessLogConfig)
+
+// WithTrustForwardedFor enables or disables reading the client IP from X-Forwarded-For
+func WithTrustForwardedFor(enabled bool) AccessLogOption {
+	return func(c *AccessLogConfig) {
+		c.TrustForwardedFor = enabled
+	}
+}
+
+// WithAccessLogClock sets the time source used for timestamps and durations
+func WithAccessLogClock(now func() time.Time) AccessLogOption {
+	return func(c *AccessLogConfig) {
+		c.Now = now
+	}
+}
+
+// NewAccessLogMiddleware creates an access log middleware writing to w. X-Forwarded-For
+// is trusted by default. It panics if format contains an unknown directive.
+func NewAccessLogMiddleware(w io.Writer, format AccessLogFormat, opts ...AccessLogOption) *AccessLogMiddleware {
+	config := AccessLogConfig{
+		Format:            format,
+		TrustForwardedFor: true,
+		Now:               time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	segments, err := parseAccessLogFormat(config.Format)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AccessLogMiddleware{
+		config:   config,
+		segments: segments,
+		out:      w,
+	}
+}
+
+// GetConfig returns the access log configuration
+func (m *AccessLogMiddleware) GetConfig() AccessLogConfig {
+	return m.config
+}
+
+// HTTPMiddleware returns HTTP middleware function
+func (m *AccessLogMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entry := &accessLogEntry{
+				request: r,
+				start:   m.config.Now(),
+				writer:  typedhttp.NewResponseRecorder(w),
+			}
+
+			next.ServeHTTP(entry.writer, r)
+			entry.duration = m.config.Now().Sub(entry.start)
+
+			m.write(entry)
+		})
+	}
+}
+
+// write formats entry and writes it as a single line.
+func (m *AccessLogMiddleware) write(entry *accessLogEntry) {
+	entry.trustForwardedFor = m.config.TrustForwardedFor
+
+	line := make([]byte, 0, 256)
+	for _, segment := range m.segments {
+		line = segment(line, entry)
+	}
+	line = append(line, '\n')
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, _ = m.out.Write(line)
+}
+
+// accessLogEntry is the data available to format directives.
+type accessLogEntry struct {
+	request           *http.Request
+	writer            *typedhttp.ResponseRecorder
+	start             time.Time
+	duration          time.Duration
+	trustForwardedFor bool
+}
+
+// accessLogSegment appends one part of a log line.
+type accessLogSegment func(line []byte, entry *accessLogEntry) []byte
+
+// parseAccessLogFormat compiles format into segments.
+func parseAccessLogFormat(format AccessLogFormat) ([]accessLogSegment, error) {
+	var segments []accessLogSegment
+	s := string(format)
+	for s != "" {
+		i := strings.IndexByte(s, '%')
+		if i < 0 {
+			segments = append(segments, literalSegment(s))
+
+			break
+		}
+		if i > 0 {
+			segments = append(segments, literalSegment(s[:i]))
+		}
+		s = s[i+1:]
+
+		var name string
+		if strings.HasPrefix(s, "{") {
+			end := strings.IndexByte(s, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("access log format %q: unterminated %%{", format)
+			}
+			name, s = s[1:end], s[end+1:]
+		}
+		s = strings.TrimPrefix(s, ">")
+		if s == "" {
+			return nil, fmt.Errorf("access log format %q: trailing %%", format)
+		}
+
+		segment, err := directiveSegment(s[0], name)
+		if err != nil {
+			return nil, fmt.Errorf("access log format %q: %w", format, err)
+		}
+		segments = append(segments, segment)
+		s = s[1:]
+	}
+
+	return segments, nil
+}
+
+func literalSegment(text string) accessLogSegment {
+	return func(line []byte, _ *accessLogEntry) []byte {
+		return append(line, text...)
+	}
+}
+
+// directiveSegment returns the segment for a directive such as 'h' or {Referer}'i'.
+func directiveSegment(directive byte, name string) (accessLogSegment, error) {
+	switch directive {
+	case 'i':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, e.request.Header.Get(name))
+		}, nil
+	case 'o':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, e.writer.Header().Get(name))
+		}, nil
+	}
+
+	if name != "" {
+		return nil, fmt.Errorf("%%{%s}%c takes no header name", name, directive)
+	}
+
+	switch directive {
+	case '%':
+		return literalSegment("%"), nil
+	case 'h':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, clientIP(e.request, e.trustForwardedFor))
+		}, nil
+	case 'l':
+		return literalSegment("-"), nil
+	case 'u':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, requestUser(e.request))
+		}, nil
+	case 't':
+		return func(line []byte, e *accessLogEntry) []byte {
+			line = append(line, '[')
+			line = e.start.AppendFormat(line, "02/Jan/2006:15:04:05 -0700")
+
+			return append(line, ']')
+		}, nil
+	case 'r':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, e.request.Method+" "+e.request.URL.RequestURI()+" "+e.request.Proto)
+		}, nil
+	case 'm':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, e.request.Method)
+		}, nil
+	case 'U':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, e.request.URL.EscapedPath())
+		}, nil
+	case 'q':
+		return func(line []byte, e *accessLogEntry) []byte {
+			if e.request.URL.RawQuery == "" {
+				return line
+			}
+
+			return appendField(line, "?"+e.request.URL.RawQuery)
+		}, nil
+	case 'H':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return appendField(line, e.request.Proto)
+		}, nil
+	case 's':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return strconv.AppendInt(line, int64(e.writer.Status()), 10)
+		}, nil
+	case 'b':
+		return func(line []byte, e *accessLogEntry) []byte {
+			if e.writer.BytesWritten() == 0 {
+				return append(line, '-')
+			}
+
+			return strconv.AppendInt(line, e.writer.BytesWritten(), 10)
+		}, nil
+	case 'B':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return strconv.AppendInt(line, e.writer.BytesWritten(), 10)
+		}, nil
+	case 'D':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return strconv.AppendInt(line, e.duration.Microseconds(), 10)
+		}, nil
+	case 'T':
+		return func(line []byte, e *accessLogEntry) []byte {
+			return strconv.AppendInt(line, int64(e.duration/time.Second), 10)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown directive %%%c", directive)
+	}
+}
+
+// appendField appends value with quotes, backslashes and control characters escaped,
+// or "-" when it is empty.
+func appendField(line []byte, value string) []byte {
+	if value == "" {
+		return append(line, '-')
+	}
+
+	quoted := strconv.AppendQuote(nil, value)
+
+	return append(line, quoted[1:len(quoted)-1]...)
+}
+
+// clientIP returns the originating client address of r.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			first, _, _ := strings.Cut(forwardedFor, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
+
+// requestUser returns the user named in the request URL or basic auth credentials.
+func requestUser(r *http.Request) string {
+	if r.URL.User != nil {
+		return r.URL.User.Username()
+	}
+	if user, _, ok := r.BasicAuth(); ok {
+		return user
+	}
+
+	return ""
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedClock(start time.Time, step time.Duration) func() time.Time {
+	current := start.Add(-step)
+
+	return func() time.Time {
+		current = current.Add(step)
+
+		return current
+	}
+}
+
+func serveAccessLog(t *testing.T, m *AccessLogMiddleware, handler http.HandlerFunc, r *http.Request) {
+	t.Helper()
+
+	m.HTTPMiddleware()(handler).ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func TestAccessLogMiddleware_CombinedFormat(t *testing.T) {
+	var out bytes.Buffer
+	start := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	m := NewAccessLogMiddleware(&out, CombinedLogFormatWithDuration,
+		WithAccessLogClock(fixedClock(start, 1500*time.Microsecond)))
+
+	r := httptest.NewRequest(http.MethodPost, "/users?notify=true", strings.NewReader(`{}`))
+	r.RemoteAddr = "10.0.0.7:51234"
+	r.Header.Set("Referer", "https://example.com/signup")
+	r.Header.Set("User-Agent", `curl/8.4 "beta"`)
+
+	serveAccessLog(t, m, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"u-1"}`))
+	}, r)
+
+	assert.Equal(t,
+		`10.0.0.7 - - [05/Mar/2024:14:07:09 +0000] "POST /users?notify=true HTTP/1.1" 201 12 `+
+			`"https://example.com/signup" "curl/8.4 \"beta\"" 1500`+"\n",
+		out.String())
+}
+
+func TestAccessLogMiddleware_CommonFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		suffix string
+	}{
+		{name: "implicit 200", body: "hello", suffix: `"GET /health HTTP/1.1" 200 5` + "\n"},
+		{name: "no body", status: http.StatusNoContent, suffix: `"GET /health HTTP/1.1" 204 -` + "\n"},
+		{name: "error", status: http.StatusServiceUnavailable, body: "unavailable",
+			suffix: `"GET /health HTTP/1.1" 503 11` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			m := NewAccessLogMiddleware(&out, CommonLogFormat)
+
+			serveAccessLog(t, m, func(w http.ResponseWriter, _ *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write([]byte(tt.body))
+			}, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			assert.True(t, strings.HasSuffix(out.String(), tt.suffix), out.String())
+		})
+	}
+}
+
+func TestAccessLogMiddleware_ClientIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		forwardedFor  string
+		trustForwards bool
+		expected      string
+	}{
+		{name: "remote addr", trustForwards: true, expected: "192.0.2.1"},
+		{name: "first forwarded entry", forwardedFor: "203.0.113.9, 10.0.0.2", trustForwards: true,
+			expected: "203.0.113.9"},
+		{name: "forwarded for not trusted", forwardedFor: "203.0.113.9", expected: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			m := NewAccessLogMiddleware(&out, "%h", WithTrustForwardedFor(tt.trustForwards))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			serveAccessLog(t, m, func(http.ResponseWriter, *http.Request) {}, r)
+
+			assert.Equal(t, tt.expected+"\n", out.String())
+		})
+	}
+}
+
+func TestAccessLogMiddleware_CustomFormat(t *testing.T) {
+	var out bytes.Buffer
+	m := NewAccessLogMiddleware(&out, `%m %U%q %s %B %{X-Request-ID}o %u 100%%`)
+
+	r := httptest.NewRequest(http.MethodDelete, "/items/7?hard=1", nil)
+	r.SetBasicAuth("alice", "secret")
+	serveAccessLog(t, m, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-ID", "req-42")
+		w.WriteHeader(http.StatusNoContent)
+	}, r)
+
+	assert.Equal(t, "DELETE /items/7?hard=1 204 0 req-42 alice 100%\n", out.String())
+}
+
+func TestAccessLogMiddleware_InvalidFormat(t *testing.T) {
+	for _, format := range []AccessLogFormat{"%z", "%{Referer", "trailing %", "%{X}h"} {
+		assert.Panics(t, func() { NewAccessLogMiddleware(&bytes.Buffer{}, format) }, format)
+	}
+}
+
+func TestAccessLogMiddleware_PreservesFlusher(t *testing.T) {
+	var out bytes.Buffer
+	m := NewAccessLogMiddleware(&out, "%s %b")
+
+	rec := httptest.NewRecorder()
+	m.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("chunk"))
+		require.NoError(t, http.NewResponseController(w).Flush())
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "200 5\n", out.String())
+}
@@ -0,0 +1,455 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// RecordingVersion is the version of the recording format written by RecordingMiddleware.
+const RecordingVersion = 1
+
+// Redacted replaces the values of redacted headers and body fields in recordings.
+const Redacted = "[REDACTED]"
+
+// Unredactable replaces bodies that cannot be checked for redacted fields, such as
+// bodies that are not JSON, when WithRedactedFields is used.
+const Unredactable = "[unredactable]"
+
+// DefaultRedactedHeaders are the headers redacted unless WithRedactedHeaders replaces them.
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// Recording is a recorded request and its response. It marshals to stable JSON, so
+// recordings can be stored, diffed and replayed later with testutil.Replay.
+type Recording struct {
+	Version    int              `json:"version"`
+	RecordedAt time.Time        `json:"recorded_at"`
+	DurationMS int64            `json:"duration_ms"`
+	Request    RecordedRequest  `json:"request"`
+	Response   RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded part of a request.
+type RecordedRequest struct {
+	Method string       `json:"method"`
+	URL    string       `json:"url"` // Path and query, as in the request line
+	Header http.Header  `json:"headers,omitempty"`
+	Body   RecordedBody `json:"body"`
+}
+
+// RecordedResponse is the recorded part of a response.
+type RecordedResponse struct {
+	Status int          `json:"status"`
+	Header http.Header  `json:"headers,omitempty"`
+	Body   RecordedBody `json:"body"`
+}
+
+// RecordedBody is a recorded message body. Text is kept as is; other bodies are base64
+// encoded. Truncated is set when the body was longer than the recording limit.
+type RecordedBody struct {
+	Data      string `json:"data,omitempty"`
+	Encoding  string `json:"encoding,omitempty"` // "" for text, "base64" otherwise
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// NewRecordedBody records data as a body.
+func NewRecordedBody(data []byte) RecordedBody {
+	if utf8.Valid(data) {
+		return RecordedBody{Data: string(data)}
+	}
+
+	return RecordedBody{Data: base64.StdEncoding.EncodeToString(data), Encoding: "base64"}
+}
+
+// Bytes returns the recorded body content.
+func (b RecordedBody) Bytes() ([]byte, error) {
+	if b.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(b.Data)
+	}
+
+	return []byte(b.Data), nil
+}
+
+// RecordingSink stores recordings, e.g. in a file or an object store. Record is called
+// after the response is written and must be safe for concurrent use.
+type RecordingSink interface {
+	Record(ctx context.Context, recording *Recording) error
+}
+
+// RecordingSinkFunc adapts a function to RecordingSink.
+type RecordingSinkFunc func(ctx context.Context, recording *Recording) error
+
+// Record implements RecordingSink.
+func (f RecordingSinkFunc) Record(ctx context.Context, recording *Recording) error {
+	return f(ctx, recording)
+}
+
+// JSONLinesSink writes each recording as one line of JSON.
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewJSONLinesSink creates a sink writing to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{out: w}
+}
+
+// Record implements RecordingSink.
+func (s *JSONLinesSink) Record(_ context.Context, recording *Recording) error {
+	line, err := json.Marshal(recording)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.out.Write(line)
+
+	return err
+}
+
+// RecordingConfig holds recording middleware configuration
+type RecordingConfig struct {
+	// SampleRate is the fraction of requests recorded, from 0 to 1.
+	SampleRate float64
+	// RedactedHeaders are replaced with Redacted in requests and responses.
+	RedactedHeaders []string
+	// RedactedFields are JSON object keys whose values are replaced with Redacted at
+	// any depth of JSON bodies, matched case-insensitively.
+	RedactedFields []string
+	// MaxBodyBytes limits the recorded size of each body.
+	MaxBodyBytes int64
+	// ErrorHandler receives errors returned by the sink. They never affect the response.
+	ErrorHandler func(ctx context.Context, err error)
+	// Now returns the current time; tests replace it for stable output.
+	Now func() time.Time
+}
+
+// RecordingMiddleware records sampled requests and their responses to a sink, to
+// reproduce production issues. Sensitive headers and JSON fields are redacted before
+// the recording leaves the middleware.
+type RecordingMiddleware struct {
+	config RecordingConfig
+	sink   RecordingSink
+}
+
+// RecordingOption configures recording middleware
+type RecordingOption func(*RecordingConfig)
+
+// WithSampleRate records the given fraction of requests
+func WithSampleRate(rate float64) RecordingOption {
+	return func(c *RecordingConfig) {
+		c.SampleRate = rate
+	}
+}
+
+// WithRedactedHeaders sets the redacted headers, replacing DefaultRedactedHeaders
+func WithRedactedHeaders(headers ...string) RecordingOption {
+	return func(c *RecordingConfig) {
+		c.RedactedHeaders = headers
+	}
+}
+
+// WithRedactedFields redacts the JSON body fields with the given names
+func WithRedactedFields(fields ...string) RecordingOption {
+	return func(c *RecordingConfig) {
+		c.RedactedFields = append(c.RedactedFields, fields...)
+	}
+}
+
+// WithMaxBodyBytes limits the recorded size of request and response bodies
+func WithMaxBodyBytes(limit int64) RecordingOption {
+	return func(c *RecordingConfig) {
+		c.MaxBodyBytes = limit
+	}
+}
+
+// WithRecordingErrorHandler receives errors returned by the sink
+func WithRecordingErrorHandler(handler func(ctx context.Context, err error)) RecordingOption {
+	return func(c *RecordingConfig) {
+		c.ErrorHandler = handler
+	}
+}
+
+// WithRecordingClock sets the time source used for timestamps and durations
+func WithRecordingClock(now func() time.Time) RecordingOption {
+	return func(c *RecordingConfig) {
+		c.Now = now
+	}
+}
+
+// NewRecordingMiddleware creates a recording middleware writing to sink. By default
+// every request is recorded, DefaultRedactedHeaders are redacted and bodies are
+// limited to 64 KiB.
+func NewRecordingMiddleware(sink RecordingSink, opts ...RecordingOption) *RecordingMiddleware {
+	config := RecordingConfig{
+		SampleRate:      1,
+		RedactedHeaders: DefaultRedactedHeaders,
+		MaxBodyBytes:    64 << 10,
+		Now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &RecordingMiddleware{config: config, sink: sink}
+}
+
+// GetConfig returns the recording configuration
+func (m *RecordingMiddleware) GetConfig() RecordingConfig {
+	return m.config
+}
+
+// HTTPMiddleware returns HTTP middleware function
+func (m *RecordingMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if m.config.SampleRate < 1 && rand.Float64() >= m.config.SampleRate {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := m.config.Now()
+			requestBody := m.captureRequestBody(r)
+			writer := &recordingWriter{
+				ResponseRecorder: typedhttp.NewResponseRecorder(w),
+				limit:            m.config.MaxBodyBytes,
+			}
+
+			next.ServeHTTP(writer, r)
+
+			recording := &Recording{
+				Version:    RecordingVersion,
+				RecordedAt: start.UTC(),
+				DurationMS: m.config.Now().Sub(start).Milliseconds(),
+				Request: RecordedRequest{
+					Method: r.Method,
+					URL:    r.URL.RequestURI(),
+					Header: m.redactHeader(r.Header),
+					Body:   m.redactBody(requestBody.Bytes(), requestBody.truncated),
+				},
+				Response: RecordedResponse{
+					Status: writer.Status(),
+					Header: m.redactHeader(writer.Header()),
+					Body:   m.redactBody(writer.body.Bytes(), writer.truncated),
+				},
+			}
+
+			if err := m.sink.Record(r.Context(), recording); err != nil && m.config.ErrorHandler != nil {
+				m.config.ErrorHandler(r.Context(), err)
+			}
+		})
+	}
+}
+
+// capturedBody is the recorded prefix of a request body.
+type capturedBody struct {
+	bytes.Buffer
+	truncated bool
+}
+
+// captureRequestBody records up to MaxBodyBytes of the request body. The body is
+// restored for the handler without buffering more than the recorded prefix.
+func (m *RecordingMiddleware) captureRequestBody(r *http.Request) *capturedBody {
+	captured := &capturedBody{}
+	if r.Body == nil || r.Body == http.NoBody {
+		return captured
+	}
+
+	_, _ = io.CopyN(&captured.Buffer, r.Body, m.config.MaxBodyBytes+1)
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(bytes.Clone(captured.Bytes())), r.Body), Closer: r.Body}
+	if int64(captured.Len()) > m.config.MaxBodyBytes {
+		captured.truncated = true
+		captured.Truncate(int(m.config.MaxBodyBytes))
+	}
+
+	return captured
+}
+
+// redactHeader returns a copy of header with the redacted headers replaced.
+func (m *RecordingMiddleware) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := header.Clone()
+	for _, name := range m.config.RedactedHeaders {
+		if values := redacted.Values(name); len(values) > 0 {
+			redacted[http.CanonicalHeaderKey(name)] = []string{Redacted}
+		}
+	}
+
+	return redacted
+}
+
+// redactBody records data, replacing the redacted fields of a JSON body. A truncated
+// body is redacted up to where it was cut. Bodies that are not JSON are recorded as
+// Unredactable rather than risk recording a redacted field.
+func (m *RecordingMiddleware) redactBody(data []byte, truncated bool) RecordedBody {
+	if len(m.config.RedactedFields) > 0 && len(bytes.TrimSpace(data)) > 0 {
+		redacted, err := scrubJSON(data, m.config.RedactedFields, truncated)
+		if err != nil {
+			return RecordedBody{Data: Unredactable, Truncated: truncated}
+		}
+		data = redacted
+	}
+
+	body := NewRecordedBody(data)
+	body.Truncated = truncated
+
+	return body
+}
+
+// scrubJSON re-encodes the JSON document in data token by token, replacing the values
+// of the redacted fields at any depth. When truncated is set, data may end anywhere and
+// the document is redacted up to its last complete token. It fails for data that is
+// not JSON.
+func scrubJSON(data []byte, fields []string, truncated bool) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var out bytes.Buffer
+	var open []json.Delim // Containers being written
+	var written []int     // Keys and values written to each open container
+	redactValue := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) || (truncated && errors.Is(err, io.ErrUnexpectedEOF)) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		delim, isDelim := token.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			out.WriteByte(byte(delim))
+			open, written = open[:len(open)-1], written[:len(written)-1]
+
+			continue
+		}
+
+		// Separators, and whether the token is an object key
+		isKey := false
+		if depth := len(open); depth > 0 {
+			n := written[depth-1]
+			written[depth-1]++
+			if open[depth-1] == '{' {
+				isKey = n%2 == 0
+				if isKey && n > 0 {
+					out.WriteByte(',')
+				}
+			} else if n > 0 {
+				out.WriteByte(',')
+			}
+		}
+
+		switch {
+		case isKey:
+			key, _ := token.(string)
+			writeJSONToken(&out, key)
+			out.WriteByte(':')
+			redactValue = containsFold(fields, key)
+		case redactValue:
+			redactValue = false
+			writeJSONToken(&out, Redacted)
+			if isDelim {
+				if err := skipJSONValue(decoder); err != nil && !truncated {
+					return nil, err
+				}
+			}
+		case isDelim:
+			out.WriteByte(byte(delim))
+			open, written = append(open, delim), append(written, 0)
+		default:
+			writeJSONToken(&out, token)
+		}
+	}
+
+	if len(open) > 0 && !truncated {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	return out.Bytes(), nil
+}
+
+// skipJSONValue consumes the rest of an object or array whose opening delimiter was read.
+func skipJSONValue(decoder *json.Decoder) error {
+	for depth := 1; depth > 0; {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+
+	return nil
+}
+
+// writeJSONToken writes a scalar token as JSON.
+func writeJSONToken(out *bytes.Buffer, token json.Token) {
+	if number, ok := token.(json.Number); ok {
+		out.WriteString(number.String())
+
+		return
+	}
+
+	encoded, _ := json.Marshal(token)
+	out.Write(encoded)
+}
+
+// containsFold reports whether names contains name, ignoring case.
+func containsFold(names []string, name string) bool {
+	for _, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordingWriter records up to limit bytes of the response body.
+type recordingWriter struct {
+	*typedhttp.ResponseRecorder
+	body      bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if room := w.limit - int64(w.body.Len()); room < int64(len(b)) {
+		w.body.Write(b[:max(room, 0)])
+		w.truncated = true
+	} else {
+		w.body.Write(b)
+	}
+
+	return w.ResponseRecorder.Write(b)
+}
+
+// readCloser joins a reader with the closer of the original body.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
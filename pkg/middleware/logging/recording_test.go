@@ -0,0 +1,199 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loginHandler echoes the request body and sets a session cookie.
+func loginHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t"})
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}
+}
+
+func serveRecorded(t *testing.T, m *RecordingMiddleware, handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	m.HTTPMiddleware()(handler).ServeHTTP(w, r)
+
+	return w
+}
+
+func TestRecordingMiddleware(t *testing.T) {
+	var out bytes.Buffer
+	start := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	m := NewRecordingMiddleware(NewJSONLinesSink(&out),
+		WithRedactedFields("password"),
+		WithRecordingClock(fixedClock(start, 25*time.Millisecond)))
+
+	r := httptest.NewRequest(http.MethodPost, "/sessions?remember=true",
+		strings.NewReader(`{"user":"ada","password":"hunter2","factors":[{"password":"1234"}]}`))
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("Content-Type", "application/json")
+
+	w := serveRecorded(t, m, loginHandler(), r)
+
+	// The handler saw the full body and the client got the unredacted response
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "hunter2")
+
+	var recording Recording
+	require.NoError(t, json.Unmarshal(out.Bytes(), &recording))
+
+	assert.Equal(t, RecordingVersion, recording.Version)
+	assert.Equal(t, start, recording.RecordedAt)
+	assert.Equal(t, int64(25), recording.DurationMS)
+
+	assert.Equal(t, http.MethodPost, recording.Request.Method)
+	assert.Equal(t, "/sessions?remember=true", recording.Request.URL)
+	assert.Equal(t, []string{Redacted}, recording.Request.Header["Authorization"])
+	assert.Equal(t, "application/json", recording.Request.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"user":"ada","password":"[REDACTED]","factors":[{"password":"[REDACTED]"}]}`,
+		recording.Request.Body.Data)
+
+	assert.Equal(t, http.StatusCreated, recording.Response.Status)
+	assert.Equal(t, []string{Redacted}, recording.Response.Header["Set-Cookie"])
+	assert.JSONEq(t, recording.Request.Body.Data, recording.Response.Body.Data)
+}
+
+func TestRecordingMiddleware_StableFormat(t *testing.T) {
+	var out bytes.Buffer
+	m := NewRecordingMiddleware(NewJSONLinesSink(&out),
+		WithRecordingClock(fixedClock(time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC), time.Millisecond)))
+
+	r := httptest.NewRequest(http.MethodGet, "/health", http.NoBody)
+	serveRecorded(t, m, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}), r)
+
+	assert.Equal(t, `{"version":1,"recorded_at":"2024-03-05T14:07:09Z","duration_ms":1,`+
+		`"request":{"method":"GET","url":"/health","body":{}},`+
+		`"response":{"status":200,"headers":{"Content-Type":["text/plain; charset=utf-8"]},"body":{"data":"ok"}}}`+"\n",
+		out.String())
+}
+
+func TestRecordingMiddleware_Bodies(t *testing.T) {
+	var recordings []*Recording
+	sink := RecordingSinkFunc(func(_ context.Context, recording *Recording) error {
+		recordings = append(recordings, recording)
+
+		return nil
+	})
+	m := NewRecordingMiddleware(sink, WithMaxBodyBytes(8))
+
+	t.Run("truncated", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("0123456789abcdef"))
+		w := serveRecorded(t, m, loginHandler(), r)
+
+		assert.Equal(t, "0123456789abcdef", w.Body.String())
+		recording := recordings[len(recordings)-1]
+		assert.Equal(t, RecordedBody{Data: "01234567", Truncated: true}, recording.Request.Body)
+		assert.Equal(t, RecordedBody{Data: "01234567", Truncated: true}, recording.Response.Body)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/upload", bytes.NewReader([]byte{0xff, 0x00, 0xfe}))
+		serveRecorded(t, m, loginHandler(), r)
+
+		body := recordings[len(recordings)-1].Request.Body
+		assert.Equal(t, "base64", body.Encoding)
+		data, err := body.Bytes()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0x00, 0xfe}, data)
+	})
+}
+
+func TestRecordingMiddleware_RedactsTruncatedBodies(t *testing.T) {
+	var recordings []*Recording
+	sink := RecordingSinkFunc(func(_ context.Context, recording *Recording) error {
+		recordings = append(recordings, recording)
+
+		return nil
+	})
+	body := `{"user":"ada","password":"hunter2","profile":{"password":["a","b"],"bio":"` +
+		strings.Repeat("x", 64) + `"},"id":12345678901234567890}`
+	m := NewRecordingMiddleware(sink, WithRedactedFields("password"), WithMaxBodyBytes(80))
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "cut inside a string",
+			body: body,
+			want: `{"user":"ada","password":"[REDACTED]","profile":{"password":"[REDACTED]","bio":`,
+		},
+		{
+			name: "cut inside a redacted value",
+			body: `{"user":"ada","password":"` + strings.Repeat("s", 100) + `"}`,
+			want: `{"user":"ada","password":`,
+		},
+		{
+			name: "complete",
+			body: `{"password":"hunter2","id":12345678901234567890}`,
+			want: `{"password":"[REDACTED]","id":12345678901234567890}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(tt.body))
+			serveRecorded(t, m, loginHandler(), r)
+
+			recorded := recordings[len(recordings)-1].Request.Body
+			assert.Equal(t, tt.want, recorded.Data)
+			assert.NotContains(t, recorded.Data, "hunter2")
+			assert.NotContains(t, recorded.Data, "sss")
+			assert.Equal(t, len(tt.body) > 80, recorded.Truncated)
+		})
+	}
+}
+
+func TestRecordingMiddleware_NonJSONBodiesAreUnredactable(t *testing.T) {
+	var recordings []*Recording
+	sink := RecordingSinkFunc(func(_ context.Context, recording *Recording) error {
+		recordings = append(recordings, recording)
+
+		return nil
+	})
+	m := NewRecordingMiddleware(sink, WithRedactedFields("password"))
+
+	r := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader("user=ada&password=hunter2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	serveRecorded(t, m, loginHandler(), r)
+
+	recording := recordings[len(recordings)-1]
+	assert.Equal(t, RecordedBody{Data: Unredactable}, recording.Request.Body)
+	assert.Equal(t, RecordedBody{Data: Unredactable}, recording.Response.Body)
+}
+
+func TestRecordingMiddleware_Sampling(t *testing.T) {
+	calls := 0
+	sink := RecordingSinkFunc(func(context.Context, *Recording) error {
+		calls++
+
+		return nil
+	})
+	m := NewRecordingMiddleware(sink, WithSampleRate(0))
+
+	w := serveRecorded(t, m, loginHandler(), httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Zero(t, calls)
+}
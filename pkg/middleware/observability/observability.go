@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
 )
 
 // LoggingConfig holds logging middleware configuration
@@ -108,7 +110,7 @@ func (m *LoggingMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 			}
 
 			// Wrap response writer to capture status code
-			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			rw := typedhttp.NewResponseRecorder(w)
 
 			// Process request
 			next.ServeHTTP(rw, r)
@@ -120,7 +122,7 @@ func (m *LoggingMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 					"event", "request_completed",
 					"method", r.Method,
 					"path", r.URL.Path,
-					"status_code", rw.statusCode,
+					"status_code", rw.Status(),
 					"duration_ms", duration.Milliseconds(),
 				}
 
@@ -212,17 +214,6 @@ func (m *LoggingMiddleware) fieldsToAttrs(fields []interface{}) []slog.Attr {
 	return attrs
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code
-type responseWriter struct {
-	http.ResponseWriter
-	statusCode int
-}
-
-func (rw *responseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
-}
-
 // MetricsConfig holds metrics middleware configuration
 type MetricsConfig struct {
 	ServiceName            string
@@ -328,14 +319,14 @@ func (m *MetricsMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 			start := time.Now()
 
 			// Wrap response writer to capture status code
-			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			rw := typedhttp.NewResponseRecorder(w)
 
 			// Process request
 			next.ServeHTTP(rw, r)
 
 			// Collect metrics
 			duration := time.Since(start)
-			m.recordHTTPMetrics(rw.statusCode, duration)
+			m.recordHTTPMetrics(rw.Status(), duration)
 		})
 	}
 }
@@ -0,0 +1,80 @@
+package processing
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// RequireContentTypeMiddleware rejects POST, PUT and PATCH requests whose body is not
+// in one of the allowed media types with 415 Unsupported Media Type, before any decoder runs.
+// Requests without a body and other methods pass through unchecked.
+type RequireContentTypeMiddleware struct {
+	types []string
+}
+
+// NewRequireContentTypeMiddleware creates a middleware accepting the given media types,
+// e.g. "application/json" or "multipart/*". It defaults to application/json.
+func NewRequireContentTypeMiddleware(types ...string) *RequireContentTypeMiddleware {
+	if len(types) == 0 {
+		types = []string{"application/json"}
+	}
+
+	return &RequireContentTypeMiddleware{
+		types: types,
+	}
+}
+
+// AllowedTypes returns the accepted media types
+func (m *RequireContentTypeMiddleware) AllowedTypes() []string {
+	return m.types
+}
+
+// HTTPMiddleware returns HTTP middleware function
+func (m *RequireContentTypeMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutation(r.Method) || r.ContentLength == 0 || m.allowed(r.Header.Get("Content-Type")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			accepted := strings.Join(m.types, ", ")
+			w.Header().Set("Accept-Post", accepted)
+			if r.Method == http.MethodPatch {
+				w.Header().Set("Accept-Patch", accepted)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"error": "unsupported content type, expected one of: " + accepted,
+			})
+		})
+	}
+}
+
+// allowed reports whether the Content-Type header matches an accepted media type.
+func (m *RequireContentTypeMiddleware) allowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, accepted := range m.types {
+		accepted = strings.ToLower(accepted)
+		if accepted == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(accepted, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMutation reports whether the method sends a request body to be stored.
+func isMutation(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
@@ -0,0 +1,57 @@
+package processing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireContentTypeMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{name: "correct content type", method: http.MethodPost, contentType: "application/json", body: `{}`, wantCode: http.StatusOK},
+		{name: "parameters and case are ignored", method: http.MethodPut, contentType: "Application/JSON; charset=utf-8", body: `{}`, wantCode: http.StatusOK},
+		{name: "wildcard subtype", method: http.MethodPost, contentType: "multipart/form-data; boundary=x", body: "--x--", wantCode: http.StatusOK},
+		{name: "missing content type", method: http.MethodPost, body: `{}`, wantCode: http.StatusUnsupportedMediaType},
+		{name: "wrong content type", method: http.MethodPatch, contentType: "text/plain", body: `{}`, wantCode: http.StatusUnsupportedMediaType},
+		{name: "malformed content type", method: http.MethodPost, contentType: "application/", body: `{}`, wantCode: http.StatusUnsupportedMediaType},
+		{name: "request without body", method: http.MethodPost, wantCode: http.StatusOK},
+		{name: "GET is unaffected", method: http.MethodGet, contentType: "text/plain", body: "ignored", wantCode: http.StatusOK},
+	}
+
+	middleware := NewRequireContentTypeMiddleware("application/json", "multipart/*")
+	handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/orders", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusUnsupportedMediaType {
+				assert.Equal(t, "application/json, multipart/*", w.Header().Get("Accept-Post"))
+				assert.Contains(t, w.Body.String(), "unsupported content type")
+			}
+		})
+	}
+}
+
+func TestRequireContentTypeMiddleware_Defaults(t *testing.T) {
+	middleware := NewRequireContentTypeMiddleware()
+
+	assert.Equal(t, []string{"application/json"}, middleware.AllowedTypes())
+}
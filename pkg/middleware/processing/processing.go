@@ -14,6 +14,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
 )
 
 // Validation constants and types
@@ -378,6 +381,13 @@ const (
 	DefaultCompressionLevel = 6
 	BestCompression        = 9
 	DefaultMinSize         = 1024
+	DefaultBrotliLevel     = brotli.DefaultCompression
+)
+
+// Supported content encodings
+const (
+	EncodingGzip   = "gzip"
+	EncodingBrotli = "br"
 )
 
 // CompressionConfig holds compression middleware configuration
@@ -386,6 +396,10 @@ type CompressionConfig struct {
 	Types   []string
 	MinSize int
 	Headers map[string]string
+	// BrotliLevel is the Brotli quality, from 0 (fastest) to 11 (best)
+	BrotliLevel int
+	// DisableBrotli restricts the middleware to gzip
+	DisableBrotli bool
 }
 
 // CompressionMiddleware provides response compression functionality
@@ -424,13 +438,28 @@ func WithCompressionHeaders(headers map[string]string) CompressionOption {
 	}
 }
 
+// WithBrotliLevel sets the Brotli compression quality
+func WithBrotliLevel(level int) CompressionOption {
+	return func(c *CompressionConfig) {
+		c.BrotliLevel = level
+	}
+}
+
+// WithoutBrotli disables Brotli so that only gzip is negotiated
+func WithoutBrotli() CompressionOption {
+	return func(c *CompressionConfig) {
+		c.DisableBrotli = true
+	}
+}
+
 // NewCompressionMiddleware creates a new compression middleware
 func NewCompressionMiddleware(opts ...CompressionOption) *CompressionMiddleware {
 	config := CompressionConfig{
-		Level:   DefaultCompressionLevel,
-		Types:   []string{"application/json", "text/html", "text/plain", "text/css", "application/javascript"},
-		MinSize: DefaultMinSize,
-		Headers: make(map[string]string),
+		Level:       DefaultCompressionLevel,
+		Types:       []string{"application/json", "text/html", "text/plain", "text/css", "application/javascript"},
+		MinSize:     DefaultMinSize,
+		Headers:     make(map[string]string),
+		BrotliLevel: DefaultBrotliLevel,
 	}
 
 	for _, opt := range opts {
@@ -451,8 +480,12 @@ func (m *CompressionMiddleware) GetConfig() CompressionConfig {
 func (m *CompressionMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Check if client accepts gzip compression
-			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			// The response representation depends on Accept-Encoding either way
+			typedhttp.AddVary(w.Header(), "Accept-Encoding")
+
+			// Pick the best encoding the client accepts
+			encoding := m.negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -462,18 +495,56 @@ func (m *CompressionMiddleware) HTTPMiddleware() func(http.Handler) http.Handler
 				ResponseWriter: w,
 				middleware:     m,
 				request:        r,
+				encoding:       encoding,
 			}
+			defer cw.Close()
 
 			next.ServeHTTP(cw, r)
 		})
 	}
 }
 
+// negotiateEncoding selects the supported encoding with the highest q-value in an
+// Accept-Encoding header. Ties prefer Brotli over gzip. It returns "" if none is acceptable.
+func (m *CompressionMiddleware) negotiateEncoding(acceptEncoding string) string {
+	supported := []string{EncodingGzip}
+	if !m.config.DisableBrotli {
+		supported = []string{EncodingBrotli, EncodingGzip}
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, encoding := range supported {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+		if ok && quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+
+	return best
+}
+
 // Before implements TypedPreMiddleware interface
 func (m *CompressionMiddleware) Before(ctx context.Context, req interface{}) (context.Context, error) {
 	// Add compression context if accept-encoding header indicates support
 	if acceptEncoding, ok := ctx.Value("accept_encoding").(string); ok {
-		if strings.Contains(acceptEncoding, "gzip") {
+		if m.negotiateEncoding(acceptEncoding) != "" {
 			ctx = context.WithValue(ctx, "compression_enabled", true)
 		}
 	}
@@ -492,51 +563,149 @@ type compressionWriter struct {
 	http.ResponseWriter
 	middleware *CompressionMiddleware
 	request    *http.Request
-	buffer     bytes.Buffer
-	gzWriter   *gzip.Writer
+	encoding   string
+	encoder    io.WriteCloser
+	status     int
 	wrote      bool
 }
 
+// WriteHeader holds the status back until the first Write, when it is known
+// whether the body will be compressed
 func (cw *compressionWriter) WriteHeader(statusCode int) {
-	cw.ResponseWriter.WriteHeader(statusCode)
+	if statusCode >= 100 && statusCode < 200 {
+		cw.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+
+	if !cw.wrote && cw.status == 0 {
+		cw.status = statusCode
+	}
 }
 
 func (cw *compressionWriter) Write(data []byte) (int, error) {
 	if !cw.wrote {
-		cw.wrote = true
-		
-		// Check if content should be compressed
-		contentType := cw.Header().Get("Content-Type")
-		if cw.shouldCompress(contentType, len(data)) {
-			cw.Header().Set("Content-Encoding", "gzip")
-			cw.Header().Del("Content-Length") // Let gzip set this
-			
-			// Add custom headers
-			for k, v := range cw.middleware.config.Headers {
-				cw.Header().Set(k, v)
-			}
-			
-			// Initialize gzip writer
-			cw.gzWriter, _ = gzip.NewWriterLevel(cw.ResponseWriter, cw.middleware.config.Level)
-			defer cw.gzWriter.Close()
-			
-			return cw.gzWriter.Write(data)
-		}
+		cw.start(data)
 	}
 	
-	if cw.gzWriter != nil {
-		return cw.gzWriter.Write(data)
+	if cw.encoder != nil {
+		return cw.encoder.Write(data)
 	}
 	
 	return cw.ResponseWriter.Write(data)
 }
 
-func (cw *compressionWriter) shouldCompress(contentType string, size int) bool {
-	// Check minimum size
+// start makes the compression decision from the headers and the first chunk, then sends the headers
+func (cw *compressionWriter) start(data []byte) {
+	cw.wrote = true
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	// net/http does not sniff encoded bodies, so detect the type while it is still plain
+	if cw.Header().Get("Content-Type") == "" && len(data) > 0 && bodyAllowed(cw.status) {
+		cw.Header().Set("Content-Type", sniffContentType(data))
+	}
+
+	if bodyAllowed(cw.status) && cw.shouldCompress(cw.Header(), len(data)) {
+		cw.Header().Set("Content-Encoding", cw.encoding)
+		cw.Header().Del("Content-Length") // Length of the compressed body is unknown
+
+		// Add custom headers
+		for k, v := range cw.middleware.config.Headers {
+			cw.Header().Set(k, v)
+		}
+
+		cw.encoder = cw.newEncoder()
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+// newEncoder creates the compressing writer for the negotiated encoding
+func (cw *compressionWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == EncodingBrotli {
+		return brotli.NewWriterLevel(cw.ResponseWriter, cw.middleware.config.BrotliLevel)
+	}
+
+	gz, err := gzip.NewWriterLevel(cw.ResponseWriter, cw.middleware.config.Level)
+	if err != nil {
+		gz = gzip.NewWriter(cw.ResponseWriter)
+	}
+
+	return gz
+}
+
+// Flush sends the held back headers and any compressed data buffered so far
+func (cw *compressionWriter) Flush() {
+	if !cw.wrote {
+		cw.start(nil)
+	}
+
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (cw *compressionWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Close sends a held back status of an empty response and flushes any buffered compressed data
+func (cw *compressionWriter) Close() error {
+	if !cw.wrote && cw.status != 0 {
+		cw.wrote = true
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+
+	if cw.encoder == nil {
+		return nil
+	}
+
+	return cw.encoder.Close()
+}
+
+// alreadyCompressedTypes are media type prefixes that gain nothing from compression
+var alreadyCompressedTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-brotli",
+	"application/x-bzip2",
+	"application/x-xz",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/zstd",
+}
+
+func (cw *compressionWriter) shouldCompress(header http.Header, size int) bool {
+	// Never encode a body twice
+	if encoding := header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		return false
+	}
+
+	// Check minimum size, trusting a declared length over the first chunk
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil {
+		size = length
+	}
 	if size < cw.middleware.config.MinSize {
 		return false
 	}
 
+	// Skip formats that are compressed already, even if configured
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if isAlreadyCompressed(contentType) {
+		return false
+	}
+
 	// Check content type
 	for _, t := range cw.middleware.config.Types {
 		if strings.Contains(contentType, t) {
@@ -547,6 +716,44 @@ func (cw *compressionWriter) shouldCompress(contentType string, size int) bool {
 	return false
 }
 
+// sniffContentType detects the media type of a body from its first bytes.
+// JSON is recognised by its opening delimiter, which http.DetectContentType reports as plain text.
+func sniffContentType(data []byte) string {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return "application/json"
+	}
+
+	return http.DetectContentType(data)
+}
+
+// isAlreadyCompressed reports whether the content type is a compressed format
+func isAlreadyCompressed(contentType string) bool {
+	if strings.HasPrefix(contentType, "image/svg+xml") {
+		return false
+	}
+
+	for _, prefix := range alreadyCompressedTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bodyAllowed reports whether a response with the status may carry a body
+func bodyAllowed(statusCode int) bool {
+	switch {
+	case statusCode >= 100 && statusCode < 200:
+		return false
+	case statusCode == http.StatusNoContent, statusCode == http.StatusNotModified:
+		return false
+	}
+
+	return true
+}
+
 // CORS configuration and middleware
 type CORSConfig struct {
 	AllowedOrigins   []string
@@ -644,8 +851,8 @@ func (m *CORSMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 				return
 			}
 
-			// Handle preflight request
-			if r.Method == http.MethodOptions {
+			// Handle preflight request; plain OPTIONS requests fall through to the router
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
 				m.handlePreflight(w, r)
 				return
 			}
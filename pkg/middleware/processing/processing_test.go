@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/andybalholm/brotli"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -249,6 +250,21 @@ func TestCompressionMiddleware_HTTPMiddleware(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), largeData)
 	})
 	
+	t.Run("vary_accept_encoding", func(t *testing.T) {
+		for _, encoding := range []string{"gzip", ""} {
+			req := httptest.NewRequest(http.MethodGet, "/data", nil)
+			if encoding != "" {
+				req.Header.Set("Accept-Encoding", encoding)
+			}
+			rr := httptest.NewRecorder()
+			rr.Header().Set("Vary", "Origin")
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, "Origin, Accept-Encoding", rr.Header().Get("Vary"))
+		}
+	})
+
 	t.Run("small_content_not_compressed", func(t *testing.T) {
 		smallHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
@@ -269,6 +285,259 @@ func TestCompressionMiddleware_HTTPMiddleware(t *testing.T) {
 	})
 }
 
+// TestCompressionMiddleware_EncodingNegotiation tests Brotli and gzip selection
+func TestCompressionMiddleware_EncodingNegotiation(t *testing.T) {
+	largeData := strings.Repeat("This is test data for compression. ", 100)
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(largeData[:len(largeData)/2]))
+		w.Write([]byte(largeData[len(largeData)/2:]))
+	})
+
+	decode := func(t *testing.T, encoding string, body io.Reader) string {
+		t.Helper()
+
+		var reader io.Reader
+		switch encoding {
+		case EncodingBrotli:
+			reader = brotli.NewReader(body)
+		case EncodingGzip:
+			gz, err := gzip.NewReader(body)
+			require.NoError(t, err)
+			reader = gz
+		default:
+			reader = body
+		}
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		opts           []CompressionOption
+		expected       string
+	}{
+		{name: "brotli capable client", acceptEncoding: "gzip, deflate, br", expected: EncodingBrotli},
+		{name: "gzip only client", acceptEncoding: "gzip", expected: EncodingGzip},
+		{name: "q-values prefer gzip", acceptEncoding: "br;q=0.5, gzip;q=0.8", expected: EncodingGzip},
+		{name: "brotli refused", acceptEncoding: "br;q=0, *", expected: EncodingGzip},
+		{name: "wildcard", acceptEncoding: "*", expected: EncodingBrotli},
+		{name: "brotli disabled", acceptEncoding: "br, gzip", opts: []CompressionOption{WithoutBrotli()}, expected: EncodingGzip},
+		{name: "brotli disabled and gzip not accepted", acceptEncoding: "br", opts: []CompressionOption{WithoutBrotli()}, expected: ""},
+		{name: "identity only", acceptEncoding: "identity", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewCompressionMiddleware(tt.opts...).HTTPMiddleware()(testHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/data", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expected, rr.Header().Get("Content-Encoding"))
+			assert.Equal(t, largeData, decode(t, tt.expected, rr.Body))
+		})
+	}
+}
+
+// TestCompressionMiddleware_SkipsCompressedResponses tests that encoded or compressed bodies pass through
+func TestCompressionMiddleware_SkipsCompressedResponses(t *testing.T) {
+	payload := []byte(strings.Repeat("x", 4096))
+
+	tests := []struct {
+		name        string
+		contentType string
+		encoding    string
+	}{
+		{name: "pre-gzipped file", contentType: "application/json", encoding: "gzip"},
+		{name: "png image", contentType: "image/png"},
+		{name: "mp4 video", contentType: "video/mp4"},
+		{name: "zip archive", contentType: "application/zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewCompressionMiddleware(WithCompressionTypes([]string{tt.contentType}))
+			handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write(payload)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/file", nil)
+			req.Header.Set("Accept-Encoding", "br, gzip")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.encoding, rr.Header().Get("Content-Encoding"))
+			assert.Equal(t, payload, rr.Body.Bytes(), "body must not be re-encoded")
+		})
+	}
+}
+
+// TestCompressionMiddleware_WriteHeaderPath tests that headers are settled before the status is sent
+func TestCompressionMiddleware_WriteHeaderPath(t *testing.T) {
+	largeData := strings.Repeat("This is test data for compression. ", 100)
+	middleware := NewCompressionMiddleware()
+
+	t.Run("stale content length removed", func(t *testing.T) {
+		handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", fmt.Sprint(len(largeData)))
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(largeData))
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/data", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		result := rr.Result()
+		assert.Equal(t, http.StatusAccepted, result.StatusCode)
+		assert.Equal(t, "gzip", result.Header.Get("Content-Encoding"))
+		assert.Empty(t, result.Header.Get("Content-Length"))
+	})
+
+	t.Run("status without body", func(t *testing.T) {
+		handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		req := httptest.NewRequest(http.MethodDelete, "/data", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Encoding"))
+		assert.Zero(t, rr.Body.Len())
+	})
+}
+
+// TestCompressionMiddleware_HeaderBuffering tests that status and headers wait for the first Write
+func TestCompressionMiddleware_HeaderBuffering(t *testing.T) {
+	largeData := strings.Repeat("This is test data for compression. ", 100)
+	middleware := NewCompressionMiddleware()
+
+	serve := func(handler http.HandlerFunc) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/data", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rr := httptest.NewRecorder()
+		middleware.HTTPMiddleware()(handler).ServeHTTP(rr, req)
+
+		return rr.Result()
+	}
+
+	t.Run("explicit WriteHeader before Write", func(t *testing.T) {
+		result := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(largeData))
+		})
+		defer result.Body.Close()
+
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, "gzip", result.Header.Get("Content-Encoding"))
+
+		gzReader, err := gzip.NewReader(result.Body)
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(gzReader)
+		require.NoError(t, err)
+		assert.Equal(t, largeData, string(decompressed))
+	})
+
+	t.Run("status flushed when nothing is written", func(t *testing.T) {
+		result := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "/data/1")
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		assert.Equal(t, http.StatusCreated, result.StatusCode)
+		assert.Equal(t, "/data/1", result.Header.Get("Location"))
+		assert.Empty(t, result.Header.Get("Content-Encoding"))
+	})
+
+	t.Run("flush sends headers early", func(t *testing.T) {
+		result := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusAccepted)
+			http.NewResponseController(w).Flush()
+			w.Write([]byte(largeData))
+		})
+
+		assert.Equal(t, http.StatusAccepted, result.StatusCode)
+		assert.Empty(t, result.Header.Get("Content-Encoding"), "an unknown body size is left uncompressed")
+	})
+}
+
+// TestCompressionMiddleware_SniffsContentType tests compression of bodies written without a Content-Type
+func TestCompressionMiddleware_SniffsContentType(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		minSize      int
+		wantType     string
+		wantEncoding string
+	}{
+		{
+			name:         "json object",
+			body:         "{\"items\":[" + strings.Repeat("{\"name\":\"widget\"},", 100) + "{}]}",
+			minSize:      DefaultMinSize,
+			wantType:     "application/json",
+			wantEncoding: "gzip",
+		},
+		{
+			name:         "json smaller than sniff window",
+			body:         " [1,2,3]",
+			minSize:      0,
+			wantType:     "application/json",
+			wantEncoding: "gzip",
+		},
+		{
+			name:     "binary body",
+			body:     "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 2048),
+			minSize:  DefaultMinSize,
+			wantType: "image/png",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewCompressionMiddleware(WithMinCompressionSize(tt.minSize))
+			handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/data", nil)
+			req.Header.Set("Accept-Encoding", "gzip")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantEncoding, rr.Header().Get("Content-Encoding"))
+
+			body := rr.Body.Bytes()
+			if tt.wantEncoding == "gzip" {
+				reader, err := gzip.NewReader(rr.Body)
+				require.NoError(t, err)
+				body, err = io.ReadAll(reader)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
 // TestCompressionMiddleware_TypedMiddleware tests compression as typed middleware
 func TestCompressionMiddleware_TypedMiddleware(t *testing.T) {
 	middleware := NewCompressionMiddleware()
@@ -397,6 +666,18 @@ func TestCORSMiddleware_HTTPMiddleware(t *testing.T) {
 		
 		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 	})
+
+	t.Run("plain_options_request_passes_through", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+		req.Header.Set("Origin", "https://example.com")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "https://example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, rr.Body.String(), "CORS test")
+	})
 }
 
 // TestCORSMiddleware_TypedMiddleware tests CORS as typed middleware
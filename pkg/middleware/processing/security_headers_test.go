@@ -0,0 +1,89 @@
+package processing
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveSecurityHeaders(middleware *SecurityHeadersMiddleware, r *http.Request) http.Header {
+	handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+
+	return rr.Header()
+}
+
+func TestSecurityHeadersMiddleware_Defaults(t *testing.T) {
+	middleware := NewSecurityHeadersMiddleware()
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/users", nil)
+	req.TLS = &tls.ConnectionState{}
+	headers := serveSecurityHeaders(middleware, req)
+
+	assert.Equal(t, "nosniff", headers.Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", headers.Get("X-Frame-Options"))
+	assert.Equal(t, "max-age=63072000; includeSubDomains", headers.Get("Strict-Transport-Security"))
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", headers.Get("Content-Security-Policy"))
+	assert.Equal(t, "no-referrer", headers.Get("Referrer-Policy"))
+}
+
+func TestSecurityHeadersMiddleware_HSTSOnlyOverHTTPS(t *testing.T) {
+	t.Run("plain HTTP", func(t *testing.T) {
+		headers := serveSecurityHeaders(NewSecurityHeadersMiddleware(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Empty(t, headers.Get("Strict-Transport-Security"))
+		assert.Equal(t, "nosniff", headers.Get("X-Content-Type-Options"))
+	})
+
+	t.Run("forwarded proto is ignored unless trusted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+
+		assert.Empty(t, serveSecurityHeaders(NewSecurityHeadersMiddleware(), req).Get("Strict-Transport-Security"))
+		assert.NotEmpty(t, serveSecurityHeaders(
+			NewSecurityHeadersMiddleware(WithTrustForwardedProto()), req,
+		).Get("Strict-Transport-Security"))
+	})
+}
+
+func TestSecurityHeadersMiddleware_Overrides(t *testing.T) {
+	middleware := NewSecurityHeadersMiddleware(
+		WithFrameOptions("SAMEORIGIN"),
+		WithHSTS(24*time.Hour, false),
+		WithContentSecurityPolicy("default-src 'self'"),
+		WithReferrerPolicy("strict-origin"),
+		WithoutSecurityHeader("x-content-type-options"),
+		WithSecurityHeader("permissions-policy", "camera=()"),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{}
+	headers := serveSecurityHeaders(middleware, req)
+
+	assert.Equal(t, "SAMEORIGIN", headers.Get("X-Frame-Options"))
+	assert.Equal(t, "max-age=86400", headers.Get("Strict-Transport-Security"))
+	assert.Equal(t, "default-src 'self'", headers.Get("Content-Security-Policy"))
+	assert.Equal(t, "strict-origin", headers.Get("Referrer-Policy"))
+	assert.Equal(t, "camera=()", headers.Get("Permissions-Policy"))
+	assert.NotContains(t, headers, "X-Content-Type-Options")
+}
+
+func TestSecurityHeadersMiddleware_HandlerOverrides(t *testing.T) {
+	handler := NewSecurityHeadersMiddleware().HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Security-Policy", "default-src 'self'")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, "default-src 'self'", rr.Header().Get("Content-Security-Policy"))
+}
@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrConcurrencyLimitExceeded is reported when every in-flight slot is taken.
+var ErrConcurrencyLimitExceeded = errors.New("concurrency limit exceeded")
+
+// ConcurrencyScope selects which requests share the in-flight limit.
+type ConcurrencyScope int
+
+const (
+	// ScopeGlobal shares one limit across every route the middleware wraps.
+	ScopeGlobal ConcurrencyScope = iota
+	// ScopePerRoute gives each wrapped route its own limit.
+	ScopePerRoute
+)
+
+// ConcurrencyLimitConfig holds concurrency limit configuration
+type ConcurrencyLimitConfig struct {
+	MaxInFlight int
+	// QueueTimeout is how long a request waits for a free slot before it is shed.
+	// Zero sheds immediately.
+	QueueTimeout time.Duration
+	Scope        ConcurrencyScope
+}
+
+// ConcurrencyLimitOption configures concurrency limit middleware
+type ConcurrencyLimitOption func(*ConcurrencyLimitConfig)
+
+// WithQueueTimeout lets requests wait up to timeout for a free slot
+func WithQueueTimeout(timeout time.Duration) ConcurrencyLimitOption {
+	return func(c *ConcurrencyLimitConfig) {
+		c.QueueTimeout = timeout
+	}
+}
+
+// WithConcurrencyScope sets whether the limit is global or per route
+func WithConcurrencyScope(scope ConcurrencyScope) ConcurrencyLimitOption {
+	return func(c *ConcurrencyLimitConfig) {
+		c.Scope = scope
+	}
+}
+
+// ConcurrencyLimitMiddleware caps the number of requests handled at the same time
+// and answers the excess with 503 Service Unavailable instead of queueing them.
+type ConcurrencyLimitMiddleware struct {
+	config ConcurrencyLimitConfig
+	global chan struct{}
+}
+
+// NewConcurrencyLimitMiddleware creates a middleware allowing at most max in-flight requests.
+// It panics if max is not positive, since such a limit would shed every request.
+func NewConcurrencyLimitMiddleware(max int, opts ...ConcurrencyLimitOption) *ConcurrencyLimitMiddleware {
+	config := ConcurrencyLimitConfig{
+		MaxInFlight: max,
+		Scope:       ScopeGlobal,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.MaxInFlight <= 0 {
+		panic(fmt.Sprintf("ratelimit: MaxInFlight must be positive, got %d", config.MaxInFlight))
+	}
+
+	return &ConcurrencyLimitMiddleware{
+		config: config,
+		global: make(chan struct{}, config.MaxInFlight),
+	}
+}
+
+// GetConfig returns the middleware configuration
+func (m *ConcurrencyLimitMiddleware) GetConfig() ConcurrencyLimitConfig {
+	return m.config
+}
+
+// HTTPMiddleware returns HTTP middleware function. With ScopePerRoute every handler
+// it wraps gets its own slots; with ScopeGlobal they share the middleware's slots.
+func (m *ConcurrencyLimitMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		slots := m.global
+		if m.config.Scope == ScopePerRoute {
+			slots = make(chan struct{}, m.config.MaxInFlight)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.acquire(r, slots) {
+				m.writeConcurrencyLimitError(w)
+				return
+			}
+			// Deferred so the slot is freed even when next panics
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// acquire takes a slot, waiting up to the queue timeout. It gives up early when
+// the request context is cancelled.
+func (m *ConcurrencyLimitMiddleware) acquire(r *http.Request, slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if m.config.QueueTimeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(m.config.QueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// writeConcurrencyLimitError writes a service unavailable error response
+func (m *ConcurrencyLimitMiddleware) writeConcurrencyLimitError(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", "1")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error": ErrConcurrencyLimitExceeded.Error(),
+	})
+}
@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingHandler holds every request until release is closed.
+type blockingHandler struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{entered: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (h *blockingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.entered <- struct{}{}
+	<-h.release
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveConcurrently sends n requests in parallel and reports each status code as it completes.
+func serveConcurrently(handler http.Handler, n int) <-chan int {
+	codes := make(chan int, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			codes <- w.Code
+		}()
+	}
+
+	return codes
+}
+
+func TestNewConcurrencyLimitMiddleware_RejectsNonPositiveLimit(t *testing.T) {
+	assert.PanicsWithValue(t, "ratelimit: MaxInFlight must be positive, got 0", func() { NewConcurrencyLimitMiddleware(0) })
+	assert.Panics(t, func() { NewConcurrencyLimitMiddleware(-1) })
+}
+
+func TestConcurrencyLimitMiddleware_ShedsExcessRequests(t *testing.T) {
+	backend := newBlockingHandler()
+	handler := NewConcurrencyLimitMiddleware(3).HTTPMiddleware()(backend)
+
+	codes := serveConcurrently(handler, 8)
+	for i := 0; i < 3; i++ {
+		<-backend.entered
+	}
+
+	// With every slot held, the five other requests are shed without waiting
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusServiceUnavailable, <-codes)
+	}
+
+	close(backend.release)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, <-codes)
+	}
+}
+
+func TestConcurrencyLimitMiddleware_QueueTimeout(t *testing.T) {
+	backend := newBlockingHandler()
+	handler := NewConcurrencyLimitMiddleware(1, WithQueueTimeout(20*time.Millisecond)).HTTPMiddleware()(backend)
+
+	go serveConcurrently(handler, 1)
+	<-backend.entered
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	close(backend.release)
+}
+
+func TestConcurrencyLimitMiddleware_QueuedRequestGetsFreedSlot(t *testing.T) {
+	backend := newBlockingHandler()
+	handler := NewConcurrencyLimitMiddleware(1, WithQueueTimeout(time.Second)).HTTPMiddleware()(backend)
+
+	go serveConcurrently(handler, 1)
+	<-backend.entered
+
+	done := make(chan int)
+	go func() {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		done <- w.Code
+	}()
+
+	close(backend.release)
+	assert.Equal(t, http.StatusOK, <-done)
+}
+
+func TestConcurrencyLimitMiddleware_CancelledWhileQueued(t *testing.T) {
+	backend := newBlockingHandler()
+	handler := NewConcurrencyLimitMiddleware(1, WithQueueTimeout(time.Minute)).HTTPMiddleware()(backend)
+
+	go serveConcurrently(handler, 1)
+	<-backend.entered
+	defer close(backend.release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestConcurrencyLimitMiddleware_ReleasesSlotOnPanic(t *testing.T) {
+	middleware := NewConcurrencyLimitMiddleware(1)
+	panicking := middleware.HTTPMiddleware()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	ok := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	assert.Panics(t, func() {
+		panicking.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	})
+
+	w := httptest.NewRecorder()
+	ok.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestConcurrencyLimitMiddleware_Scope(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      ConcurrencyScope
+		wantStatus int
+	}{
+		{name: "global limit is shared", scope: ScopeGlobal, wantStatus: http.StatusServiceUnavailable},
+		{name: "per route limits are independent", scope: ScopePerRoute, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewConcurrencyLimitMiddleware(1, WithConcurrencyScope(tt.scope))
+			backend := newBlockingHandler()
+			busy := middleware.HTTPMiddleware()(backend)
+			other := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			go serveConcurrently(busy, 1)
+			<-backend.entered
+			defer close(backend.release)
+
+			w := httptest.NewRecorder()
+			other.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
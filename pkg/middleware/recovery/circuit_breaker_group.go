@@ -0,0 +1,96 @@
+package recovery
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// CircuitBreakerKeyFunc returns the key selecting the breaker for a request.
+type CircuitBreakerKeyFunc func(r *http.Request) string
+
+// CircuitBreakerGroup keeps an independent circuit breaker per key, so a failing
+// endpoint only opens its own breaker. Breakers are created on first use.
+type CircuitBreakerGroup struct {
+	name     string
+	keyFunc  CircuitBreakerKeyFunc
+	opts     []CircuitBreakerOption
+	breakers sync.Map // key -> *CircuitBreakerMiddleware
+}
+
+// CircuitBreakerGroupOption configures a CircuitBreakerGroup.
+type CircuitBreakerGroupOption func(*CircuitBreakerGroup)
+
+// WithBreakerKeyFunc sets how requests are mapped to breakers, e.g. by upstream host.
+func WithBreakerKeyFunc(fn CircuitBreakerKeyFunc) CircuitBreakerGroupOption {
+	return func(g *CircuitBreakerGroup) {
+		g.keyFunc = fn
+	}
+}
+
+// WithBreakerOptions sets the options every breaker in the group is created with.
+func WithBreakerOptions(opts ...CircuitBreakerOption) CircuitBreakerGroupOption {
+	return func(g *CircuitBreakerGroup) {
+		g.opts = append(g.opts, opts...)
+	}
+}
+
+// NewCircuitBreakerGroup creates a group of breakers keyed by route pattern by default.
+func NewCircuitBreakerGroup(name string, opts ...CircuitBreakerGroupOption) *CircuitBreakerGroup {
+	group := &CircuitBreakerGroup{
+		name:    name,
+		keyFunc: RouteKey,
+	}
+
+	for _, opt := range opts {
+		opt(group)
+	}
+
+	return group
+}
+
+// RouteKey keys requests by the typedhttp route pattern, falling back to the
+// http.ServeMux pattern. Requests matching neither share the "" breaker.
+func RouteKey(r *http.Request) string {
+	if pattern, ok := typedhttp.RoutePatternFromContext(r.Context()); ok {
+		return pattern.String()
+	}
+
+	return r.Pattern
+}
+
+// Breaker returns the breaker for key, creating it if needed.
+func (g *CircuitBreakerGroup) Breaker(key string) *CircuitBreakerMiddleware {
+	if breaker, ok := g.breakers.Load(key); ok {
+		return breaker.(*CircuitBreakerMiddleware)
+	}
+
+	serviceName := g.name
+	if key != "" {
+		serviceName += " " + key
+	}
+	breaker, _ := g.breakers.LoadOrStore(key, NewCircuitBreakerMiddleware(serviceName, g.opts...))
+
+	return breaker.(*CircuitBreakerMiddleware)
+}
+
+// States returns the current state of every breaker created so far.
+func (g *CircuitBreakerGroup) States() map[string]CircuitBreakerState {
+	states := make(map[string]CircuitBreakerState)
+	g.breakers.Range(func(key, breaker any) bool {
+		states[key.(string)] = breaker.(*CircuitBreakerMiddleware).GetState()
+		return true
+	})
+
+	return states
+}
+
+// HTTPMiddleware returns HTTP middleware applying the request's breaker.
+func (g *CircuitBreakerGroup) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			g.Breaker(g.keyFunc(r)).HTTPMiddleware()(next).ServeHTTP(w, r)
+		})
+	}
+}
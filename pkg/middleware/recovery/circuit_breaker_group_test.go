@@ -0,0 +1,92 @@
+package recovery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreakerGroup_IsolatesRoutes(t *testing.T) {
+	group := NewCircuitBreakerGroup("gateway", WithBreakerOptions(
+		WithFailureThreshold(2),
+		WithRecoveryTimeout(time.Minute),
+	))
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /a", group.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})))
+	mux.Handle("GET /b", group.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	serve := func(path string) int {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusBadGateway, serve("/a"))
+	assert.Equal(t, http.StatusBadGateway, serve("/a"))
+	assert.Equal(t, http.StatusServiceUnavailable, serve("/a"), "breaker for route A is open")
+
+	assert.Equal(t, http.StatusOK, serve("/b"), "route B keeps its own closed breaker")
+	assert.Equal(t, map[string]CircuitBreakerState{
+		"GET /a": StateOpen,
+		"GET /b": StateClosed,
+	}, group.States())
+	assert.Equal(t, "gateway GET /a", group.Breaker("GET /a").GetConfig().ServiceName)
+}
+
+func TestCircuitBreakerGroup_CustomKeyFunc(t *testing.T) {
+	group := NewCircuitBreakerGroup("upstreams",
+		WithBreakerKeyFunc(func(r *http.Request) string { return r.Header.Get("X-Upstream") }),
+		WithBreakerOptions(WithFailureThreshold(1)),
+	)
+	handler := group.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Upstream") == "billing" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(upstream string) int {
+		req := httptest.NewRequest(http.MethodGet, "/proxy", nil)
+		req.Header.Set("X-Upstream", upstream)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, serve("billing"))
+	assert.Equal(t, http.StatusServiceUnavailable, serve("billing"))
+	assert.Equal(t, http.StatusOK, serve("catalog"))
+}
+
+func TestCircuitBreakerGroup_ConcurrentKeys(t *testing.T) {
+	group := NewCircuitBreakerGroup("gateway")
+
+	var wg sync.WaitGroup
+	breakers := make([]*CircuitBreakerMiddleware, 50)
+	for i := range breakers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			breakers[i] = group.Breaker("GET /shared")
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, group.States(), 1)
+	for _, breaker := range breakers {
+		assert.Same(t, breakers[0], breaker)
+	}
+}
@@ -1,22 +1,30 @@
 package recovery
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math"
+	"math/rand/v2"
 	"net/http"
 	"runtime/debug"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
 )
 
 // Common errors
 var (
 	ErrCircuitBreakerOpen = errors.New("circuit breaker open")
 	ErrMaxRetriesExceeded = errors.New("maximum retries exceeded")
+	ErrBudgetExhausted    = errors.New("request budget exhausted")
 )
 
 // Panic Recovery Middleware
@@ -26,6 +34,7 @@ type PanicRecoveryConfig struct {
 	IncludeStackTrace bool
 	StatusCode        int
 	PanicHandler      func(interface{}) error
+	ErrorMapper       typedhttp.ErrorMapper
 }
 
 type PanicRecoveryMiddleware struct {
@@ -69,6 +78,17 @@ func WithPanicHandler(handler func(interface{}) error) PanicRecoveryOption {
 	}
 }
 
+// WithPanicErrorMapper maps the error of a recovered panic like a handler error, so a
+// panic carrying a typed error such as typedhttp.NotFoundError keeps its status code.
+// The error is the one returned by the panic handler or, without one, a
+// typedhttp.PanicError wrapping the panic value. Pass &typedhttp.DefaultErrorMapper{}
+// to use the router's default mapping.
+func WithPanicErrorMapper(mapper typedhttp.ErrorMapper) PanicRecoveryOption {
+	return func(c *PanicRecoveryConfig) {
+		c.ErrorMapper = mapper
+	}
+}
+
 // NewPanicRecoveryMiddleware creates a new panic recovery middleware
 func NewPanicRecoveryMiddleware(opts ...PanicRecoveryOption) *PanicRecoveryMiddleware {
 	config := PanicRecoveryConfig{
@@ -130,7 +150,11 @@ func (m *PanicRecoveryMiddleware) handlePanic(w http.ResponseWriter, r *http.Req
 	// Use custom panic handler if provided
 	if m.config.PanicHandler != nil {
 		err = m.config.PanicHandler(panicValue)
-	} else {
+	}
+	if err == nil && m.config.ErrorMapper != nil {
+		err = &typedhttp.PanicError{Value: panicValue, Stack: debug.Stack()}
+	}
+	if err == nil && m.config.PanicHandler == nil {
 		err = fmt.Errorf("panic recovered: %v", panicValue)
 	}
 
@@ -140,7 +164,16 @@ func (m *PanicRecoveryMiddleware) handlePanic(w http.ResponseWriter, r *http.Req
 		if m.config.IncludeStackTrace {
 			logMessage += fmt.Sprintf("\nStack trace:\n%s", debug.Stack())
 		}
-		log.Printf(logMessage)
+		log.Print(logMessage)
+	}
+
+	if m.config.ErrorMapper != nil {
+		statusCode, response := m.config.ErrorMapper.MapError(err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(response)
+
+		return
 	}
 
 	// Write error response
@@ -326,11 +359,11 @@ func (cb *CircuitBreakerMiddleware) HTTPMiddleware() func(http.Handler) http.Han
 			}
 
 			// Use a custom response writer to capture the status code
-			rw := &circuitBreakerResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			rw := typedhttp.NewResponseRecorder(w)
 			next.ServeHTTP(rw, r)
 
 			// Record success or failure based on status code
-			if rw.statusCode >= 200 && rw.statusCode < 400 {
+			if rw.Status() >= 200 && rw.Status() < 400 {
 				cb.RecordSuccess()
 			} else {
 				cb.RecordFailure()
@@ -348,18 +381,24 @@ func (cb *CircuitBreakerMiddleware) writeCircuitBreakerError(w http.ResponseWrit
 	})
 }
 
-// circuitBreakerResponseWriter wraps http.ResponseWriter to capture status code
-type circuitBreakerResponseWriter struct {
-	http.ResponseWriter
-	statusCode int
-}
+// Retry Middleware
 
-func (rw *circuitBreakerResponseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
-}
+// JitterStrategy randomizes retry delays so that clients retrying the same failure
+// spread out instead of hitting the backend in synchronized waves.
+type JitterStrategy int
+
+const (
+	// NoJitter waits exactly the exponential backoff.
+	NoJitter JitterStrategy = iota
+	// FullJitter waits a random duration between zero and the backoff.
+	FullJitter
+	// EqualJitter waits half the backoff plus a random duration up to the other half.
+	EqualJitter
+	// DecorrelatedJitter waits a random duration between InitialDelay and three times
+	// the previous delay, ignoring BackoffMultiplier.
+	DecorrelatedJitter
+)
 
-// Retry Middleware
 type RetryConfig struct {
 	MaxRetries         int
 	InitialDelay       time.Duration
@@ -367,7 +406,22 @@ type RetryConfig struct {
 	MaxDelay           time.Duration
 	RetryableErrors    []error
 	RetryCondition     func(error) bool
-}
+	Jitter             JitterStrategy
+	// IgnoreRetryAfter disables waiting for the Retry-After header of 429 and 503 responses.
+	IgnoreRetryAfter bool
+	// RetryableMethods are the request methods HTTPMiddleware retries. Defaults to the
+	// idempotent methods GET, HEAD, PUT, DELETE and OPTIONS.
+	RetryableMethods []string
+	// IdempotencyKeyHeader, when set, also retries requests of any method that carry
+	// a non-empty value in this header.
+	IdempotencyKeyHeader string
+	// MaxBufferedBody is the largest request body buffered for replay. Requests with a
+	// larger or unknown-length body are never retried.
+	MaxBufferedBody int64
+}
+
+// DefaultMaxBufferedBody is the default limit for request bodies buffered by the retry middleware.
+const DefaultMaxBufferedBody = 1 << 20
 
 type RetryMiddleware struct {
 	config RetryConfig
@@ -403,6 +457,57 @@ func WithMaxDelay(maxDelay time.Duration) RetryOption {
 	}
 }
 
+// WithFullJitter randomizes each delay between zero and the exponential backoff
+func WithFullJitter() RetryOption {
+	return func(c *RetryConfig) {
+		c.Jitter = FullJitter
+	}
+}
+
+// WithEqualJitter randomizes each delay between half and all of the exponential backoff
+func WithEqualJitter() RetryOption {
+	return func(c *RetryConfig) {
+		c.Jitter = EqualJitter
+	}
+}
+
+// WithDecorrelatedJitter randomizes each delay between the initial delay and three times the previous one
+func WithDecorrelatedJitter() RetryOption {
+	return func(c *RetryConfig) {
+		c.Jitter = DecorrelatedJitter
+	}
+}
+
+// WithIgnoreRetryAfter always uses the computed backoff, even when a 429 or 503
+// response carries a Retry-After header
+func WithIgnoreRetryAfter() RetryOption {
+	return func(c *RetryConfig) {
+		c.IgnoreRetryAfter = true
+	}
+}
+
+// WithRetryableMethods opts additional request methods, e.g. POST, into retrying
+func WithRetryableMethods(methods ...string) RetryOption {
+	return func(c *RetryConfig) {
+		c.RetryableMethods = append(c.RetryableMethods, methods...)
+	}
+}
+
+// WithIdempotencyKeyHeader retries requests of any method that carry an idempotency key
+// in the given header, e.g. "Idempotency-Key", so the server can deduplicate them
+func WithIdempotencyKeyHeader(header string) RetryOption {
+	return func(c *RetryConfig) {
+		c.IdempotencyKeyHeader = header
+	}
+}
+
+// WithMaxBufferedBody sets the largest request body buffered for replay across attempts
+func WithMaxBufferedBody(limit int64) RetryOption {
+	return func(c *RetryConfig) {
+		c.MaxBufferedBody = limit
+	}
+}
+
 // WithRetryableErrors sets specific errors that should trigger retries
 func WithRetryableErrors(errors []error) RetryOption {
 	return func(c *RetryConfig) {
@@ -426,6 +531,10 @@ func NewRetryMiddleware(opts ...RetryOption) *RetryMiddleware {
 		MaxDelay:          5 * time.Second,
 		RetryableErrors:   []error{},
 		RetryCondition:    nil,
+		RetryableMethods: []string{
+			http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions,
+		},
+		MaxBufferedBody: DefaultMaxBufferedBody,
 	}
 
 	for _, opt := range opts {
@@ -454,48 +563,66 @@ func (m *RetryMiddleware) GetConfig() RetryConfig {
 func (m *RetryMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.retryable(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, ok := m.bufferBody(r)
+			if !ok {
+				// The body cannot be replayed, so the request gets a single attempt
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			var lastErr error
+			var delay time.Duration
 			
 			for attempt := 0; attempt <= m.config.MaxRetries; attempt++ {
 				if attempt > 0 {
-					// Wait before retry
-					delay := m.calculateDelay(attempt - 1)
-					time.Sleep(delay)
+					// Wait before retry, giving up once the client has gone away
+					select {
+					case <-r.Context().Done():
+						return
+					case <-time.After(delay):
+					}
+				}
+				if body != nil {
+					r.Body = io.NopCloser(bytes.NewReader(body))
 				}
 
 				// Create a response recorder to capture the response
 				rr := &retryResponseRecorder{
 					ResponseWriter: w,
+					header:         make(http.Header),
 					statusCode:     http.StatusOK,
 					body:          make([]byte, 0),
 				}
 
+				start := time.Now()
 				next.ServeHTTP(rr, r)
+				elapsed := time.Since(start)
 
 				// Check if the response indicates success
 				if rr.statusCode >= 200 && rr.statusCode < 400 {
 					// Success - write the response and return
-					for key, values := range rr.Header() {
-						for _, value := range values {
-							w.Header().Add(key, value)
-						}
-					}
-					w.WriteHeader(rr.statusCode)
-					w.Write(rr.body)
+					rr.commit(w)
 					return
 				}
 
 				// Failure - check if we should retry
 				lastErr = fmt.Errorf("HTTP %d", rr.statusCode)
-				if !m.shouldRetry(lastErr) || attempt == m.config.MaxRetries {
+				retry := m.shouldRetry(lastErr) && attempt < m.config.MaxRetries
+				if retry {
+					delay, retry = m.retryDelay(attempt, delay, rr.statusCode, rr.Header())
+				}
+				if retry && !budgetCovers(r.Context(), delay+elapsed) {
+					writeBudgetExhausted(w)
+					return
+				}
+				if !retry {
 					// Don't retry or max retries reached - write the response
-					for key, values := range rr.Header() {
-						for _, value := range values {
-							w.Header().Add(key, value)
-						}
-					}
-					w.WriteHeader(rr.statusCode)
-					w.Write(rr.body)
+					rr.commit(w)
 					return
 				}
 			}
@@ -507,9 +634,14 @@ func (m *RetryMiddleware) HTTPMiddleware() func(http.Handler) http.Handler {
 func (m *RetryMiddleware) ExecuteWithRetry(ctx context.Context, fn func() error) error {
 	var lastErr error
 	
+	var delay time.Duration
+	var elapsed time.Duration
 	for attempt := 0; attempt <= m.config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			delay := m.calculateDelay(attempt - 1)
+			delay = m.nextDelay(attempt-1, delay)
+			if !budgetCovers(ctx, delay+elapsed) {
+				return fmt.Errorf("%w: %w", ErrBudgetExhausted, lastErr)
+			}
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -517,12 +649,14 @@ func (m *RetryMiddleware) ExecuteWithRetry(ctx context.Context, fn func() error)
 			}
 		}
 
+		start := time.Now()
 		err := fn()
 		if err == nil {
 			return nil
 		}
 
 		lastErr = err
+		elapsed = time.Since(start)
 		if !m.shouldRetry(err) || attempt == m.config.MaxRetries {
 			break
 		}
@@ -531,6 +665,24 @@ func (m *RetryMiddleware) ExecuteWithRetry(ctx context.Context, fn func() error)
 	return lastErr
 }
 
+// budgetCovers reports whether the request budget of ctx has room for another attempt,
+// estimated as the wait before it plus the duration of the previous attempt. Without a
+// budget there is always room.
+func budgetCovers(ctx context.Context, needed time.Duration) bool {
+	remaining, ok := typedhttp.Budget(ctx)
+
+	return !ok || remaining > needed
+}
+
+// writeBudgetExhausted writes the response for a request whose budget ran out between attempts
+func writeBudgetExhausted(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusGatewayTimeout)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": ErrBudgetExhausted.Error(),
+	})
+}
+
 // calculateDelay calculates the delay for a given attempt
 func (m *RetryMiddleware) calculateDelay(attempt int) time.Duration {
 	delay := float64(m.config.InitialDelay) * math.Pow(m.config.BackoffMultiplier, float64(attempt))
@@ -540,6 +692,100 @@ func (m *RetryMiddleware) calculateDelay(attempt int) time.Duration {
 	return time.Duration(delay)
 }
 
+// retryable reports whether the request method is safe to retry.
+func (m *RetryMiddleware) retryable(r *http.Request) bool {
+	if m.config.IdempotencyKeyHeader != "" && r.Header.Get(m.config.IdempotencyKeyHeader) != "" {
+		return true
+	}
+
+	return slices.Contains(m.config.RetryableMethods, r.Method)
+}
+
+// bufferBody reads the request body into memory so every attempt can re-read it.
+// It returns a nil slice for requests without a body and false for bodies that cannot
+// be replayed: streams of unknown length, bodies over MaxBufferedBody, or read failures.
+func (m *RetryMiddleware) bufferBody(r *http.Request) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true
+	}
+	if r.ContentLength < 0 || r.ContentLength > m.config.MaxBufferedBody {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, m.config.MaxBufferedBody))
+	_ = r.Body.Close()
+	if err != nil {
+		// The body is partially consumed, so pass on what was read and fail the handler's reads
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+		return nil, false
+	}
+
+	return body, true
+}
+
+// errReader returns err from every Read.
+type errReader struct{ err error }
+
+func (e errReader) Read([]byte) (int, error) { return 0, e.err }
+
+// nextDelay applies the jitter strategy to the backoff of an attempt. previous is the
+// delay before the failed attempt, zero for the first one.
+func (m *RetryMiddleware) nextDelay(attempt int, previous time.Duration) time.Duration {
+	backoff := m.calculateDelay(attempt)
+
+	switch m.config.Jitter {
+	case FullJitter:
+		return time.Duration(rand.Float64() * float64(backoff))
+	case EqualJitter:
+		return backoff/2 + time.Duration(rand.Float64()*float64(backoff/2))
+	case DecorrelatedJitter:
+		upper := 3 * previous
+		if upper < m.config.InitialDelay {
+			upper = m.config.InitialDelay
+		}
+		delay := m.config.InitialDelay + time.Duration(rand.Float64()*float64(upper-m.config.InitialDelay))
+
+		return min(delay, m.config.MaxDelay)
+	default:
+		return backoff
+	}
+}
+
+// retryDelay returns how long to wait before retrying a failed response. A Retry-After
+// header on a 429 or 503 response replaces the computed delay; when it asks for a longer
+// wait than MaxDelay the response is returned to the client instead, reported as false.
+func (m *RetryMiddleware) retryDelay(attempt int, previous time.Duration, statusCode int, header http.Header) (time.Duration, bool) {
+	if !m.config.IgnoreRetryAfter &&
+		(statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+			return wait, wait <= m.config.MaxDelay
+		}
+	}
+
+	return m.nextDelay(attempt, previous), true
+}
+
+// parseRetryAfter parses a Retry-After value given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
 // shouldRetry determines if an error should trigger a retry
 func (m *RetryMiddleware) shouldRetry(err error) bool {
 	if m.config.RetryCondition != nil {
@@ -557,13 +803,20 @@ func (m *RetryMiddleware) shouldRetry(err error) bool {
 }
 
 // retryResponseRecorder captures HTTP responses for retry logic
+// retryResponseRecorder buffers one attempt. It keeps its own headers so that only
+// the attempt finally returned to the client reaches the real response.
 type retryResponseRecorder struct {
 	http.ResponseWriter
+	header     http.Header
 	statusCode int
 	body       []byte
 	written    bool
 }
 
+func (rr *retryResponseRecorder) Header() http.Header {
+	return rr.header
+}
+
 func (rr *retryResponseRecorder) WriteHeader(code int) {
 	if !rr.written {
 		rr.statusCode = code
@@ -576,6 +829,15 @@ func (rr *retryResponseRecorder) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// commit writes the recorded attempt to w.
+func (rr *retryResponseRecorder) commit(w http.ResponseWriter) {
+	for key, values := range rr.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(rr.statusCode)
+	w.Write(rr.body)
+}
+
 // Combined Recovery Middleware
 type RecoveryMiddleware struct {
 	panicRecovery  *PanicRecoveryMiddleware
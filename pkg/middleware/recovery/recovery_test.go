@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -124,6 +127,59 @@ func TestPanicRecoveryMiddleware_HTTPMiddleware(t *testing.T) {
 	})
 }
 
+// TestPanicRecoveryMiddleware_ErrorMapper tests mapping panics through an error mapper
+func TestPanicRecoveryMiddleware_ErrorMapper(t *testing.T) {
+	tests := []struct {
+		name         string
+		panicValue   interface{}
+		panicHandler func(interface{}) error
+		wantCode     int
+		wantBody     string
+	}{
+		{
+			name:       "typed error keeps its status",
+			panicValue: typedhttp.NewNotFoundError("order", "42"),
+			wantCode:   http.StatusNotFound,
+			wantBody:   `{"error":"order with id '42' not found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:       "untyped panic is an internal error",
+			panicValue: "nil pointer",
+			wantCode:   http.StatusInternalServerError,
+			wantBody:   `{"error":"Internal server error","code":"INTERNAL_ERROR"}`,
+		},
+		{
+			name:       "panic handler error is mapped",
+			panicValue: "conflict",
+			panicHandler: func(interface{}) error {
+				return &typedhttp.ConflictError{Message: "order already shipped"}
+			},
+			wantCode: http.StatusConflict,
+			wantBody: `{"error":"order already shipped","code":"CONFLICT"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []PanicRecoveryOption{WithPanicLogging(false), WithPanicErrorMapper(&typedhttp.DefaultErrorMapper{})}
+			if tt.panicHandler != nil {
+				opts = append(opts, WithPanicHandler(tt.panicHandler))
+			}
+			handler := NewPanicRecoveryMiddleware(opts...).HTTPMiddleware()(
+				http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					panic(tt.panicValue)
+				}),
+			)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+			assert.Equal(t, tt.wantCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
 // TestPanicRecoveryMiddleware_TypedMiddleware tests panic recovery as typed middleware
 func TestPanicRecoveryMiddleware_TypedMiddleware(t *testing.T) {
 	middleware := NewPanicRecoveryMiddleware()
@@ -496,6 +552,314 @@ func TestRetryMiddleware_TypedMiddleware(t *testing.T) {
 	})
 }
 
+func TestRetryMiddleware_RequestSafety(t *testing.T) {
+	failFirst := func(count *int32, bodies *[]string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			*bodies = append(*bodies, string(body))
+			if atomic.AddInt32(count, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	t.Run("post_not_retried_by_default", func(t *testing.T) {
+		var count int32
+		var bodies []string
+		handler := NewRetryMiddleware(WithInitialDelay(time.Millisecond)).HTTPMiddleware()(failFirst(&count, &bodies))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":1}`)))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+	})
+
+	t.Run("retried_get_resends_body", func(t *testing.T) {
+		var count int32
+		var bodies []string
+		handler := NewRetryMiddleware(WithInitialDelay(time.Millisecond)).HTTPMiddleware()(failFirst(&count, &bodies))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search", strings.NewReader(`{"q":"go"}`)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []string{`{"q":"go"}`, `{"q":"go"}`}, bodies)
+	})
+
+	t.Run("opted_in_post_resends_body", func(t *testing.T) {
+		var count int32
+		var bodies []string
+		handler := NewRetryMiddleware(
+			WithInitialDelay(time.Millisecond),
+			WithRetryableMethods(http.MethodPost),
+		).HTTPMiddleware()(failFirst(&count, &bodies))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":1}`)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, []string{`{"id":1}`, `{"id":1}`}, bodies)
+	})
+
+	t.Run("post_with_idempotency_key_retried", func(t *testing.T) {
+		middleware := NewRetryMiddleware(WithInitialDelay(time.Millisecond), WithIdempotencyKeyHeader("Idempotency-Key"))
+
+		var count int32
+		var bodies []string
+		handler := middleware.HTTPMiddleware()(failFirst(&count, &bodies))
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":1}`))
+		req.Header.Set("Idempotency-Key", "abc")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+
+		count, bodies = 0, nil
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":1}`)))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+	})
+
+	t.Run("streamed_body_not_retried", func(t *testing.T) {
+		var count int32
+		var bodies []string
+		handler := NewRetryMiddleware(WithInitialDelay(time.Millisecond)).HTTPMiddleware()(failFirst(&count, &bodies))
+
+		req := httptest.NewRequest(http.MethodPut, "/upload", strings.NewReader("chunk"))
+		req.ContentLength = -1
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, []string{"chunk"}, bodies)
+	})
+
+	t.Run("oversized_body_not_retried", func(t *testing.T) {
+		var count int32
+		var bodies []string
+		handler := NewRetryMiddleware(
+			WithInitialDelay(time.Millisecond),
+			WithMaxBufferedBody(4),
+		).HTTPMiddleware()(failFirst(&count, &bodies))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/upload", strings.NewReader("too large")))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, []string{"too large"}, bodies)
+	})
+}
+
+func TestRetryMiddleware_Jitter(t *testing.T) {
+	const samples = 200
+	backoff := 100 * time.Millisecond // third attempt: 25ms * 2^2
+
+	t.Run("full jitter", func(t *testing.T) {
+		middleware := NewRetryMiddleware(WithInitialDelay(25*time.Millisecond), WithFullJitter())
+		for i := 0; i < samples; i++ {
+			delay := middleware.nextDelay(2, 0)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, backoff)
+		}
+	})
+
+	t.Run("equal jitter", func(t *testing.T) {
+		middleware := NewRetryMiddleware(WithInitialDelay(25*time.Millisecond), WithEqualJitter())
+		for i := 0; i < samples; i++ {
+			delay := middleware.nextDelay(2, 0)
+			assert.GreaterOrEqual(t, delay, backoff/2)
+			assert.LessOrEqual(t, delay, backoff)
+		}
+	})
+
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		middleware := NewRetryMiddleware(
+			WithInitialDelay(10*time.Millisecond),
+			WithMaxDelay(50*time.Millisecond),
+			WithDecorrelatedJitter(),
+		)
+		assert.Equal(t, 10*time.Millisecond, middleware.nextDelay(0, 0))
+
+		for i := 0; i < samples; i++ {
+			delay := middleware.nextDelay(1, 12*time.Millisecond)
+			assert.GreaterOrEqual(t, delay, 10*time.Millisecond)
+			assert.LessOrEqual(t, delay, 36*time.Millisecond)
+
+			assert.LessOrEqual(t, middleware.nextDelay(5, 40*time.Millisecond), 50*time.Millisecond)
+		}
+	})
+
+	t.Run("no jitter", func(t *testing.T) {
+		middleware := NewRetryMiddleware(WithInitialDelay(25 * time.Millisecond))
+		assert.Equal(t, backoff, middleware.nextDelay(2, 0))
+	})
+}
+
+func TestRetryMiddleware_RetryAfter(t *testing.T) {
+	t.Run("overrides the computed delay", func(t *testing.T) {
+		middleware := NewRetryMiddleware(WithInitialDelay(time.Millisecond), WithMaxDelay(time.Hour))
+
+		header := http.Header{}
+		header.Set("Retry-After", "2")
+		delay, ok := middleware.retryDelay(0, 0, http.StatusServiceUnavailable, header)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Second, delay)
+
+		header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		delay, ok = middleware.retryDelay(0, 0, http.StatusTooManyRequests, header)
+		assert.True(t, ok)
+		assert.InDelta(t, time.Minute, delay, float64(2*time.Second))
+	})
+
+	t.Run("ignored on other statuses and when disabled", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Retry-After", "2")
+
+		middleware := NewRetryMiddleware(WithInitialDelay(time.Millisecond))
+		delay, ok := middleware.retryDelay(0, 0, http.StatusBadGateway, header)
+		assert.True(t, ok)
+		assert.Equal(t, time.Millisecond, delay)
+
+		middleware = NewRetryMiddleware(WithInitialDelay(time.Millisecond), WithIgnoreRetryAfter())
+		delay, ok = middleware.retryDelay(0, 0, http.StatusServiceUnavailable, header)
+		assert.True(t, ok)
+		assert.Equal(t, time.Millisecond, delay)
+	})
+
+	t.Run("retries after the requested wait", func(t *testing.T) {
+		var requestCount int32
+		middleware := NewRetryMiddleware(WithMaxRetries(1), WithInitialDelay(time.Hour), WithMaxDelay(time.Hour))
+		handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requestCount, 1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		rec := httptest.NewRecorder()
+		start := time.Now()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requestCount))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("returns the response when the wait exceeds MaxDelay", func(t *testing.T) {
+		var requestCount int32
+		middleware := NewRetryMiddleware(WithMaxRetries(3), WithMaxDelay(time.Second))
+		handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "120", rec.Header().Get("Retry-After"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requestCount))
+	})
+}
+
+func TestRetryMiddleware_Budget(t *testing.T) {
+	t.Run("stops retrying once the budget cannot cover an attempt", func(t *testing.T) {
+		var requestCount int32
+		middleware := NewRetryMiddleware(WithMaxRetries(5), WithInitialDelay(10*time.Millisecond))
+		handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestCount, 1)
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		ctx, cancel := typedhttp.ContextWithBudget(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx))
+
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		assert.Contains(t, rec.Body.String(), ErrBudgetExhausted.Error())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requestCount))
+	})
+
+	t.Run("ExecuteWithRetry returns the last error", func(t *testing.T) {
+		errUpstream := errors.New("upstream unavailable")
+		middleware := NewRetryMiddleware(WithMaxRetries(5), WithInitialDelay(time.Second))
+
+		ctx, cancel := typedhttp.ContextWithBudget(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		calls := 0
+		err := middleware.ExecuteWithRetry(ctx, func() error {
+			calls++
+			return errUpstream
+		})
+
+		assert.ErrorIs(t, err, ErrBudgetExhausted)
+		assert.ErrorIs(t, err, errUpstream)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRetryMiddleware_AttemptHeaders(t *testing.T) {
+	middleware := NewRetryMiddleware(WithMaxRetries(2), WithInitialDelay(time.Millisecond))
+
+	attempts := 0
+	handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Add("X-Attempt", fmt.Sprint(attempts))
+		if attempts < 3 {
+			w.Header().Set("X-Failed", "true")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"3"}, rr.Header().Values("X-Attempt"))
+	assert.Empty(t, rr.Header().Get("X-Failed"))
+}
+
+func TestRetryMiddleware_StopsWaitingWhenCancelled(t *testing.T) {
+	middleware := NewRetryMiddleware(WithMaxRetries(3), WithInitialDelay(time.Hour), WithMaxDelay(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	handler := middleware.HTTPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody).WithContext(ctx)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("retry middleware kept waiting after the request was cancelled")
+	}
+	assert.Equal(t, 1, calls)
+}
+
 // TestRecoveryMiddleware_CombinedUsage tests using all recovery middleware together
 func TestRecoveryMiddleware_CombinedUsage(t *testing.T) {
 	// Create all recovery middleware
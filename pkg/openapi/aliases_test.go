@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type aliasedLookupRequest struct {
+	UserID string `query:"userId" aliases:"user_id,uid"`
+	Tenant string `header:"X-Tenant"`
+}
+
+type aliasedLookupHandler struct{}
+
+func (h *aliasedLookupHandler) Handle(_ context.Context, req aliasedLookupRequest) (aliasedLookupRequest, error) {
+	return req, nil
+}
+
+func TestGenerate_DocumentsCanonicalParameterName(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/lookup", &aliasedLookupHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	params := spec.Paths.Find("/lookup").Get.Parameters
+	userID := params.GetByInAndName("query", "userId")
+	require.NotNil(t, userID)
+	assert.Contains(t, userID.Description, "user_id, uid")
+	assert.Nil(t, params.GetByInAndName("query", "user_id"))
+	assert.Empty(t, params.GetByInAndName("header", "X-Tenant").Description)
+}
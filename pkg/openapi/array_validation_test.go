@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type InviteRequest struct {
+	Emails []string `query:"emails" validate:"required,min=1,max=10,dive,email"`
+	Tags   []string `query:"tags" validate:"max=3,dive,min=2,max=20"`
+}
+
+type InviteResponse struct {
+	Sent int `json:"sent"`
+}
+
+type InviteHandler struct{}
+
+func (h *InviteHandler) Handle(_ context.Context, req InviteRequest) (InviteResponse, error) {
+	return InviteResponse{Sent: len(req.Emails)}, nil
+}
+
+func TestArrayValidation_ItemConstraints(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/invites", &InviteHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	params := spec.Paths.Find("/invites").Get.Parameters
+
+	emails := params.GetByInAndName("query", "emails").Schema.Value
+	assert.True(t, emails.Type.Is("array"))
+	assert.Equal(t, uint64(1), emails.MinItems)
+	require.NotNil(t, emails.MaxItems)
+	assert.Equal(t, uint64(10), *emails.MaxItems)
+	assert.Equal(t, "email", emails.Items.Value.Format)
+	assert.Zero(t, emails.Items.Value.MinLength, "array bounds must not leak into the items")
+
+	tags := params.GetByInAndName("query", "tags").Schema.Value
+	require.NotNil(t, tags.MaxItems)
+	assert.Equal(t, uint64(3), *tags.MaxItems)
+	assert.Equal(t, uint64(2), tags.Items.Value.MinLength)
+	require.NotNil(t, tags.Items.Value.MaxLength)
+	assert.Equal(t, uint64(20), *tags.Items.Value.MaxLength)
+}
+
+func TestArrayValidation_DiveOnNonArrayIsIgnored(t *testing.T) {
+	generator := NewGenerator(&Config{})
+	schema, err := generator.createSchemaFromType(reflect.TypeOf(""))
+	require.NoError(t, err)
+
+	generator.applyValidationToSchema(schema, "min=1,dive,email")
+
+	assert.Equal(t, uint64(1), schema.Value.MinLength)
+	assert.Empty(t, schema.Value.Format)
+}
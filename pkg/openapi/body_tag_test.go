@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UpdateOrderRequest struct {
+	ID       string `path:"id"`
+	DryRun   bool   `query:"dry_run"`
+	Item     string `body:"item"`
+	Quantity int    `body:"quantity,omitempty"`
+}
+
+type UpdateOrderHandler struct{}
+
+func (h *UpdateOrderHandler) Handle(_ context.Context, req UpdateOrderRequest) (OrderResponse, error) {
+	return OrderResponse{ID: req.ID}, nil
+}
+
+func TestGenerate_BodyTagLimitsRequestBody(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.PUT(router, "/orders/{id}", &UpdateOrderHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Orders API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	update := spec.Paths.Find("/orders/{id}").Put
+
+	params := map[string]string{}
+	for _, param := range update.Parameters {
+		params[param.Value.Name] = param.Value.In
+	}
+	assert.Equal(t, map[string]string{"id": "path", "dry_run": "query"}, params)
+
+	require.NotNil(t, update.RequestBody)
+	schema := update.RequestBody.Value.Content["application/json"].Schema.Value
+	assert.ElementsMatch(t, []string{"item", "quantity"}, keys(schema.Properties))
+	assert.Equal(t, []string{"item"}, schema.Required)
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	return result
+}
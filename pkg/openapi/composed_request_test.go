@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AuthContext struct {
+	Token string `header:"Authorization" validate:"required"`
+}
+
+type Authed[P any] = typedhttp.Composed[AuthContext, P]
+
+type GetAccountParams struct {
+	ID string `path:"id" validate:"required"`
+}
+
+type UpdateAccountParams struct {
+	ID    string `path:"id" validate:"required"`
+	Token string `header:"Authorization"`
+	Name  string `json:"name" validate:"required"`
+}
+
+type GetAccountHandler struct{}
+
+func (h *GetAccountHandler) Handle(_ context.Context, _ Authed[GetAccountParams]) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+type UpdateAccountHandler struct{}
+
+func (h *UpdateAccountHandler) Handle(_ context.Context, _ Authed[UpdateAccountParams]) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_ComposedRequestParameters(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/accounts/{id}", &GetAccountHandler{})
+	typedhttp.PUT(router, "/accounts/{id}", &UpdateAccountHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Accounts", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	pathItem := spec.Paths.Find("/accounts/{id}")
+	for _, operation := range []struct {
+		name       string
+		parameters int
+		body       bool
+	}{
+		{name: "GET", parameters: 2},
+		{name: "PUT", parameters: 2, body: true},
+	} {
+		op := pathItem.GetOperation(operation.name)
+		require.NotNil(t, op, operation.name)
+		require.Len(t, op.Parameters, operation.parameters, operation.name)
+
+		auth := op.Parameters.GetByInAndName("header", "Authorization")
+		require.NotNil(t, auth, operation.name)
+		assert.True(t, auth.Required, operation.name)
+
+		id := op.Parameters.GetByInAndName("path", "id")
+		require.NotNil(t, id, operation.name)
+
+		if !operation.body {
+			assert.Nil(t, op.RequestBody, operation.name)
+
+			continue
+		}
+		schema := op.RequestBody.Value.Content["application/json"].Schema.Value
+		assert.Equal(t, []string{"name"}, keys(schema.Properties))
+	}
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AvatarUploadRequest struct {
+	UserID string                `path:"id"`
+	Title  string                `form:"title" validate:"required"`
+	Image  *multipart.FileHeader `form:"image"`
+}
+
+type AvatarUploadHandler struct{}
+
+func (h *AvatarUploadHandler) Handle(_ context.Context, _ AvatarUploadRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+type AvatarTitleRequest struct {
+	UserID string `path:"id"`
+	Title  string `form:"title" validate:"required"`
+}
+
+type AvatarTitleHandler struct{}
+
+func (h *AvatarTitleHandler) Handle(_ context.Context, _ AvatarTitleRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_ConsumesListsOnlyDeclaredMediaTypes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.PUT(router, "/users/{id}/avatar", &AvatarUploadHandler{}, typedhttp.WithConsumes("multipart/form-data"))
+	typedhttp.PATCH(router, "/users/{id}/avatar", &AvatarTitleHandler{}, typedhttp.WithConsumes("multipart/form-data"))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Avatars", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	pathItem := spec.Paths.Find("/users/{id}/avatar")
+	for _, name := range []string{"PUT", "PATCH"} {
+		content := pathItem.GetOperation(name).RequestBody.Value.Content
+		assert.Equal(t, []string{"multipart/form-data"}, keys(content), name)
+
+		schema := content["multipart/form-data"].Schema.Value
+		assert.Contains(t, schema.Properties, "title", name)
+		assert.Equal(t, []string{"title"}, schema.Required, name)
+	}
+}
@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ListInvoicesRequest struct {
+	Status string `query:"status" default:"open"`
+	State  string `query:"state" default:"open" deprecated:"true"`
+}
+
+type InvoiceSummary struct {
+	ID       string `json:"id"`
+	Total    int    `json:"total"`
+	TotalUSD int    `json:"total_usd,omitempty" deprecated:"true"`
+}
+
+type ListInvoicesHandler struct{}
+
+func (h *ListInvoicesHandler) Handle(_ context.Context, _ ListInvoicesRequest) (InvoiceSummary, error) {
+	return InvoiceSummary{}, nil
+}
+
+type UpdateInvoiceRequest struct {
+	ID    string `path:"id"`
+	Note  string `json:"note"`
+	Memo  string `json:"memo,omitempty" deprecated:"true"`
+	Draft bool   `json:"draft" deprecated:"false"`
+}
+
+type UpdateInvoiceHandler struct{}
+
+func (h *UpdateInvoiceHandler) Handle(_ context.Context, _ UpdateInvoiceRequest) (InvoiceSummary, error) {
+	return InvoiceSummary{}, nil
+}
+
+func TestGenerate_DeprecatedFields(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/invoices", &ListInvoicesHandler{})
+	typedhttp.PATCH(router, "/invoices/{id}", &UpdateInvoiceHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Invoices", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	t.Run("query parameter", func(t *testing.T) {
+		list := spec.Paths.Find("/invoices").Get
+		assert.False(t, list.Parameters.GetByInAndName("query", "status").Deprecated)
+		assert.True(t, list.Parameters.GetByInAndName("query", "state").Deprecated)
+	})
+
+	t.Run("body properties", func(t *testing.T) {
+		update := spec.Paths.Find("/invoices/{id}").Patch
+		body := update.RequestBody.Value.Content["application/json"].Schema.Value
+		assert.True(t, body.Properties["memo"].Value.Deprecated)
+		assert.False(t, body.Properties["note"].Value.Deprecated)
+		assert.False(t, body.Properties["draft"].Value.Deprecated)
+
+		response := update.Responses.Status(200).Value.Content["application/json"].Schema.Value
+		assert.True(t, response.Properties["total_usd"].Value.Deprecated)
+		assert.False(t, response.Properties["total"].Value.Deprecated)
+	})
+
+	data, err := NewGenerator(&Config{}).GenerateJSON(spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"deprecated": true`)
+}
@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ValidateOrderRequest struct {
+	SKU string `json:"sku" validate:"required"`
+}
+
+type ValidateOrderHandler struct{}
+
+func (h *ValidateOrderHandler) Handle(_ context.Context, _ ValidateOrderRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_DryRunParameter(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/orders", &ValidateOrderHandler{}, typedhttp.WithDryRun("dry_run"))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Orders", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	param := spec.Paths.Find("/orders").Post.Parameters.GetByInAndName("query", "dry_run")
+	require.NotNil(t, param)
+	assert.False(t, param.Required)
+	assert.True(t, param.Schema.Value.Type.Is("boolean"))
+}
@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pluginReportRequest struct {
+	ID     string `path:"id" validate:"required"`
+	Format string `query:"format" validate:"omitempty,oneof=pdf csv"`
+}
+
+type pluginReportResponse struct {
+	ID    string `json:"id"`
+	Pages int    `json:"pages"`
+}
+
+func TestGenerate_DynamicRoute(t *testing.T) {
+	router := typedhttp.NewRouter()
+	router.RegisterDynamic(http.MethodGet, "/reports/{id}",
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}),
+		reflect.TypeOf(pluginReportRequest{}), reflect.TypeOf(pluginReportResponse{}),
+		typedhttp.WithTags("plugins"), typedhttp.WithOperationID("renderReport"),
+	)
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	operation := spec.Paths.Find("/reports/{id}").Get
+	require.NotNil(t, operation)
+	assert.Equal(t, []string{"plugins"}, operation.Tags)
+	assert.Equal(t, "renderReport", operation.OperationID)
+	require.NotNil(t, operation.Parameters.GetByInAndName("path", "id"))
+	require.NotNil(t, operation.Parameters.GetByInAndName("query", "format"))
+
+	schema := operation.Responses.Status(http.StatusOK).Value.Content.Get("application/json").Schema.Value
+	assert.Contains(t, schema.Properties, "id")
+	assert.Contains(t, schema.Properties, "pages")
+}
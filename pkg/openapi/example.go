@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// parseExampleValue converts an example tag to a value of the field's type.
+// Slices accept a JSON array or a comma-separated list; structs and maps accept JSON.
+// Values that cannot be converted are returned as the raw string.
+func parseExampleValue(example string, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return example
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val, err := strconv.ParseInt(example, 10, 64); err == nil {
+			return val
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val, err := strconv.ParseUint(example, 10, 64); err == nil {
+			return val
+		}
+	case reflect.Float32, reflect.Float64:
+		if val, err := strconv.ParseFloat(example, 64); err == nil {
+			return val
+		}
+	case reflect.Bool:
+		if val, err := strconv.ParseBool(example); err == nil {
+			return val
+		}
+	case reflect.Slice, reflect.Array:
+		if !strings.HasPrefix(strings.TrimSpace(example), "[") {
+			parts := strings.Split(example, ",")
+			items := make([]interface{}, len(parts))
+			for i, part := range parts {
+				items[i] = parseExampleValue(strings.TrimSpace(part), t.Elem())
+			}
+
+			return items
+		}
+		fallthrough
+	default:
+		var val interface{}
+		if err := json.Unmarshal([]byte(example), &val); err == nil {
+			return val
+		}
+	}
+
+	return example
+}
+
+// buildExample composes an example value for t from the example tags of its fields,
+// recursing into nested structs, pointers and slices. It reports false when no field
+// of t declares an example. Untagged fields are named with strategy when it is set.
+func buildExample(t reflect.Type, strategy typedhttp.FieldNameStrategy) (interface{}, bool) {
+	return buildExampleVisited(t, strategy, make(map[reflect.Type]bool))
+}
+
+func buildExampleVisited(
+	t reflect.Type, strategy typedhttp.FieldNameStrategy, visiting map[reflect.Type]bool,
+) (interface{}, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if item, ok := buildExampleVisited(t.Elem(), strategy, visiting); ok {
+			return []interface{}{item}, true
+		}
+	case reflect.Struct:
+		// Recursive types would never terminate
+		if visiting[t] {
+			return nil, false
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		example := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := typedhttp.JSONFieldName(field, strategy)
+			if !ok {
+				continue
+			}
+
+			if tag, ok := field.Tag.Lookup("example"); ok {
+				example[name] = parseExampleValue(tag, field.Type)
+			} else if nested, ok := buildExampleVisited(field.Type, strategy, visiting); ok {
+				example[name] = nested
+			}
+		}
+
+		if len(example) > 0 {
+			return example, true
+		}
+	}
+
+	return nil, false
+}
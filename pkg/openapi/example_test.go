@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ExampleAddress struct {
+	Street string `json:"street" example:"1 Main St"`
+	Zip    int    `json:"zip" example:"12345"`
+}
+
+type ExampleLine struct {
+	SKU      string  `json:"sku" example:"WIDGET-1"`
+	Quantity int     `json:"quantity" example:"2"`
+	Price    float64 `json:"price" example:"9.99"`
+}
+
+type CreateInvoiceRequest struct {
+	DryRun   bool            `query:"dry_run" example:"true"`
+	Customer string          `json:"customer" example:"Acme"`
+	Paid     bool            `json:"paid" example:"false"`
+	Tags     []string        `json:"tags" example:"urgent,b2b"`
+	Address  *ExampleAddress `json:"address"`
+	Lines    []ExampleLine   `json:"lines"`
+	Notes    string          `json:"notes,omitempty"`
+}
+
+type CreateInvoiceHandler struct{}
+
+func (h *CreateInvoiceHandler) Handle(_ context.Context, _ CreateInvoiceRequest) (OrderResponse, error) {
+	return OrderResponse{ID: "1"}, nil
+}
+
+func TestGenerate_ComposesRequestExample(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/invoices", &CreateInvoiceHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Invoices API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	create := spec.Paths.Find("/invoices").Post
+	media := create.RequestBody.Value.Content["application/json"]
+	assert.Equal(t, map[string]interface{}{
+		"customer": "Acme",
+		"paid":     false,
+		"tags":     []interface{}{"urgent", "b2b"},
+		"address":  map[string]interface{}{"street": "1 Main St", "zip": int64(12345)},
+		"lines": []interface{}{
+			map[string]interface{}{"sku": "WIDGET-1", "quantity": int64(2), "price": 9.99},
+		},
+	}, media.Example)
+
+	customer := media.Schema.Value.Properties["customer"].Value
+	assert.Equal(t, "Acme", customer.Example)
+
+	require.Len(t, create.Parameters, 1)
+	assert.Equal(t, true, create.Parameters[0].Value.Example)
+}
+
+func TestParseExampleValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		example  string
+		t        reflect.Type
+		expected interface{}
+	}{
+		{name: "int", example: "42", t: reflect.TypeOf(0), expected: int64(42)},
+		{name: "uint pointer", example: "7", t: reflect.TypeOf((*uint)(nil)), expected: uint64(7)},
+		{name: "float", example: "1.5", t: reflect.TypeOf(0.0), expected: 1.5},
+		{name: "bool", example: "true", t: reflect.TypeOf(false), expected: true},
+		{name: "invalid int stays string", example: "many", t: reflect.TypeOf(0), expected: "many"},
+		{name: "csv slice", example: "1, 2", t: reflect.TypeOf([]int{}), expected: []interface{}{int64(1), int64(2)}},
+		{name: "json slice", example: `["a","b"]`, t: reflect.TypeOf([]string{}), expected: []interface{}{"a", "b"}},
+		{name: "json object", example: `{"a":1}`, t: reflect.TypeOf(map[string]int{}), expected: map[string]interface{}{"a": 1.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseExampleValue(tt.example, tt.t))
+		})
+	}
+}
+
+func TestBuildExample_NoExamples(t *testing.T) {
+	_, ok := buildExample(reflect.TypeOf(OrderResponse{}), nil)
+	assert.False(t, ok)
+}
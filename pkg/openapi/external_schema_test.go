@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const moneyRef = "./common.yaml#/components/schemas/Money"
+
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type GetInvoiceRequest struct {
+	ID string `path:"id"`
+}
+
+type InvoiceResponse struct {
+	ID       string  `json:"id"`
+	Total    Money   `json:"total" example:"{\"amount\":100,\"currency\":\"EUR\"}"`
+	Discount *Money  `json:"discount,omitempty"`
+	Lines    []Money `json:"lines"`
+}
+
+type GetInvoiceHandler struct{}
+
+func (h *GetInvoiceHandler) Handle(_ context.Context, req GetInvoiceRequest) (InvoiceResponse, error) {
+	return InvoiceResponse{ID: req.ID}, nil
+}
+
+type GetBalanceHandler struct{}
+
+func (h *GetBalanceHandler) Handle(_ context.Context, _ GetInvoiceRequest) (Money, error) {
+	return Money{}, nil
+}
+
+func TestRegisterExternalSchema(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/invoices/{id}", &GetInvoiceHandler{})
+	typedhttp.GET(router, "/balances/{id}", &GetBalanceHandler{})
+
+	generator := NewGenerator(&Config{Info: Info{Title: "Billing API", Version: "1.0.0"}})
+	generator.RegisterExternalSchema(reflect.TypeOf(Money{}), moneyRef)
+
+	spec, err := generator.Generate(router)
+	require.NoError(t, err)
+
+	invoice := spec.Paths.Find("/invoices/{id}").Get.Responses.Value("200").Value.Content["application/json"].Schema.Value
+	assert.Equal(t, moneyRef, invoice.Properties["total"].Ref)
+	assert.Equal(t, moneyRef, invoice.Properties["discount"].Ref)
+	assert.Equal(t, moneyRef, invoice.Properties["lines"].Value.Items.Ref)
+	assert.Nil(t, invoice.Properties["id"].Value.Items, "other fields stay inline")
+
+	balance := spec.Paths.Find("/balances/{id}").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	assert.Equal(t, moneyRef, balance.Ref)
+
+	data, err := generator.GenerateJSON(spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"$ref": "`+moneyRef+`"`)
+}
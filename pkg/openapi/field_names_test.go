@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CreateAccountRequest struct {
+	OrgID    string `path:"org_id"`
+	UserName string `validate:"required" example:"jane"`
+	Email    string `json:"email_address,omitempty"`
+	Ignored  string `json:"-"`
+}
+
+type AccountResponse struct {
+	AccountID string
+	UserName  string
+}
+
+type CreateAccountHandler struct{}
+
+func (h *CreateAccountHandler) Handle(_ context.Context, req CreateAccountRequest) (AccountResponse, error) {
+	return AccountResponse{AccountID: "a-1", UserName: req.UserName}, nil
+}
+
+func TestGenerate_FieldNameStrategy(t *testing.T) {
+	router := typedhttp.NewRouter(typedhttp.WithFieldNameStrategy(typedhttp.SnakeCase))
+	typedhttp.POST(router, "/orgs/{org_id}/accounts", &CreateAccountHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	operation := spec.Paths.Find("/orgs/{org_id}/accounts").Post
+	require.NotNil(t, operation.RequestBody)
+	body := operation.RequestBody.Value.Content["application/json"]
+	require.NotNil(t, body)
+
+	properties := body.Schema.Value.Properties
+	assert.Contains(t, properties, "user_name")
+	assert.Contains(t, properties, "email_address", "explicit tags win")
+	assert.NotContains(t, properties, "org_id", "path parameters are not body fields")
+	assert.NotContains(t, properties, "ignored")
+	assert.NotContains(t, properties, "UserName")
+	assert.ElementsMatch(t, []string{"user_name"}, body.Schema.Value.Required)
+	assert.Equal(t, map[string]interface{}{"user_name": "jane"}, body.Example)
+
+	response := operation.Responses.Status(201).Value.Content["application/json"].Schema.Value
+	assert.Contains(t, response.Properties, "account_id")
+	assert.Contains(t, response.Properties, "user_name")
+}
+
+func TestGenerate_WithoutFieldNameStrategySkipsUntaggedFields(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/orgs/{org_id}/accounts", &CreateAccountHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	body := spec.Paths.Find("/orgs/{org_id}/accounts").Post.RequestBody.Value.Content["application/json"]
+	assert.Contains(t, body.Schema.Value.Properties, "email_address")
+	assert.NotContains(t, body.Schema.Value.Properties, "user_name")
+}
+
+func TestGenerate_ConcurrentRoutersKeepTheirFieldNames(t *testing.T) {
+	snake := typedhttp.NewRouter(typedhttp.WithFieldNameStrategy(typedhttp.SnakeCase))
+	typedhttp.POST(snake, "/orgs/{org_id}/accounts", &CreateAccountHandler{})
+	plain := typedhttp.NewRouter()
+	typedhttp.POST(plain, "/orgs/{org_id}/accounts", &CreateAccountHandler{})
+
+	generator := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		for _, router := range []*typedhttp.TypedRouter{snake, plain} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				spec, err := generator.Generate(router)
+				if !assert.NoError(t, err) {
+					return
+				}
+				response := spec.Paths.Find("/orgs/{org_id}/accounts").Post.Responses.Status(201)
+				properties := response.Value.Content["application/json"].Schema.Value.Properties
+				if router == snake {
+					assert.Contains(t, properties, "account_id")
+				} else {
+					assert.NotContains(t, properties, "account_id")
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
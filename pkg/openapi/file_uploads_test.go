@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestHasFileUploads tests the hasFileUploads function.
@@ -28,3 +29,22 @@ func TestHasFileUploads(t *testing.T) {
 	noFiles := generator.hasFileUploads(reflect.TypeOf(NoFileRequest{}))
 	assert.False(t, noFiles)
 }
+
+// TestCreateFormSchema_RequiredFields tests that validate:"required" populates the multipart required list.
+func TestCreateFormSchema_RequiredFields(t *testing.T) {
+	generator := NewGenerator(&Config{})
+
+	type UploadRequest struct {
+		Title       string                `form:"title" validate:"required"`
+		Description string                `form:"description" validate:"omitempty,max=200"`
+		Document    *multipart.FileHeader `form:"document" validate:"required"`
+		Thumbnail   *multipart.FileHeader `form:"thumbnail"`
+	}
+
+	schema, err := generator.createFormSchema(reflect.TypeOf(UploadRequest{}))
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"title", "document"}, schema.Value.Required)
+	assert.NotContains(t, schema.Value.Required, "description")
+	assert.NotContains(t, schema.Value.Required, "thumbnail")
+}
@@ -3,12 +3,18 @@ package openapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
@@ -20,6 +26,11 @@ type Config struct {
 	Info     Info                      `json:"info"`
 	Servers  []Server                  `json:"servers,omitempty"`
 	Security map[string]SecurityScheme `json:"security,omitempty"`
+	// Tags describes the tags used by operations and sets their display order.
+	Tags []Tag `json:"tags,omitempty"`
+	// OpenAPIVersion selects the document version: OpenAPIVersion30 (the default) or
+	// OpenAPIVersion31.
+	OpenAPIVersion string `json:"openapi_version,omitempty"`
 }
 
 // Info represents OpenAPI info object.
@@ -60,22 +71,137 @@ type SecurityScheme struct {
 	Name         string `json:"name,omitempty"`
 }
 
+// ErrMissingDocs is returned in strict mode when routes lack a summary or description.
+var ErrMissingDocs = errors.New("routes missing documentation")
+
 // Generator generates OpenAPI specifications from TypedHTTP routers.
 type Generator struct {
-	config Config
+	config          Config
+	requireDocs     bool
+	markUnavailable bool
+	externalSchemas map[reflect.Type]string
+	webhooks        []webhook
+	// fieldNames is the field name strategy of the router being documented, set on the
+	// per-call copy made by Generate.
+	fieldNames typedhttp.FieldNameStrategy
+}
+
+// GeneratorOption configures a Generator.
+type GeneratorOption func(*Generator)
+
+// WithRequireDocs makes Generate fail when any route is missing a summary or description.
+// All under-documented routes are reported in a single UndocumentedRoutesError.
+func WithRequireDocs() GeneratorOption {
+	return func(g *Generator) {
+		g.requireDocs = true
+	}
+}
+
+// WithUnavailableRoutes marks routes disabled with TypedRouter.SetRouteEnabled as
+// unavailable: their operations get an "x-unavailable" extension and a 503 response.
+func WithUnavailableRoutes() GeneratorOption {
+	return func(g *Generator) {
+		g.markUnavailable = true
+	}
+}
+
+// RegisterExternalSchema makes the generator reference t as ref, e.g.
+// "./common.yaml#/components/schemas/Money", instead of describing it inline.
+// Pointers to t are referenced the same way. Register types before calling Generate.
+func (g *Generator) RegisterExternalSchema(t reflect.Type, ref string) {
+	if g.externalSchemas == nil {
+		g.externalSchemas = make(map[reflect.Type]string)
+	}
+	g.externalSchemas[t] = ref
 }
 
 // NewGenerator creates a new OpenAPI generator.
-func NewGenerator(config *Config) *Generator {
-	return &Generator{
+func NewGenerator(config *Config, opts ...GeneratorOption) *Generator {
+	g := &Generator{
 		config: *config,
 	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// UndocumentedRoute describes a route that is missing documentation.
+type UndocumentedRoute struct {
+	Method  string
+	Path    string
+	Missing []string // "summary", "description"
+}
+
+// UndocumentedRoutesError lists every route that failed the documentation check.
+type UndocumentedRoutesError struct {
+	Routes []UndocumentedRoute
 }
 
-// Generate creates an OpenAPI specification from a TypedHTTP router.
+func (e *UndocumentedRoutesError) Error() string {
+	routes := make([]string, len(e.Routes))
+	for i, route := range e.Routes {
+		routes[i] = fmt.Sprintf("%s %s (missing %s)", route.Method, route.Path, strings.Join(route.Missing, ", "))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrMissingDocs, strings.Join(routes, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrMissingDocs).
+func (e *UndocumentedRoutesError) Unwrap() error {
+	return ErrMissingDocs
+}
+
+// checkDocs reports routes without a summary or description.
+func checkDocs(handlers []typedhttp.HandlerRegistration) error {
+	var undocumented []UndocumentedRoute
+
+	for i := range handlers {
+		var missing []string
+		if strings.TrimSpace(handlers[i].Metadata.Summary) == "" {
+			missing = append(missing, "summary")
+		}
+		if strings.TrimSpace(handlers[i].Metadata.Description) == "" {
+			missing = append(missing, "description")
+		}
+
+		if len(missing) > 0 {
+			undocumented = append(undocumented, UndocumentedRoute{
+				Method:  handlers[i].Method,
+				Path:    handlers[i].Path,
+				Missing: missing,
+			})
+		}
+	}
+
+	if len(undocumented) > 0 {
+		return &UndocumentedRoutesError{Routes: undocumented}
+	}
+
+	return nil
+}
+
+// Generate creates an OpenAPI specification from a TypedHTTP router. It is safe to
+// call concurrently, e.g. for several routers.
 func (g *Generator) Generate(router *typedhttp.TypedRouter) (*openapi3.T, error) {
+	// Work on a copy that carries the router's field names, leaving g unchanged
+	call := *g
+	call.fieldNames = router.FieldNameStrategy()
+
+	return call.generate(router)
+}
+
+// generate creates the specification for router with the field names set on g.
+func (g *Generator) generate(router *typedhttp.TypedRouter) (*openapi3.T, error) {
+	version, err := g.openAPIVersion()
+	if err != nil {
+		return nil, err
+	}
+
 	spec := &openapi3.T{
-		OpenAPI: "3.0.3",
+		OpenAPI: version,
 		Info: &openapi3.Info{
 			Title:       g.config.Info.Title,
 			Version:     g.config.Info.Version,
@@ -100,12 +226,42 @@ func (g *Generator) Generate(router *typedhttp.TypedRouter) (*openapi3.T, error)
 
 	// Process each registered handler
 	handlers := router.GetHandlers()
+	if g.requireDocs {
+		if err := checkDocs(handlers); err != nil {
+			return nil, err
+		}
+	}
+
+	operationIDs, err := assignOperationIDs(handlers)
+	if err != nil {
+		return nil, err
+	}
+
+	var usedTags []string
 	for i := range handlers {
 		err := g.processHandler(spec, &handlers[i])
 		if err != nil {
 			return nil, fmt.Errorf("failed to process handler %s %s: %w",
 				handlers[i].Method, handlers[i].Path, err)
 		}
+		usedTags = append(usedTags, handlers[i].Metadata.Tags...)
+
+		operation := spec.Paths.Find(handlers[i].Path).GetOperation(handlers[i].Method)
+		if operation != nil {
+			operation.OperationID = operationIDs[i]
+		}
+		if g.markUnavailable && !router.RouteEnabled(handlers[i].Method, handlers[i].Path) {
+			markUnavailable(operation)
+		}
+	}
+	spec.Tags = buildTags(g.config.Tags, usedTags)
+
+	if err := g.addWebhooks(spec); err != nil {
+		return nil, err
+	}
+
+	if version == OpenAPIVersion31 {
+		convertTo31(spec)
 	}
 
 	return spec, nil
@@ -131,13 +287,29 @@ func (g *Generator) processHandler(spec *openapi3.T, reg *typedhttp.HandlerRegis
 		return fmt.Errorf("failed to extract parameters: %w", err)
 	}
 	operation.Parameters = parameters
+	if param := reg.Config.DryRunParam; param != "" && operation.Parameters.GetByInAndName("query", param) == nil {
+		operation.Parameters = append(operation.Parameters, &openapi3.ParameterRef{Value: &openapi3.Parameter{
+			Name:        param,
+			In:          "query",
+			Description: "Validate the request and return it without executing it.",
+			Schema:      &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"boolean"}}},
+		}})
+	}
 
-	// Check if we need a request body
-	if g.needsRequestBody(reg.RequestType) {
-		requestBody, err := g.createRequestBody(reg.RequestType)
+	// Check if we need a request body; composed requests read it into their params
+	bodyType := reg.RequestType
+	if _, params, ok := requestParts(reg.RequestType); ok {
+		bodyType = params
+	}
+	if g.needsRequestBody(bodyType) {
+		requestBody, err := g.createRequestBody(bodyType)
 		if err != nil {
 			return fmt.Errorf("failed to create request body: %w", err)
 		}
+		addCodecContent(requestBody.Value.Content, reg.Config.BodyCodecs)
+		if err := g.restrictRequestContent(requestBody.Value.Content, bodyType, reg.Config.Consumes); err != nil {
+			return fmt.Errorf("failed to create request body: %w", err)
+		}
 		operation.RequestBody = requestBody
 	}
 
@@ -157,29 +329,47 @@ func (g *Generator) processHandler(spec *openapi3.T, reg *typedhttp.HandlerRegis
 		return fmt.Errorf("failed to apply middleware schema transformations: %w", err)
 	}
 
-	statusCode := "200"
-	description := "Success"
-	if reg.Method == http.MethodPost {
-		statusCode = "201"
-		description = "Created"
+	content := map[string]*openapi3.MediaType{
+		"application/json": {
+			Schema: finalResponseSchema,
+		},
+	}
+	addCodecContent(content, reg.Config.BodyCodecs)
+	addDeclaredContent(content, reg.Config.Produces)
+	if isStreamingType(reg.ResponseType) {
+		content = map[string]*openapi3.MediaType{
+			typedhttp.DefaultStreamContentType: {
+				Schema: binarySchema(),
+			},
+		}
+	}
+	if isRawType(reg.ResponseType) {
+		content = rawContent(reg.Config.Produces)
 	}
 
-	operation.Responses.Set(statusCode, &openapi3.ResponseRef{
-		Value: &openapi3.Response{
-			Description: &description,
-			Content: map[string]*openapi3.MediaType{
-				"application/json": {
-					Schema: finalResponseSchema,
-				},
+	if isRedirectType(reg.ResponseType) {
+		addRedirectResponses(operation, reg.Config)
+	}
+
+	for _, status := range successStatuses(reg) {
+		description := successDescription(status)
+		operation.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: &description,
+				Content:     content,
 			},
-		},
-	})
+		})
+	}
+
+	addResponseHeaders(operation, responseHeaderSpecs(reg))
 
 	// Add error responses if envelope middleware is present
 	if g.hasEnvelopeMiddleware(reg.MiddlewareEntries) {
 		g.addEnvelopeErrorResponses(operation)
 	}
 
+	operation.Tags = reg.Metadata.Tags
+
 	// Assign operation to method
 	switch reg.Method {
 	case http.MethodGet:
@@ -201,8 +391,38 @@ func (g *Generator) processHandler(spec *openapi3.T, reg *typedhttp.HandlerRegis
 	return nil
 }
 
+// markUnavailable documents that an operation is currently switched off.
+func markUnavailable(operation *openapi3.Operation) {
+	if operation == nil {
+		return
+	}
+
+	if operation.Extensions == nil {
+		operation.Extensions = make(map[string]interface{})
+	}
+	operation.Extensions["x-unavailable"] = true
+
+	description := "Service Unavailable"
+	operation.Responses.Set(strconv.Itoa(http.StatusServiceUnavailable), &openapi3.ResponseRef{
+		Value: &openapi3.Response{Description: &description},
+	})
+}
+
+// successDescription returns the response description for a success status.
+func successDescription(status int) string {
+	if status == http.StatusOK {
+		return "Success"
+	}
+
+	return http.StatusText(status)
+}
+
 // extractParameters extracts OpenAPI parameters from request type.
 func (g *Generator) extractParameters(requestType reflect.Type) (openapi3.Parameters, error) {
+	if shared, params, ok := requestParts(requestType); ok {
+		return g.extractComposedParameters(shared, params)
+	}
+
 	var parameters openapi3.Parameters
 
 	for i := 0; i < requestType.NumField(); i++ {
@@ -223,6 +443,27 @@ func (g *Generator) extractParameters(requestType reflect.Type) (openapi3.Parame
 	return parameters, nil
 }
 
+// extractComposedParameters returns the parameters of both parts of a composed request.
+// A parameter declared by both is listed once, as the shared fragment declares it.
+func (g *Generator) extractComposedParameters(shared, params reflect.Type) (openapi3.Parameters, error) {
+	parameters, err := g.extractParameters(shared)
+	if err != nil {
+		return nil, err
+	}
+
+	paramsParameters, err := g.extractParameters(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, parameter := range paramsParameters {
+		if parameters.GetByInAndName(parameter.Value.In, parameter.Value.Name) == nil {
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	return parameters, nil
+}
+
 // extractFieldParameters extracts parameters from a single field.
 func (g *Generator) extractFieldParameters(field *reflect.StructField) (openapi3.Parameters, error) {
 	var parameters openapi3.Parameters
@@ -256,7 +497,7 @@ func (g *Generator) extractFieldParameters(field *reflect.StructField) (openapi3
 	}
 
 	// Check for cookie parameters
-	if cookieName := field.Tag.Get("cookie"); cookieName != "" {
+	if cookieName := field.Tag.Get("cookie"); cookieName != "" && cookieName != typedhttp.CookieCatchAll {
 		required := strings.Contains(field.Tag.Get("validate"), "required")
 		param, err := g.createParameter(field, "cookie", cookieName, required)
 		if err != nil {
@@ -278,10 +519,27 @@ func (g *Generator) createQueryParameter(field *reflect.StructField, queryName s
 		return nil, err
 	}
 
-	if defaultValue != "" {
+	if defaultValue != "" && param.Value.Schema.Value != nil {
 		param.Value.Schema.Value.Default = g.parseDefaultValue(defaultValue, field.Type)
 	}
 
+	// Maps and structs are sent in bracket notation, e.g. filter[status]=open
+	if kind := field.Type.Kind(); kind == reflect.Map || (kind == reflect.Struct && field.Type != reflect.TypeOf(time.Time{})) {
+		explode := true
+		param.Value.Style = openapi3.SerializationDeepObject
+		param.Value.Explode = &explode
+	}
+
+	// Slices declare how the decoder expects their values to be delimited
+	if field.Type.Kind() == reflect.Slice && field.Type != reflect.TypeOf(net.IP{}) {
+		style, err := typedhttp.FieldQueryStyle(*field)
+		if err != nil {
+			return nil, fmt.Errorf("query parameter %s: %w", queryName, err)
+		}
+		param.Value.Style = style.Style
+		param.Value.Explode = &style.Explode
+	}
+
 	return param, nil
 }
 
@@ -303,17 +561,28 @@ func (g *Generator) createParameter(
 		Required: required,
 		Schema:   schema,
 	}
+	if example, ok := field.Tag.Lookup("example"); ok {
+		param.Example = parseExampleValue(example, field.Type)
+	}
+	param.Deprecated = isDeprecated(field)
+	if aliases := typedhttp.FieldAliases(*field); len(aliases) > 0 && in != "path" {
+		param.Description = "Also accepted under the deprecated names: " + strings.Join(aliases, ", ") + "."
+	}
 
 	return &openapi3.ParameterRef{Value: param}, nil
 }
 
 // needsRequestBody determines if a request type needs a request body.
 func (g *Generator) needsRequestBody(requestType reflect.Type) bool {
+	if typedhttp.BodyType(requestType) != nil {
+		return true
+	}
+
 	for i := 0; i < requestType.NumField(); i++ {
 		field := requestType.Field(i)
 
 		// Check for JSON body fields
-		if field.Tag.Get("json") != "" {
+		if _, ok := typedhttp.JSONFieldName(field, g.fieldNames); ok {
 			return true
 		}
 
@@ -341,12 +610,19 @@ func (g *Generator) createRequestBody(requestType reflect.Type) (*openapi3.Reque
 		}
 		content["multipart/form-data"] = &openapi3.MediaType{Schema: schema}
 	} else {
-		// Create JSON schema
+		// Create JSON schema, limited to body-tagged fields when there are any
+		if bodyType := typedhttp.BodyType(requestType); bodyType != nil {
+			requestType = bodyType
+		}
 		schema, err := g.createSchemaFromType(requestType)
 		if err != nil {
 			return nil, err
 		}
-		content["application/json"] = &openapi3.MediaType{Schema: schema}
+		mediaType := &openapi3.MediaType{Schema: schema}
+		if example, ok := buildExample(requestType, g.fieldNames); ok {
+			mediaType.Example = example
+		}
+		content["application/json"] = mediaType
 	}
 
 	return &openapi3.RequestBodyRef{
@@ -419,12 +695,315 @@ func (g *Generator) createFormSchema(requestType reflect.Type) (*openapi3.Schema
 			}
 		}
 
+		if isDeprecated(&field) && fieldSchema.Value != nil {
+			fieldSchema.Value.Deprecated = true
+		}
 		schema.Properties[formName] = fieldSchema
+
+		if hasValidationRule(field.Tag.Get("validate"), "required") {
+			schema.Required = append(schema.Required, formName)
+		}
 	}
 
 	return &openapi3.SchemaRef{Value: schema}, nil
 }
 
+// isDeprecated reports whether a field is marked deprecated:"true". Deprecation only
+// affects the document; the field is still decoded and encoded.
+func isDeprecated(field *reflect.StructField) bool {
+	deprecated, err := strconv.ParseBool(field.Tag.Get("deprecated"))
+
+	return err == nil && deprecated
+}
+
+// hasValidationRule reports whether a validate tag contains the given rule.
+func hasValidationRule(validate, rule string) bool {
+	for _, r := range strings.Split(validate, ",") {
+		if strings.TrimSpace(r) == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addCodecContent documents the handler's additional body codecs alongside its JSON content.
+func addCodecContent(content map[string]*openapi3.MediaType, codecs []typedhttp.BodyCodec) {
+	jsonContent, ok := content["application/json"]
+	if !ok {
+		return
+	}
+
+	for _, codec := range codecs {
+		content[codec.ContentType()] = &openapi3.MediaType{Schema: jsonContent.Schema}
+	}
+}
+
+// addDeclaredContent adds media types declared with WithProduces or WithConsumes,
+// describing each with the schema of the generated JSON (or form) content.
+func addDeclaredContent(content map[string]*openapi3.MediaType, mediaTypes []string) {
+	if len(mediaTypes) == 0 {
+		return
+	}
+
+	base, ok := content["application/json"]
+	if !ok {
+		names := make([]string, 0, len(content))
+		for name := range content {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return
+		}
+		sort.Strings(names)
+		base = content[names[0]]
+	}
+
+	for _, mediaType := range mediaTypes {
+		if _, exists := content[mediaType]; !exists {
+			content[mediaType] = &openapi3.MediaType{Schema: base.Schema}
+		}
+	}
+}
+
+// restrictRequestContent limits a request body to the media types declared with
+// WithConsumes, which the router enforces. Form media types are described by the form
+// fields when the request has any.
+func (g *Generator) restrictRequestContent(
+	content map[string]*openapi3.MediaType, requestType reflect.Type, mediaTypes []string,
+) error {
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+
+	addDeclaredContent(content, mediaTypes)
+	for name := range content {
+		if !slices.Contains(mediaTypes, name) {
+			delete(content, name)
+		}
+	}
+
+	for _, mediaType := range mediaTypes {
+		if mediaType != "multipart/form-data" && mediaType != "application/x-www-form-urlencoded" {
+			continue
+		}
+		if !hasFormFields(requestType) {
+			continue
+		}
+
+		schema, err := g.createFormSchema(requestType)
+		if err != nil {
+			return err
+		}
+		content[mediaType] = &openapi3.MediaType{Schema: schema}
+	}
+
+	return nil
+}
+
+// hasFormFields reports whether a request type binds any form-tagged fields.
+func hasFormFields(requestType reflect.Type) bool {
+	for i := 0; i < requestType.NumField(); i++ {
+		if requestType.Field(i).Tag.Get("form") != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isStreamingType reports whether a response type is streamed as raw bytes by the router.
+func isStreamingType(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	streamingType := reflect.TypeOf(typedhttp.StreamingResponse{})
+	if t == streamingType || t == reflect.PointerTo(streamingType) {
+		return true
+	}
+
+	return t.Implements(reflect.TypeOf((*io.Reader)(nil)).Elem())
+}
+
+// isRawType reports whether a response type is written verbatim by the router.
+func isRawType(t reflect.Type) bool {
+	rawType := reflect.TypeOf(typedhttp.Raw{})
+
+	return t == rawType || t == reflect.PointerTo(rawType)
+}
+
+// rawContent documents a pre-serialized body under the declared media types, or any
+// media type when none are declared. The body's shape is unknown, so its schema is empty.
+func rawContent(mediaTypes []string) map[string]*openapi3.MediaType {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"*/*"}
+	}
+
+	content := make(map[string]*openapi3.MediaType, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{}}}
+	}
+
+	return content
+}
+
+// composedRequest is implemented by typedhttp.Composed.
+type composedRequest interface {
+	RequestParts() (shared, params reflect.Type)
+}
+
+// composedRequestType is the interface implemented by composed request types.
+var composedRequestType = reflect.TypeOf((*composedRequest)(nil)).Elem()
+
+// requestParts returns the parts of a composed request type.
+func requestParts(t reflect.Type) (shared, params reflect.Type, ok bool) {
+	if t == nil || t.Kind() == reflect.Ptr || !t.Implements(composedRequestType) {
+		return nil, nil, false
+	}
+
+	shared, params = reflect.Zero(t).Interface().(composedRequest).RequestParts()
+
+	return shared, params, true
+}
+
+// itemStream is implemented by typedhttp.JSONStream.
+type itemStream interface {
+	ItemType() reflect.Type
+}
+
+// itemStreamType is the interface implemented by streamed JSON array responses.
+var itemStreamType = reflect.TypeOf((*itemStream)(nil)).Elem()
+
+// unionValue is implemented by typedhttp.Union.
+type unionValue interface {
+	UnionVariants() (discriminator string, variants map[string]reflect.Type)
+}
+
+// unionValueType is the interface implemented by discriminated unions.
+var unionValueType = reflect.TypeOf((*unionValue)(nil)).Elem()
+
+// redirectResponseType is the interface implemented by redirect responses.
+var redirectResponseType = reflect.TypeOf((*typedhttp.RedirectResponse)(nil)).Elem()
+
+// isRedirectType reports whether a response type is written as a redirect by the router.
+func isRedirectType(t reflect.Type) bool {
+	return t != nil && t.Implements(redirectResponseType)
+}
+
+// successStatuses returns the non-redirect success statuses of a route.
+func successStatuses(reg *typedhttp.HandlerRegistration) []int {
+	if isRedirectType(reg.ResponseType) {
+		return nil
+	}
+
+	return typedhttp.SuccessStatuses(reg.Method, reg.Config)
+}
+
+// addRedirectResponses documents the 3xx responses of a redirecting route.
+// responseHeaderSpecs returns the response headers declared by the middleware of reg and
+// by its handler options.
+func responseHeaderSpecs(reg *typedhttp.HandlerRegistration) []typedhttp.ResponseHeaderSpec {
+	var specs []typedhttp.ResponseHeaderSpec
+	for _, entry := range slices.Concat(reg.MiddlewareEntries, reg.Config.TypedMiddleware) {
+		if modifier, ok := entry.Middleware.(typedhttp.ResponseHeaderModifier); ok {
+			specs = append(specs, modifier.ResponseHeaders()...)
+		}
+	}
+
+	return append(specs, reg.Config.ResponseHeaders...)
+}
+
+// addResponseHeaders documents specs under the headers of the operation's responses.
+// Specs without a status apply to every success and redirect response; a spec for an
+// undocumented status adds that response.
+func addResponseHeaders(operation *openapi3.Operation, specs []typedhttp.ResponseHeaderSpec) {
+	for _, spec := range specs {
+		statuses := []string{strconv.Itoa(spec.Status)}
+		if spec.Status == 0 {
+			statuses = statuses[:0]
+			for code := range operation.Responses.Map() {
+				if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 400 {
+					statuses = append(statuses, code)
+				}
+			}
+		}
+
+		for _, code := range statuses {
+			response := operation.Responses.Value(code)
+			if response == nil || response.Value == nil {
+				description := http.StatusText(spec.Status)
+				response = &openapi3.ResponseRef{Value: &openapi3.Response{Description: &description}}
+				operation.Responses.Set(code, response)
+			}
+			if response.Value.Headers == nil {
+				response.Value.Headers = openapi3.Headers{}
+			}
+			response.Value.Headers[spec.Name] = &openapi3.HeaderRef{Value: responseHeader(spec)}
+		}
+	}
+}
+
+// responseHeader converts spec to an OpenAPI header. A nil schema means a string.
+func responseHeader(spec typedhttp.ResponseHeaderSpec) *openapi3.Header {
+	schema := spec.Schema
+	if schema == nil {
+		schema = openapi3.NewStringSchema()
+	}
+
+	return &openapi3.Header{
+		Parameter: openapi3.Parameter{
+			Description: spec.Description,
+			Schema:      &openapi3.SchemaRef{Value: schema},
+		},
+	}
+}
+
+// Statuses come from WithSuccessStatuses, defaulting to 302 Found.
+func addRedirectResponses(operation *openapi3.Operation, config typedhttp.HandlerConfig) {
+	statuses := config.SuccessStatuses
+	if len(statuses) == 0 {
+		statuses = []int{http.StatusFound}
+	}
+
+	for _, status := range statuses {
+		if !typedhttp.IsRedirectStatus(status) {
+			continue
+		}
+
+		description := http.StatusText(status)
+		operation.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: &description,
+				Headers: openapi3.Headers{
+					"Location": &openapi3.HeaderRef{
+						Value: &openapi3.Header{
+							Parameter: openapi3.Parameter{
+								Description: "URL to redirect to",
+								Required:    true,
+								Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{
+									Type:   &openapi3.Types{"string"},
+									Format: "uri-reference",
+								}},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+}
+
+// binarySchema returns a schema describing raw binary content.
+func binarySchema() *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			Type:   &openapi3.Types{"string"},
+			Format: "binary",
+		},
+	}
+}
+
 // createResponseSchema creates schema for response type.
 func (g *Generator) createResponseSchema(responseType reflect.Type) (*openapi3.SchemaRef, error) {
 	return g.createSchemaFromType(responseType)
@@ -432,8 +1011,36 @@ func (g *Generator) createResponseSchema(responseType reflect.Type) (*openapi3.S
 
 // createSchemaFromType creates OpenAPI schema from Go type.
 func (g *Generator) createSchemaFromType(t reflect.Type) (*openapi3.SchemaRef, error) {
+	if ref, ok := g.externalSchemas[t]; ok {
+		return &openapi3.SchemaRef{Ref: ref}, nil
+	}
+
 	schema := &openapi3.Schema{}
 
+	// time.Time marshals to an RFC 3339 string, not an object
+	if t == reflect.TypeOf(time.Time{}) {
+		schema.Type = &openapi3.Types{"string"}
+		schema.Format = "date-time"
+
+		return &openapi3.SchemaRef{Value: schema}, nil
+	}
+
+	// JSON streams are written as an array of their items
+	if t.Kind() != reflect.Ptr && t.Implements(itemStreamType) {
+		items, err := g.createSchemaFromType(reflect.Zero(t).Interface().(itemStream).ItemType())
+		if err != nil {
+			return nil, err
+		}
+		schema.Type = &openapi3.Types{"array"}
+		schema.Items = items
+
+		return &openapi3.SchemaRef{Value: schema}, nil
+	}
+
+	if t.Kind() != reflect.Ptr && t.Implements(unionValueType) {
+		return g.createUnionSchema(t)
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		schema.Type = &openapi3.Types{"string"}
@@ -452,19 +1059,13 @@ func (g *Generator) createSchemaFromType(t reflect.Type) (*openapi3.SchemaRef, e
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
 
-			// Skip unexported fields
-			if !field.IsExported() {
-				continue
-			}
-
-			jsonName := field.Tag.Get("json")
-			if jsonName == "" || jsonName == "-" {
+			fieldName, ok := typedhttp.JSONFieldName(field, g.fieldNames)
+			if !ok {
 				continue
 			}
 
 			// Handle omitempty
-			parts := strings.Split(jsonName, ",")
-			fieldName := parts[0]
+			parts := strings.Split(field.Tag.Get("json"), ",")
 			omitempty := len(parts) > 1 && parts[1] == "omitempty"
 
 			fieldSchema, err := g.createSchemaFromType(field.Type)
@@ -472,6 +1073,19 @@ func (g *Generator) createSchemaFromType(t reflect.Type) (*openapi3.SchemaRef, e
 				return nil, err
 			}
 
+			if example, ok := field.Tag.Lookup("example"); ok && fieldSchema.Value != nil {
+				fieldSchema.Value.Example = parseExampleValue(example, field.Type)
+			}
+			g.applyValidationToSchema(fieldSchema, field.Tag.Get("validate"))
+			if isDeprecated(&field) && fieldSchema.Value != nil {
+				fieldSchema.Value.Deprecated = true
+			}
+
+			// A nil pointer is encoded as null unless it is omitted
+			if field.Type.Kind() == reflect.Ptr && !omitempty && fieldSchema.Value != nil {
+				fieldSchema.Value.Nullable = true
+			}
+
 			schema.Properties[fieldName] = fieldSchema
 
 			// Add to required if not omitempty
@@ -514,6 +1128,49 @@ func (g *Generator) createSchemaFromType(t reflect.Type) (*openapi3.SchemaRef, e
 	return &openapi3.SchemaRef{Value: schema}, nil
 }
 
+// createUnionSchema describes a discriminated union as a oneOf of its variants. Each
+// variant requires the discriminator property, fixed to the value that selects it.
+func (g *Generator) createUnionSchema(t reflect.Type) (*openapi3.SchemaRef, error) {
+	discriminator, variants := reflect.Zero(t).Interface().(unionValue).UnionVariants()
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("union %s has no registered variants", t)
+	}
+
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schema := &openapi3.Schema{
+		Discriminator: &openapi3.Discriminator{PropertyName: discriminator},
+	}
+	for _, name := range names {
+		variant, err := g.createSchemaFromType(variants[name])
+		if err != nil {
+			return nil, err
+		}
+		if variant.Value == nil {
+			return nil, fmt.Errorf("union %s variant %q must be described inline", t, name)
+		}
+
+		if variant.Value.Properties == nil {
+			variant.Value.Properties = make(openapi3.Schemas)
+		}
+		variant.Value.Properties[discriminator] = &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type: &openapi3.Types{"string"},
+			Enum: []interface{}{name},
+		}}
+		if !slices.Contains(variant.Value.Required, discriminator) {
+			variant.Value.Required = append([]string{discriminator}, variant.Value.Required...)
+		}
+
+		schema.OneOf = append(schema.OneOf, variant)
+	}
+
+	return &openapi3.SchemaRef{Value: schema}, nil
+}
+
 // applyValidationToSchema applies validation constraints to schema.
 func (g *Generator) applyValidationToSchema(schemaRef *openapi3.SchemaRef, validate string) {
 	if validate == "" || schemaRef.Value == nil {
@@ -525,16 +1182,39 @@ func (g *Generator) applyValidationToSchema(schemaRef *openapi3.SchemaRef, valid
 
 	for _, rule := range rules {
 		rule = strings.TrimSpace(rule)
+
+		// Rules after dive apply to each element
+		if rule == "dive" {
+			schema = diveSchema(schema)
+			if schema == nil {
+				return
+			}
+
+			continue
+		}
+
 		g.applyValidationRule(schema, rule)
 	}
 }
 
+// diveSchema returns the inline item schema of an array, or nil when the items are
+// not an array or are a shared component that must not be modified.
+func diveSchema(schema *openapi3.Schema) *openapi3.Schema {
+	if !schema.Type.Is("array") || schema.Items == nil || schema.Items.Ref != "" {
+		return nil
+	}
+
+	return schema.Items.Value
+}
+
 // applyValidationRule applies a single validation rule to the schema.
 func (g *Generator) applyValidationRule(schema *openapi3.Schema, rule string) {
 	if strings.HasPrefix(rule, "min=") {
 		g.applyMinValidation(schema, rule)
 	} else if strings.HasPrefix(rule, "max=") {
 		g.applyMaxValidation(schema, rule)
+	} else if strings.HasPrefix(rule, typedhttp.PatternTag+"=") {
+		schema.Pattern = typedhttp.UnescapePattern(strings.TrimPrefix(rule, typedhttp.PatternTag+"="))
 	} else if rule == "email" {
 		schema.Format = "email"
 	} else if rule == "uuid" {
@@ -562,6 +1242,10 @@ func (g *Generator) applyMinValidation(schema *openapi3.Schema, rule string) {
 	case "integer", "number":
 		minFloat := float64(minVal)
 		schema.Min = &minFloat
+	case "array":
+		if minVal >= 0 {
+			schema.MinItems = uint64(minVal)
+		}
 	}
 }
 
@@ -586,6 +1270,11 @@ func (g *Generator) applyMaxValidation(schema *openapi3.Schema, rule string) {
 	case "integer", "number":
 		maxFloat := float64(maxVal)
 		schema.Max = &maxFloat
+	case "array":
+		if maxVal >= 0 {
+			maxPtr := uint64(maxVal)
+			schema.MaxItems = &maxPtr
+		}
 	}
 }
 
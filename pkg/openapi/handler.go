@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// SpecFormat identifies the serialization format served by a SpecHandler.
+type SpecFormat string
+
+const (
+	FormatJSON SpecFormat = "json"
+	FormatYAML SpecFormat = "yaml"
+)
+
+// SpecHandlerConfig holds configuration for the live OpenAPI spec handler.
+type SpecHandlerConfig struct {
+	// CacheEnabled keeps the first generated document and serves it until Invalidate is called.
+	// It is enabled by default, which is the right choice for production where routes are static.
+	CacheEnabled bool
+	Format       SpecFormat
+}
+
+// SpecHandlerOption configures a SpecHandler.
+type SpecHandlerOption func(*SpecHandlerConfig)
+
+// WithSpecCache enables or disables caching of the generated document.
+func WithSpecCache(enabled bool) SpecHandlerOption {
+	return func(c *SpecHandlerConfig) {
+		c.CacheEnabled = enabled
+	}
+}
+
+// WithDevelopmentMode regenerates the document on every request so that routes
+// registered after startup are reflected without a restart.
+func WithDevelopmentMode() SpecHandlerOption {
+	return WithSpecCache(false)
+}
+
+// WithSpecFormat sets the serialization format of the served document.
+func WithSpecFormat(format SpecFormat) SpecHandlerOption {
+	return func(c *SpecHandlerConfig) {
+		c.Format = format
+	}
+}
+
+// SpecHandler serves the OpenAPI document of a router, generating it lazily on demand.
+type SpecHandler struct {
+	cache  *SpecCache
+	config SpecHandlerConfig
+}
+
+// NewSpecHandler creates an http.Handler that serves the OpenAPI document for the router.
+//
+// The document is generated on the first request rather than at construction time.
+// With the default configuration it is then cached; use WithDevelopmentMode to
+// regenerate it per request, or call Invalidate after changing the routes.
+func NewSpecHandler(router *typedhttp.TypedRouter, config *Config, opts ...SpecHandlerOption) *SpecHandler {
+	return NewCachedSpecHandler(NewSpecCache(router, config), opts...)
+}
+
+// NewCachedSpecHandler creates a SpecHandler backed by an existing cache, so handlers
+// for several formats share one generated document.
+func NewCachedSpecHandler(cache *SpecCache, opts ...SpecHandlerOption) *SpecHandler {
+	handlerConfig := SpecHandlerConfig{
+		CacheEnabled: true,
+		Format:       FormatJSON,
+	}
+
+	for _, opt := range opts {
+		opt(&handlerConfig)
+	}
+
+	return &SpecHandler{
+		cache:  cache,
+		config: handlerConfig,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SpecHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	data, err := h.document()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	w.Header().Set("Content-Type", h.contentType())
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// Invalidate drops the cached document so the next request regenerates it.
+func (h *SpecHandler) Invalidate() {
+	h.cache.Invalidate()
+}
+
+// document returns the serialized spec, generating it if needed.
+func (h *SpecHandler) document() ([]byte, error) {
+	if !h.config.CacheEnabled {
+		h.cache.Invalidate()
+	}
+
+	if h.config.Format == FormatYAML {
+		return h.cache.YAML()
+	}
+
+	return h.cache.JSON()
+}
+
+// contentType returns the response content type for the configured format.
+func (h *SpecHandler) contentType() string {
+	if h.config.Format == FormatYAML {
+		return "application/yaml"
+	}
+
+	return "application/json"
+}
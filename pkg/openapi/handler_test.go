@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func serveSpec(t *testing.T, handler http.Handler) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func loadServedSpec(t *testing.T, data []byte) *openapi3.T {
+	t.Helper()
+
+	spec, err := openapi3.NewLoader().LoadFromData(data)
+	require.NoError(t, err)
+
+	return spec
+}
+
+func TestSpecHandler_ServesValidSpec(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	handler := NewSpecHandler(router, &Config{Info: Info{Title: "Live API", Version: "1.0.0"}})
+
+	w := serveSpec(t, handler)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	spec := loadServedSpec(t, w.Body.Bytes())
+	require.NoError(t, spec.Validate(context.Background()))
+	assert.Equal(t, "Live API", spec.Info.Title)
+	assert.NotNil(t, spec.Paths.Find("/users/{id}"))
+}
+
+func TestSpecHandler_DevelopmentModeReflectsNewRoutes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	handler := NewSpecHandler(router, &Config{Info: Info{Title: "Dev API", Version: "1.0.0"}}, WithDevelopmentMode())
+
+	first := loadServedSpec(t, serveSpec(t, handler).Body.Bytes())
+	assert.Nil(t, first.Paths.Find("/users"))
+
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+
+	second := loadServedSpec(t, serveSpec(t, handler).Body.Bytes())
+	require.NotNil(t, second.Paths.Find("/users"))
+	assert.NotNil(t, second.Paths.Find("/users").Post)
+}
+
+func TestSpecHandler_ProductionModeUsesCache(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	handler := NewSpecHandler(router, &Config{Info: Info{Title: "Prod API", Version: "1.0.0"}})
+	assert.True(t, handler.config.CacheEnabled)
+
+	first := serveSpec(t, handler).Body.String()
+
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+
+	second := serveSpec(t, handler).Body.String()
+	assert.Equal(t, first, second, "cached document should be served until invalidated")
+	assert.Nil(t, loadServedSpec(t, []byte(second)).Paths.Find("/users"))
+
+	handler.Invalidate()
+
+	third := loadServedSpec(t, serveSpec(t, handler).Body.Bytes())
+	assert.NotNil(t, third.Paths.Find("/users"))
+}
+
+func TestSpecHandler_YAMLFormat(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	handler := NewSpecHandler(router, &Config{Info: Info{Title: "YAML API", Version: "1.0.0"}}, WithSpecFormat(FormatYAML))
+
+	w := serveSpec(t, handler)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+
+	var doc map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+}
@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ExportedEvent struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+}
+
+type ExportEventsHandler struct{}
+
+func (h *ExportEventsHandler) Handle(_ context.Context, _ struct{}) (typedhttp.JSONStream[ExportedEvent], error) {
+	return typedhttp.StreamSeq(slices.Values([]ExportedEvent{})), nil
+}
+
+func TestGenerate_JSONStreamDocumentedAsArray(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/events/export", &ExportEventsHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	response := spec.Paths.Find("/events/export").Get.Responses.Status(200)
+	require.NotNil(t, response)
+	mediaType := response.Value.Content["application/json"]
+	require.NotNil(t, mediaType)
+
+	schema := mediaType.Schema.Value
+	assert.Equal(t, "array", (*schema.Type)[0])
+	require.NotNil(t, schema.Items)
+	assert.Contains(t, schema.Items.Value.Properties, "id")
+	assert.Contains(t, schema.Items.Value.Properties, "kind")
+}
@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AuditedUpdateRequest struct {
+	ID        string `path:"id"`
+	Title     string `json:"title"`
+	RemoteIP  string `meta:"remote_ip"`
+	UserAgent string `meta:"user_agent"`
+}
+
+type AuditedUpdateHandler struct{}
+
+func (h *AuditedUpdateHandler) Handle(_ context.Context, _ AuditedUpdateRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_MetaFieldsAreNotDocumented(t *testing.T) {
+	router := typedhttp.NewRouter(typedhttp.WithFieldNameStrategy(typedhttp.SnakeCase))
+	typedhttp.PUT(router, "/documents/{id}", &AuditedUpdateHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Docs", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	operation := spec.Paths.Find("/documents/{id}").Put
+	require.Len(t, operation.Parameters, 1)
+	assert.Equal(t, "id", operation.Parameters[0].Value.Name)
+
+	schema := operation.RequestBody.Value.Content["application/json"].Schema.Value
+	assert.Equal(t, []string{"title"}, keys(schema.Properties))
+}
+
+type CreateNoteRequest struct {
+	Title  string `json:"title"`
+	UserID string `json:"user_id" principal:"id"`
+}
+
+type CreateNoteHandler struct{}
+
+func (h *CreateNoteHandler) Handle(_ context.Context, _ CreateNoteRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_PrincipalFieldsAreNotDocumented(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/notes", &CreateNoteHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Notes", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	schema := spec.Paths.Find("/notes").Post.RequestBody.Value.Content["application/json"].Schema.Value
+	assert.Equal(t, []string{"title"}, keys(schema.Properties))
+}
@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CreateOrderRequest struct {
+	Item     string `json:"item" validate:"required"`
+	Quantity int    `json:"quantity"`
+}
+
+type OrderResponse struct {
+	ID string `json:"id"`
+}
+
+type CreateOrderHandler struct{}
+
+func (h *CreateOrderHandler) Handle(_ context.Context, _ CreateOrderRequest) (OrderResponse, error) {
+	return OrderResponse{ID: "1"}, nil
+}
+
+func TestGenerate_ListsMsgpackContentType(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/orders", &CreateOrderHandler{}, typedhttp.WithMsgpack())
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Msgpack API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	create := spec.Paths.Find("/orders").Post
+	require.NotNil(t, create.RequestBody)
+	requestContent := create.RequestBody.Value.Content
+	require.Contains(t, requestContent, "application/json")
+	require.Contains(t, requestContent, typedhttp.MediaTypeMsgpack)
+	assert.Equal(t, requestContent["application/json"].Schema, requestContent[typedhttp.MediaTypeMsgpack].Schema)
+	assert.Contains(t, create.Responses.Status(201).Value.Content, typedhttp.MediaTypeMsgpack)
+
+	get := spec.Paths.Find("/users/{id}").Get
+	assert.NotContains(t, get.Responses.Status(200).Value.Content, typedhttp.MediaTypeMsgpack,
+		"routes without WithMsgpack only document JSON")
+}
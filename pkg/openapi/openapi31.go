@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OpenAPI versions the generator can emit.
+const (
+	OpenAPIVersion30 = "3.0.3"
+	OpenAPIVersion31 = "3.1.0"
+)
+
+// openAPIVersion returns the configured document version, validating it.
+func (g *Generator) openAPIVersion() (string, error) {
+	switch g.config.OpenAPIVersion {
+	case "", OpenAPIVersion30:
+		return OpenAPIVersion30, nil
+	case OpenAPIVersion31:
+		return OpenAPIVersion31, nil
+	default:
+		return "", fmt.Errorf("unsupported OpenAPI version %q", g.config.OpenAPIVersion)
+	}
+}
+
+// convertTo31 rewrites the 3.0 constructs of spec that OpenAPI 3.1 replaced: nullable
+// becomes a "null" entry in the schema type, a schema example becomes a one-element
+// examples array, and webhooks move to the top-level webhooks section. Parameter and
+// media type examples are unchanged in 3.1.
+func convertTo31(spec *openapi3.T) {
+	c := &converter31{seen: make(map[*openapi3.Schema]bool)}
+
+	if spec.Components != nil {
+		for _, schema := range spec.Components.Schemas {
+			c.schema(schema)
+		}
+	}
+	if spec.Paths != nil {
+		for _, pathItem := range spec.Paths.Map() {
+			c.pathItem(pathItem)
+		}
+	}
+	if webhooks, ok := spec.Extensions[WebhooksExtension].(map[string]*openapi3.PathItem); ok {
+		for _, pathItem := range webhooks {
+			c.pathItem(pathItem)
+		}
+		delete(spec.Extensions, WebhooksExtension)
+		spec.Extensions["webhooks"] = webhooks
+	}
+}
+
+// converter31 walks a document once, converting each schema a single time.
+type converter31 struct {
+	seen map[*openapi3.Schema]bool
+}
+
+func (c *converter31) pathItem(pathItem *openapi3.PathItem) {
+	c.parameters(pathItem.Parameters)
+	for _, operation := range pathItem.Operations() {
+		c.parameters(operation.Parameters)
+		if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+			c.content(operation.RequestBody.Value.Content)
+		}
+		if operation.Responses == nil {
+			continue
+		}
+		for _, response := range operation.Responses.Map() {
+			if response.Value == nil {
+				continue
+			}
+			c.content(response.Value.Content)
+			for _, header := range response.Value.Headers {
+				if header.Value != nil {
+					c.schema(header.Value.Schema)
+				}
+			}
+		}
+	}
+}
+
+func (c *converter31) parameters(parameters openapi3.Parameters) {
+	for _, parameter := range parameters {
+		if parameter.Value != nil {
+			c.schema(parameter.Value.Schema)
+		}
+	}
+}
+
+func (c *converter31) content(content openapi3.Content) {
+	for _, mediaType := range content {
+		if mediaType != nil {
+			c.schema(mediaType.Schema)
+		}
+	}
+}
+
+func (c *converter31) schema(ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil || c.seen[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	c.seen[schema] = true
+
+	if schema.Nullable {
+		schema.Nullable = false
+		if schema.Type != nil && !schema.Type.Includes("null") {
+			types := append(openapi3.Types{}, *schema.Type...)
+			types = append(types, "null")
+			schema.Type = &types
+		}
+	}
+	if schema.Example != nil {
+		if schema.Extensions == nil {
+			schema.Extensions = make(map[string]interface{})
+		}
+		schema.Extensions["examples"] = []interface{}{schema.Example}
+		schema.Example = nil
+	}
+
+	for _, property := range schema.Properties {
+		c.schema(property)
+	}
+	c.schema(schema.Items)
+	c.schema(schema.Not)
+	c.schema(schema.AdditionalProperties.Schema)
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, sub := range group {
+			c.schema(sub)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type GetProfileRequest struct {
+	ID string `path:"id"`
+}
+
+type ProfileResponse struct {
+	ID       string  `json:"id" example:"u-42"`
+	Nickname *string `json:"nickname"`
+	Bio      *string `json:"bio,omitempty"`
+}
+
+type GetProfileHandler struct{}
+
+func (h *GetProfileHandler) Handle(_ context.Context, req GetProfileRequest) (ProfileResponse, error) {
+	return ProfileResponse{ID: req.ID}, nil
+}
+
+// generateDocument renders the profile API at version as a generic JSON document.
+func generateDocument(t *testing.T, version string) map[string]interface{} {
+	t.Helper()
+
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/profiles/{id}", &GetProfileHandler{})
+
+	generator := NewGenerator(&Config{
+		Info:           Info{Title: "Profiles API", Version: "1.0.0"},
+		OpenAPIVersion: version,
+	})
+	generator.AddWebhook("profileUpdated", "post", reflect.TypeOf(ProfileResponse{}))
+
+	spec, err := generator.Generate(router)
+	require.NoError(t, err)
+	data, err := generator.GenerateJSON(spec)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	return doc
+}
+
+// profileProperties returns the response schema properties of the profile route.
+func profileProperties(t *testing.T, doc map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	schema := doc["paths"].(map[string]interface{})["/profiles/{id}"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+
+	return schema["properties"].(map[string]interface{})
+}
+
+func TestOpenAPIVersion_DefaultsTo30(t *testing.T) {
+	doc := generateDocument(t, "")
+
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	properties := profileProperties(t, doc)
+	nickname := properties["nickname"].(map[string]interface{})
+	assert.Equal(t, "string", nickname["type"])
+	assert.Equal(t, true, nickname["nullable"])
+	assert.NotContains(t, properties["bio"], "nullable", "omitted pointers are never null")
+
+	id := properties["id"].(map[string]interface{})
+	assert.Equal(t, "u-42", id["example"])
+	assert.NotContains(t, id, "examples")
+
+	assert.Contains(t, doc, WebhooksExtension)
+	assert.NotContains(t, doc, "webhooks")
+}
+
+func TestOpenAPIVersion_31(t *testing.T) {
+	doc := generateDocument(t, OpenAPIVersion31)
+
+	assert.Equal(t, "3.1.0", doc["openapi"])
+
+	properties := profileProperties(t, doc)
+	nickname := properties["nickname"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"string", "null"}, nickname["type"])
+	assert.NotContains(t, nickname, "nullable")
+	assert.Equal(t, "string", properties["bio"].(map[string]interface{})["type"])
+
+	id := properties["id"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"u-42"}, id["examples"])
+	assert.NotContains(t, id, "example")
+
+	require.Contains(t, doc, "webhooks")
+	assert.NotContains(t, doc, WebhooksExtension)
+	webhookSchema := doc["webhooks"].(map[string]interface{})["profileUpdated"].(map[string]interface{})["post"].(map[string]interface{})["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	nickname = webhookSchema["properties"].(map[string]interface{})["nickname"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"string", "null"}, nickname["type"])
+}
+
+func TestOpenAPIVersion_Unsupported(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/profiles/{id}", &GetProfileHandler{})
+
+	generator := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}, OpenAPIVersion: "2.0"})
+	_, err := generator.Generate(router)
+
+	assert.ErrorContains(t, err, `unsupported OpenAPI version "2.0"`)
+}
@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// ErrDuplicateOperationID is returned when two routes set the same operationId with WithOperationID.
+var ErrDuplicateOperationID = errors.New("duplicate operationId")
+
+// assignOperationIDs returns the operationId of every handler, by index.
+// Explicit ids set with WithOperationID must be unique. Ids derived from the method
+// and path that collide with another id get a numeric suffix, e.g. "getUsers2".
+func assignOperationIDs(handlers []typedhttp.HandlerRegistration) ([]string, error) {
+	ids := make([]string, len(handlers))
+	owners := make(map[string]int, len(handlers))
+
+	for i := range handlers {
+		id := handlers[i].Metadata.OperationID
+		if id == "" {
+			continue
+		}
+		if owner, ok := owners[id]; ok {
+			return nil, fmt.Errorf("%w %q: %s %s and %s %s", ErrDuplicateOperationID, id,
+				handlers[owner].Method, handlers[owner].Path, handlers[i].Method, handlers[i].Path)
+		}
+		owners[id] = i
+		ids[i] = id
+	}
+
+	for i := range handlers {
+		if ids[i] != "" {
+			continue
+		}
+		base := deriveOperationID(handlers[i].Method, handlers[i].Path)
+		id := base
+		for n := 2; ; n++ {
+			if _, taken := owners[id]; !taken {
+				break
+			}
+			id = base + strconv.Itoa(n)
+		}
+		owners[id] = i
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// deriveOperationID builds a camelCase id from the method and path,
+// e.g. GET /users/{id}/orders becomes "getUsersByIdOrders".
+func deriveOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			b.WriteString("By")
+			segment = strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		}
+		words := strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_DerivesOperationIDs(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	assert.Equal(t, "getUsersById", spec.Paths.Find("/users/{id}").Get.OperationID)
+	assert.Equal(t, "postUsers", spec.Paths.Find("/users").Post.OperationID)
+
+	data, err := spec.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"operationId":"getUsersById"`)
+}
+
+func TestWithOperationID(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{}, typedhttp.WithOperationID("getUser"))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	assert.Equal(t, "getUser", spec.Paths.Find("/users/{id}").Get.OperationID)
+}
+
+func TestGenerate_OperationIDCollisions(t *testing.T) {
+	t.Run("derived ids are disambiguated", func(t *testing.T) {
+		router := typedhttp.NewRouter()
+		typedhttp.POST(router, "/user-list", &CreateUserHandler{})
+		typedhttp.POST(router, "/user/list", &CreateUserHandler{})
+		typedhttp.POST(router, "/users", &CreateUserHandler{}, typedhttp.WithOperationID("postUserList"))
+
+		spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+		require.NoError(t, err)
+
+		assert.Equal(t, "postUserList2", spec.Paths.Find("/user-list").Post.OperationID)
+		assert.Equal(t, "postUserList3", spec.Paths.Find("/user/list").Post.OperationID)
+		assert.Equal(t, "postUserList", spec.Paths.Find("/users").Post.OperationID)
+	})
+
+	t.Run("explicit duplicates are an error", func(t *testing.T) {
+		router := typedhttp.NewRouter()
+		typedhttp.GET(router, "/users/{id}", &GetUserHandler{}, typedhttp.WithOperationID("getUser"))
+		typedhttp.POST(router, "/users", &CreateUserHandler{}, typedhttp.WithOperationID("getUser"))
+
+		_, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+		require.ErrorIs(t, err, ErrDuplicateOperationID)
+		assert.Contains(t, err.Error(), "GET /users/{id} and POST /users")
+	})
+}
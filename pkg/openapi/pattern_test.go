@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CreateRouteRequest struct {
+	Origin      string `json:"origin" validate:"required,regexp=^[A-Z]{3}$"`
+	Destination string `json:"destination" validate:"regexp=^[A-Z]{20x2C3}$"`
+	Carrier     string `query:"carrier" validate:"omitempty,regexp=^(AA0x7CBA)$"`
+}
+
+type CreateRouteResponse struct {
+	ID string `json:"id"`
+}
+
+type CreateRouteHandler struct{}
+
+func (h *CreateRouteHandler) Handle(_ context.Context, req CreateRouteRequest) (CreateRouteResponse, error) {
+	return CreateRouteResponse{ID: req.Origin + "-" + req.Destination}, nil
+}
+
+func TestPattern_InSpec(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/routes", &CreateRouteHandler{})
+
+	generator := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	spec, err := generator.Generate(router)
+	require.NoError(t, err)
+
+	assertPatterns := func(t *testing.T, spec *openapi3.T) {
+		t.Helper()
+
+		operation := spec.Paths.Find("/routes").Post
+		body := operation.RequestBody.Value.Content["application/json"].Schema.Value
+		assert.Equal(t, "^[A-Z]{3}$", body.Properties["origin"].Value.Pattern)
+		assert.Equal(t, "^[A-Z]{2,3}$", body.Properties["destination"].Value.Pattern)
+		assert.Equal(t, "^(AA|BA)$", operation.Parameters.GetByInAndName("query", "carrier").Schema.Value.Pattern)
+	}
+
+	assertPatterns(t, spec)
+
+	// The expressions must survive serialization
+	jsonData, err := generator.GenerateJSON(spec)
+	require.NoError(t, err)
+	fromJSON, err := openapi3.NewLoader().LoadFromData(jsonData)
+	require.NoError(t, err)
+	assertPatterns(t, fromJSON)
+
+	yamlData, err := generator.GenerateYAML(spec)
+	require.NoError(t, err)
+	fromYAML, err := openapi3.NewLoader().LoadFromData(yamlData)
+	require.NoError(t, err)
+	assertPatterns(t, fromYAML)
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// csvCodec renders order responses as CSV.
+type csvCodec struct{}
+
+func (csvCodec) ContentType() string { return "text/csv" }
+
+func (csvCodec) Marshal(v interface{}) ([]byte, error) {
+	order, ok := v.(OrderResponse)
+	if !ok {
+		return nil, fmt.Errorf("cannot render %T as CSV", v)
+	}
+
+	return []byte("id\n" + order.ID + "\n"), nil
+}
+
+func (csvCodec) Unmarshal([]byte, interface{}) error {
+	return errors.New("CSV request bodies are not supported")
+}
+
+func TestGenerate_NegotiatedResponseTypes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/orders", &CreateOrderHandler{}, typedhttp.WithBodyCodecs(csvCodec{}))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	content := spec.Paths.Find("/orders").Post.Responses.Status(201).Value.Content
+	assert.ElementsMatch(t, []string{"application/json", "text/csv"}, keys(content))
+}
+
+func TestWithProducesAndConsumes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/orders", &CreateOrderHandler{},
+		typedhttp.WithProduces("text/csv", "application/json"),
+		typedhttp.WithConsumes("application/x-www-form-urlencoded"),
+	)
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	operation := spec.Paths.Find("/orders").Post
+
+	response := operation.Responses.Status(201).Value.Content
+	assert.ElementsMatch(t, []string{"application/json", "text/csv"}, keys(response))
+	assert.Equal(t, response["application/json"].Schema, response["text/csv"].Schema)
+
+	// Declared request media types replace the inferred ones
+	request := operation.RequestBody.Value.Content
+	assert.ElementsMatch(t, []string{"application/x-www-form-urlencoded"}, keys(request))
+	assert.True(t, request["application/x-www-form-urlencoded"].Schema.Value.Type.Is("object"))
+}
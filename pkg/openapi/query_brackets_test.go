@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type filteredSearchRequest struct {
+	Filter map[string]string `query:"filter"`
+	Tags   []string          `query:"tags"`
+}
+
+type filteredSearchHandler struct{}
+
+func (h *filteredSearchHandler) Handle(_ context.Context, req filteredSearchRequest) (filteredSearchRequest, error) {
+	return req, nil
+}
+
+func TestGenerate_NestedQueryParameterStyle(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/search", &filteredSearchHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	params := spec.Paths.Find("/search").Get.Parameters
+	filter := params.GetByInAndName("query", "filter")
+	require.NotNil(t, filter)
+	assert.Equal(t, openapi3.SerializationDeepObject, filter.Style)
+	require.NotNil(t, filter.Explode)
+	assert.True(t, *filter.Explode)
+
+	tags := params.GetByInAndName("query", "tags")
+	assert.Equal(t, openapi3.SerializationForm, tags.Style)
+	require.NotNil(t, tags.Explode)
+	assert.True(t, *tags.Explode)
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type delimitedSearchRequest struct {
+	Tags   []string `query:"tags" style:"form,explode=false"`
+	IDs    []int    `query:"ids" style:"pipeDelimited"`
+	Fields []string `query:"fields" style:"spaceDelimited,explode=false"`
+}
+
+type delimitedSearchHandler struct{}
+
+func (h *delimitedSearchHandler) Handle(_ context.Context, _ delimitedSearchRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_QueryArrayStyle(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/search", &delimitedSearchHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	params := spec.Paths.Find("/search").Get.Parameters
+	for name, style := range map[string]string{
+		"tags":   openapi3.SerializationForm,
+		"ids":    openapi3.SerializationPipeDelimited,
+		"fields": openapi3.SerializationSpaceDelimited,
+	} {
+		param := params.GetByInAndName("query", name)
+		require.NotNil(t, param, name)
+		assert.Equal(t, style, param.Style, name)
+		require.NotNil(t, param.Explode, name)
+		assert.False(t, *param.Explode, name)
+	}
+}
+
+type invalidStyleRequest struct {
+	Tags []string `query:"tags" style:"matrix"`
+}
+
+type invalidStyleHandler struct{}
+
+func (h *invalidStyleHandler) Handle(_ context.Context, _ invalidStyleRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_QueryArrayStyleInvalid(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/search", &invalidStyleHandler{})
+
+	_, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+
+	assert.ErrorIs(t, err, typedhttp.ErrInvalidQueryStyle)
+}
@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CachedReportHandler struct{}
+
+func (h *CachedReportHandler) Handle(_ context.Context, _ DownloadRequest) (typedhttp.Raw, error) {
+	return typedhttp.Raw{ContentType: "application/json", Body: []byte(`{}`)}, nil
+}
+
+func TestGenerate_RawResponseDocumentedGenerically(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/reports/{id}", &CachedReportHandler{})
+	typedhttp.GET(router, "/reports/{id}/cached", &CachedReportHandler{},
+		typedhttp.WithProduces("application/json", "text/csv"))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Reports", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	content := spec.Paths.Find("/reports/{id}").Get.Responses.Status(200).Value.Content
+	require.Len(t, content, 1)
+	require.Contains(t, content, "*/*")
+	assert.Nil(t, content["*/*"].Schema.Value.Type)
+	assert.Empty(t, content["*/*"].Schema.Value.Properties)
+
+	content = spec.Paths.Find("/reports/{id}/cached").Get.Responses.Status(200).Value.Content
+	assert.ElementsMatch(t, []string{"application/json", "text/csv"}, keys(content))
+	assert.Nil(t, content["application/json"].Schema.Value.Type)
+}
@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ResolveLinkRequest struct {
+	Code string `path:"code"`
+}
+
+type ResolveLinkHandler struct{}
+
+func (h *ResolveLinkHandler) Handle(_ context.Context, _ ResolveLinkRequest) (typedhttp.Redirect, error) {
+	return typedhttp.Redirect{URL: "https://example.com"}, nil
+}
+
+func TestGenerate_DocumentsRedirects(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/l/{code}", &ResolveLinkHandler{})
+	typedhttp.GET(router, "/p/{code}", &ResolveLinkHandler{},
+		typedhttp.WithSuccessStatuses(http.StatusMovedPermanently, http.StatusPermanentRedirect))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Links API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	temporary := spec.Paths.Find("/l/{code}").Get.Responses
+	assert.Nil(t, temporary.Value("200"))
+	require.NotNil(t, temporary.Value("302"))
+	assert.Empty(t, temporary.Value("302").Value.Content)
+	require.Contains(t, temporary.Value("302").Value.Headers, "Location")
+	assert.True(t, temporary.Value("302").Value.Headers["Location"].Value.Required)
+
+	permanent := spec.Paths.Find("/p/{code}").Get.Responses
+	assert.Nil(t, permanent.Value("302"))
+	assert.NotNil(t, permanent.Value("301"))
+	assert.NotNil(t, permanent.Value("308"))
+}
@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// RequestValidationConfig holds configuration for RequestValidator.
+type RequestValidationConfig struct {
+	// Strict rejects object properties the schema does not declare, in request and
+	// response bodies alike. Schemas that set additionalProperties themselves, such as
+	// maps, keep their own setting.
+	Strict bool
+}
+
+// RequestValidationOption configures a RequestValidator.
+type RequestValidationOption func(*RequestValidationConfig)
+
+// WithStrictBodies rejects bodies carrying fields the schema does not declare.
+func WithStrictBodies() RequestValidationOption {
+	return func(c *RequestValidationConfig) {
+		c.Strict = true
+	}
+}
+
+// RequestValidator checks request bodies against the request body schema of the
+// matched operation in a generated document. It catches structural problems, such
+// as wrong types or unexpected fields, before the typed decoder sees the body.
+// ValidateResponse applies the same checks to the operation's responses.
+type RequestValidator struct {
+	config RequestValidationConfig
+	router routers.Router
+}
+
+// NewRequestValidator creates a validator for the document. The document is copied,
+// so later changes to spec do not affect the validator.
+func NewRequestValidator(spec *openapi3.T, opts ...RequestValidationOption) (*RequestValidator, error) {
+	config := RequestValidationConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	doc, err := cloneSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Servers would make the router match on host and base path; operations are
+	// matched by path alone, as the typed router does
+	doc.Servers = nil
+
+	if config.Strict {
+		closeSchemas(doc)
+	}
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("building request router: %w", err)
+	}
+
+	return &RequestValidator{
+		config: config,
+		router: router,
+	}, nil
+}
+
+// GetConfig returns the request validation configuration
+func (v *RequestValidator) GetConfig() RequestValidationConfig {
+	return v.config
+}
+
+// ValidateRequest checks the request body against the matched operation's schema.
+// Requests that match no operation, or operations without a body, pass.
+// The body is restored so handlers can read it again.
+func (v *RequestValidator) ValidateRequest(ctx context.Context, r *http.Request) error {
+	route, pathParams, err := v.router.FindRoute(r)
+	if err != nil || route.Operation.RequestBody == nil || route.Operation.RequestBody.Value == nil {
+		return nil
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+
+	return openapi3filter.ValidateRequestBody(ctx, input, route.Operation.RequestBody.Value)
+}
+
+// ValidateResponse checks a response to r against the matched operation's response
+// schema for status. Responses to requests that match no operation pass, as do
+// statuses the operation does not document.
+func (v *RequestValidator) ValidateResponse(
+	ctx context.Context, r *http.Request, status int, header http.Header, body []byte,
+) error {
+	route, pathParams, err := v.router.FindRoute(r)
+	if err != nil {
+		return nil
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status:  status,
+		Header:  header,
+		Options: &openapi3filter.Options{},
+	}
+	input.SetBodyBytes(body)
+
+	return openapi3filter.ValidateResponse(ctx, input)
+}
+
+// HTTPMiddleware returns HTTP middleware that answers 400 with the schema violation
+// when a request body does not match its operation's schema.
+func (v *RequestValidator) HTTPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := v.ValidateRequest(r.Context(), r); err != nil {
+				writeSchemaViolation(w, err)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeSchemaViolation writes a 400 response describing where the body diverged from the schema.
+func writeSchemaViolation(w http.ResponseWriter, err error) {
+	response := typedhttp.ErrorResponse{
+		Error: "Request body does not match schema",
+		Code:  "SCHEMA_VIOLATION",
+	}
+
+	var schemaErr *openapi3.SchemaError
+	var requestErr *openapi3filter.RequestError
+	switch {
+	case errors.As(err, &schemaErr):
+		response.Details = map[string]interface{}{
+			"field":  "/" + strings.Join(schemaErr.JSONPointer(), "/"),
+			"reason": schemaErr.Reason,
+		}
+	case errors.As(err, &requestErr):
+		response.Details = map[string]interface{}{
+			"reason": requestErr.Error(),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// cloneSpec deep-copies a document by round-tripping it through JSON.
+func cloneSpec(spec *openapi3.T) (*openapi3.T, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("encoding spec: %w", err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading spec: %w", err)
+	}
+
+	return doc, nil
+}
+
+// closeSchemas disallows undeclared properties on every object schema reachable from
+// component schemas, request bodies and responses.
+func closeSchemas(doc *openapi3.T) {
+	visited := make(map[*openapi3.Schema]bool)
+
+	if doc.Components != nil {
+		for _, ref := range doc.Components.Schemas {
+			closeSchema(ref, visited)
+		}
+	}
+
+	for _, item := range doc.Paths.Map() {
+		for _, operation := range item.Operations() {
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				for _, media := range operation.RequestBody.Value.Content {
+					closeSchema(media.Schema, visited)
+				}
+			}
+			for _, response := range operation.Responses.Map() {
+				if response.Value == nil {
+					continue
+				}
+				for _, media := range response.Value.Content {
+					closeSchema(media.Schema, visited)
+				}
+			}
+		}
+	}
+}
+
+func closeSchema(ref *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	visited[schema] = true
+
+	// Objects without declared properties are free-form, e.g. interface{} values
+	if schema.Type.Is(openapi3.TypeObject) && len(schema.Properties) > 0 &&
+		schema.AdditionalProperties.Has == nil && schema.AdditionalProperties.Schema == nil {
+		closed := false
+		schema.AdditionalProperties.Has = &closed
+	}
+
+	for _, property := range schema.Properties {
+		closeSchema(property, visited)
+	}
+	closeSchema(schema.Items, visited)
+	closeSchema(schema.AdditionalProperties.Schema, visited)
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range group {
+			closeSchema(member, visited)
+		}
+	}
+}
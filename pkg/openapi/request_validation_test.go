@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createProjectRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Seats int    `json:"seats"`
+}
+
+type createProjectResponse struct {
+	Name string `json:"name"`
+}
+
+type createProjectHandler struct{}
+
+func (h *createProjectHandler) Handle(_ context.Context, req createProjectRequest) (createProjectResponse, error) {
+	return createProjectResponse{Name: req.Name}, nil
+}
+
+// newSchemaValidatedRouter returns a router whose requests pass through a RequestValidator
+// built from its own generated document.
+func newSchemaValidatedRouter(t *testing.T, opts ...RequestValidationOption) http.Handler {
+	t.Helper()
+
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/projects", &createProjectHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	validator, err := NewRequestValidator(spec, opts...)
+	require.NoError(t, err)
+
+	return validator.HTTPMiddleware()(router)
+}
+
+func postProject(handler http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestRequestValidator_StrictRejectsUnknownFields(t *testing.T) {
+	handler := newSchemaValidatedRouter(t, WithStrictBodies())
+
+	rr := postProject(handler, `{"name":"apollo","seats":3,"owner":"root"}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response struct {
+		Code    string            `json:"code"`
+		Details map[string]string `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "SCHEMA_VIOLATION", response.Code)
+	assert.Contains(t, response.Details["reason"], "owner")
+
+	rr = postProject(handler, `{"name":"apollo","seats":3}`)
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.JSONEq(t, `{"name":"apollo"}`, rr.Body.String())
+}
+
+func TestRequestValidator_LenientAllowsUnknownFields(t *testing.T) {
+	handler := newSchemaValidatedRouter(t)
+
+	rr := postProject(handler, `{"name":"apollo","seats":3,"owner":"root"}`)
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+}
+
+func TestRequestValidator_RejectsWrongTypes(t *testing.T) {
+	handler := newSchemaValidatedRouter(t)
+
+	rr := postProject(handler, `{"name":"apollo","seats":"three"}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response struct {
+		Code    string            `json:"code"`
+		Details map[string]string `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "/seats", response.Details["field"])
+}
+
+func TestRequestValidator_PassesUnknownRoutes(t *testing.T) {
+	handler := newSchemaValidatedRouter(t, WithStrictBodies())
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
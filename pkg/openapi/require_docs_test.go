@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequireDocs_ReportsUndocumentedRoutes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{},
+		typedhttp.WithSummary("Get user"),
+		typedhttp.WithDescription("Returns a single user"),
+	)
+	typedhttp.POST(router, "/users", &CreateUserHandler{},
+		typedhttp.WithDescription("Creates a user"),
+	)
+	typedhttp.PUT(router, "/users/{id}", &GetUserHandler{})
+
+	generator := NewGenerator(&Config{Info: Info{Title: "Strict API", Version: "1.0.0"}}, WithRequireDocs())
+
+	spec, err := generator.Generate(router)
+	require.Error(t, err)
+	assert.Nil(t, spec)
+	assert.ErrorIs(t, err, ErrMissingDocs)
+
+	var docsErr *UndocumentedRoutesError
+	require.True(t, errors.As(err, &docsErr))
+	assert.Equal(t, []UndocumentedRoute{
+		{Method: "POST", Path: "/users", Missing: []string{"summary"}},
+		{Method: "PUT", Path: "/users/{id}", Missing: []string{"summary", "description"}},
+	}, docsErr.Routes)
+	assert.Contains(t, err.Error(), "POST /users (missing summary)")
+}
+
+func TestWithRequireDocs_DocumentedRoutesPass(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{},
+		typedhttp.WithSummary("Get user"),
+		typedhttp.WithDescription("Returns a single user"),
+	)
+
+	generator := NewGenerator(&Config{Info: Info{Title: "Strict API", Version: "1.0.0"}}, WithRequireDocs())
+
+	spec, err := generator.Generate(router)
+	require.NoError(t, err)
+	assert.NotNil(t, spec.Paths.Find("/users/{id}"))
+}
+
+func TestGenerate_UndocumentedRoutesAllowedByDefault(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	_, err := NewGenerator(&Config{Info: Info{Title: "Lenient API", Version: "1.0.0"}}).Generate(router)
+
+	assert.NoError(t, err)
+}
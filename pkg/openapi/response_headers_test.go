@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type GetDocumentRequest struct {
+	ID string `path:"id"`
+}
+
+type DocumentResponse struct {
+	Title string `json:"title"`
+}
+
+type GetDocumentHandler struct{}
+
+func (h *GetDocumentHandler) Handle(_ context.Context, _ GetDocumentRequest) (DocumentResponse, error) {
+	return DocumentResponse{}, nil
+}
+
+func TestGenerate_ResponseHeaders(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/documents/{id}", &GetDocumentHandler{},
+		typedhttp.WithMiddlewareEntries(typedhttp.MiddlewareEntry{
+			Middleware: typedhttp.NewResponseEnvelopeMiddleware[DocumentResponse](),
+			Config:     typedhttp.MiddlewareConfig{Name: "envelope"},
+		}),
+		typedhttp.WithSuccessStatuses(http.StatusOK, http.StatusNotModified),
+		typedhttp.WithResponseHeader(http.StatusOK, "ETag", nil, "Version of the document"),
+		typedhttp.WithResponseHeader(0, "X-RateLimit-Remaining", openapi3.NewIntegerSchema(), "Requests left in the window"),
+	)
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Documents", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	responses := spec.Paths.Find("/documents/{id}").Get.Responses
+
+	ok := responses.Value("200").Value.Headers
+	require.Contains(t, ok, "X-Request-ID")
+	assert.True(t, ok["X-Request-ID"].Value.Schema.Value.Type.Is("string"))
+	require.Contains(t, ok, "ETag")
+	assert.True(t, ok["ETag"].Value.Schema.Value.Type.Is("string"))
+	assert.Equal(t, "Version of the document", ok["ETag"].Value.Description)
+	require.Contains(t, ok, "X-RateLimit-Remaining")
+	assert.True(t, ok["X-RateLimit-Remaining"].Value.Schema.Value.Type.Is("integer"))
+
+	notModified := responses.Value("304").Value.Headers
+	assert.Contains(t, notModified, "X-Request-ID")
+	assert.Contains(t, notModified, "X-RateLimit-Remaining")
+	assert.NotContains(t, notModified, "ETag")
+}
+
+func TestGenerate_ResponseHeadersWithoutRequestID(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/documents/{id}", &GetDocumentHandler{},
+		typedhttp.WithMiddlewareEntries(typedhttp.MiddlewareEntry{
+			Middleware: typedhttp.NewResponseEnvelopeMiddleware[DocumentResponse](typedhttp.WithRequestID(false)),
+		}),
+	)
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Documents", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	assert.Empty(t, spec.Paths.Find("/documents/{id}").Get.Responses.Value("200").Value.Headers)
+}
@@ -3,6 +3,7 @@ package openapi
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,6 +40,11 @@ func TestCreateSchemaFromType_ComplexTypes(t *testing.T) {
 			}{},
 			expectedType: "object",
 		},
+		{
+			name:         "time",
+			inputType:    time.Time{},
+			expectedType: "string",
+		},
 	}
 
 	for _, tt := range tests {
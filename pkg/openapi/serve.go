@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// DefaultSwaggerUIURL is the CDN location of the Swagger UI assets used by the docs page.
+const DefaultSwaggerUIURL = "https://unpkg.com/swagger-ui-dist@5"
+
+// ServeConfig holds configuration for the documentation routes added by ServeOpenAPI.
+type ServeConfig struct {
+	JSONPath string // Path of the JSON document, "/openapi.json" by default
+	YAMLPath string // Path of the YAML document, "/openapi.yaml" by default
+	DocsPath string // Path of the Swagger UI page, "/docs" by default
+	// DocsUI enables the Swagger UI page. It is enabled by default.
+	DocsUI bool
+	// SwaggerUIURL is the base URL the Swagger UI scripts and styles are loaded from.
+	SwaggerUIURL string
+	// SpecOptions configure the spec handlers, e.g. WithDevelopmentMode.
+	SpecOptions []SpecHandlerOption
+}
+
+// ServeOption configures ServeOpenAPI.
+type ServeOption func(*ServeConfig)
+
+// WithSpecPaths sets the paths of the JSON and YAML documents. An empty path disables that format.
+func WithSpecPaths(jsonPath, yamlPath string) ServeOption {
+	return func(c *ServeConfig) {
+		c.JSONPath = jsonPath
+		c.YAMLPath = yamlPath
+	}
+}
+
+// WithDocsPath sets the path of the Swagger UI page.
+func WithDocsPath(path string) ServeOption {
+	return func(c *ServeConfig) {
+		c.DocsPath = path
+	}
+}
+
+// WithoutDocsUI serves only the spec documents.
+func WithoutDocsUI() ServeOption {
+	return func(c *ServeConfig) {
+		c.DocsUI = false
+	}
+}
+
+// WithSwaggerUIURL loads the Swagger UI assets from url instead of the public CDN,
+// e.g. from a self-hosted copy of swagger-ui-dist.
+func WithSwaggerUIURL(url string) ServeOption {
+	return func(c *ServeConfig) {
+		c.SwaggerUIURL = url
+	}
+}
+
+// WithSpecHandlerOptions passes options to the JSON and YAML spec handlers.
+func WithSpecHandlerOptions(opts ...SpecHandlerOption) ServeOption {
+	return func(c *ServeConfig) {
+		c.SpecOptions = append(c.SpecOptions, opts...)
+	}
+}
+
+// SpecRoutes are the spec handlers registered by ServeOpenAPI. Both share one cached document.
+type SpecRoutes struct {
+	Cache *SpecCache
+	JSON  *SpecHandler // nil when the JSON path is disabled
+	YAML  *SpecHandler // nil when the YAML path is disabled
+}
+
+// Invalidate drops the cached document so it is regenerated on the next request.
+func (s *SpecRoutes) Invalidate() {
+	s.Cache.Invalidate()
+}
+
+// ServeOpenAPI registers GET routes on the router serving its OpenAPI document as JSON
+// and YAML, and a Swagger UI page that loads the JSON document.
+//
+// The document is generated lazily on the first request and then cached, so routes
+// registered after ServeOpenAPI are included. The documentation routes themselves are
+// not part of the document.
+func ServeOpenAPI(router *typedhttp.TypedRouter, config *Config, opts ...ServeOption) *SpecRoutes {
+	serveConfig := ServeConfig{
+		JSONPath:     "/openapi.json",
+		YAMLPath:     "/openapi.yaml",
+		DocsPath:     "/docs",
+		DocsUI:       true,
+		SwaggerUIURL: DefaultSwaggerUIURL,
+	}
+
+	for _, opt := range opts {
+		opt(&serveConfig)
+	}
+
+	routes := &SpecRoutes{Cache: NewSpecCache(router, config)}
+	if serveConfig.JSONPath != "" {
+		routes.JSON = NewCachedSpecHandler(routes.Cache, append(serveConfig.SpecOptions, WithSpecFormat(FormatJSON))...)
+		router.Handle(http.MethodGet, serveConfig.JSONPath, routes.JSON)
+	}
+	if serveConfig.YAMLPath != "" {
+		routes.YAML = NewCachedSpecHandler(routes.Cache, append(serveConfig.SpecOptions, WithSpecFormat(FormatYAML))...)
+		router.Handle(http.MethodGet, serveConfig.YAMLPath, routes.YAML)
+	}
+
+	specPath := serveConfig.JSONPath
+	if specPath == "" {
+		specPath = serveConfig.YAMLPath
+	}
+	if serveConfig.DocsUI && serveConfig.DocsPath != "" && specPath != "" {
+		router.Handle(http.MethodGet, serveConfig.DocsPath, newDocsHandler(config.Info.Title, specPath, serveConfig.SwaggerUIURL))
+	}
+
+	return routes
+}
+
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.AssetsURL}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      SwaggerUIBundle({ url: {{.SpecURL}}, dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`))
+
+// newDocsHandler renders the Swagger UI page once and serves it for every request.
+func newDocsHandler(title, specURL, assetsURL string) http.Handler {
+	if title == "" {
+		title = "API Documentation"
+	}
+
+	var page bytes.Buffer
+	err := docsTemplate.Execute(&page, struct {
+		Title     string
+		SpecURL   string
+		AssetsURL string
+	}{Title: title, SpecURL: specURL, AssetsURL: assetsURL})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page.Bytes())
+	})
+}
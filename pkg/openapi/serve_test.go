@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(router http.Handler, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	return w
+}
+
+func TestServeOpenAPI_DefaultRoutes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	ServeOpenAPI(router, &Config{Info: Info{Title: "Users API", Version: "1.0.0"}})
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	w := get(router, "/openapi.json")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var doc struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Contains(t, doc.Paths, "/users/{id}", "routes registered later are included")
+	assert.NotContains(t, doc.Paths, "/openapi.json", "documentation routes are not documented")
+
+	w = get(router, "/openapi.yaml")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "/users/{id}")
+
+	w = get(router, "/docs")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<title>Users API</title>")
+	assert.Contains(t, w.Body.String(), DefaultSwaggerUIURL+"/swagger-ui-bundle.js")
+	assert.Contains(t, w.Body.String(), `url: "/openapi.json"`)
+}
+
+func TestServeOpenAPI_CustomPaths(t *testing.T) {
+	router := typedhttp.NewRouter()
+	routes := ServeOpenAPI(router, &Config{Info: Info{Title: "API", Version: "1.0.0"}},
+		WithSpecPaths("/spec.json", ""),
+		WithDocsPath("/reference"),
+		WithSwaggerUIURL("/assets/swagger"),
+	)
+
+	assert.Nil(t, routes.YAML)
+	assert.Equal(t, http.StatusOK, get(router, "/spec.json").Code)
+	assert.Equal(t, http.StatusNotFound, get(router, "/openapi.yaml").Code)
+	assert.Equal(t, http.StatusNotFound, get(router, "/docs").Code)
+
+	w := get(router, "/reference")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/assets/swagger/swagger-ui.css")
+	assert.Contains(t, w.Body.String(), `url: "/spec.json"`)
+}
+
+func TestServeOpenAPI_WithoutDocsUI(t *testing.T) {
+	router := typedhttp.NewRouter()
+	ServeOpenAPI(router, &Config{Info: Info{Title: "API", Version: "1.0.0"}}, WithoutDocsUI())
+
+	assert.Equal(t, http.StatusOK, get(router, "/openapi.json").Code)
+	assert.Equal(t, http.StatusNotFound, get(router, "/docs").Code)
+}
+
+func TestServeOpenAPI_Invalidate(t *testing.T) {
+	router := typedhttp.NewRouter()
+	routes := ServeOpenAPI(router, &Config{Info: Info{Title: "API", Version: "1.0.0"}})
+
+	assert.NotContains(t, get(router, "/openapi.json").Body.String(), "/users/{id}")
+
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+	assert.NotContains(t, get(router, "/openapi.json").Body.String(), "/users/{id}", "the document is cached")
+
+	routes.Invalidate()
+	assert.Contains(t, get(router, "/openapi.json").Body.String(), "/users/{id}")
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// SpecCache generates the OpenAPI document of a router once and reuses it, along
+// with its JSON and YAML renderings, until Invalidate is called.
+//
+// Routers are normally static after startup, so the usual pattern is to register all
+// routes, then serve the cached document for the lifetime of the process. Call
+// Invalidate after registering routes at runtime.
+type SpecCache struct {
+	router    *typedhttp.TypedRouter
+	generator *Generator
+
+	mu   sync.Mutex
+	spec *openapi3.T
+	json []byte
+	yaml []byte
+}
+
+// NewSpecCache creates a cache for the router's document. Nothing is generated until
+// the document is first requested.
+func NewSpecCache(router *typedhttp.TypedRouter, config *Config, opts ...GeneratorOption) *SpecCache {
+	return &SpecCache{
+		router:    router,
+		generator: NewGenerator(config, opts...),
+	}
+}
+
+// Spec returns the generated document. Callers must not modify it.
+func (c *SpecCache) Spec() (*openapi3.T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.specLocked()
+}
+
+// JSON returns the document serialized as JSON.
+func (c *SpecCache) JSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.json != nil {
+		return c.json, nil
+	}
+
+	spec, err := c.specLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.generator.GenerateJSON(spec)
+	if err != nil {
+		return nil, err
+	}
+	c.json = data
+
+	return data, nil
+}
+
+// YAML returns the document serialized as YAML.
+func (c *SpecCache) YAML() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.yaml != nil {
+		return c.yaml, nil
+	}
+
+	spec, err := c.specLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.generator.GenerateYAML(spec)
+	if err != nil {
+		return nil, err
+	}
+	c.yaml = data
+
+	return data, nil
+}
+
+// Invalidate drops the document and its renderings so the next request regenerates them.
+func (c *SpecCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.spec, c.json, c.yaml = nil, nil, nil
+}
+
+// specLocked returns the cached document, generating it if needed. c.mu must be held.
+func (c *SpecCache) specLocked() (*openapi3.T, error) {
+	if c.spec != nil {
+		return c.spec, nil
+	}
+
+	spec, err := c.generator.Generate(c.router)
+	if err != nil {
+		return nil, err
+	}
+	c.spec = spec
+
+	return spec, nil
+}
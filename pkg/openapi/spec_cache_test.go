@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheTestRouter() *typedhttp.TypedRouter {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+
+	return router
+}
+
+func TestSpecCache_GeneratesOnce(t *testing.T) {
+	router := newCacheTestRouter()
+	cache := NewSpecCache(router, &Config{Info: Info{Title: "API", Version: "1.0.0"}})
+
+	first, err := cache.Spec()
+	require.NoError(t, err)
+	second, err := cache.Spec()
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	jsonData, err := cache.JSON()
+	require.NoError(t, err)
+	yamlData, err := cache.YAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonData), "/users/{id}")
+	assert.Contains(t, string(yamlData), "/users/{id}")
+}
+
+func TestSpecCache_Invalidate(t *testing.T) {
+	router := newCacheTestRouter()
+	cache := NewSpecCache(router, &Config{Info: Info{Title: "API", Version: "1.0.0"}})
+
+	first, err := cache.Spec()
+	require.NoError(t, err)
+
+	typedhttp.PUT(router, "/users/{id}", &GetUserHandler{})
+	cache.Invalidate()
+
+	second, err := cache.Spec()
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.NotNil(t, second.Paths.Find("/users/{id}").Put)
+
+	jsonData, err := cache.JSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonData), `"put"`)
+}
+
+func TestNewCachedSpecHandler_SharesDocument(t *testing.T) {
+	router := newCacheTestRouter()
+	cache := NewSpecCache(router, &Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	jsonHandler := NewCachedSpecHandler(cache)
+	yamlHandler := NewCachedSpecHandler(cache, WithSpecFormat(FormatYAML))
+
+	w := httptest.NewRecorder()
+	jsonHandler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	spec, err := cache.Spec()
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	yamlHandler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.yaml", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	again, err := cache.Spec()
+	require.NoError(t, err)
+	assert.Same(t, spec, again, "the YAML handler reuses the document generated for JSON")
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	router := newCacheTestRouter()
+	generator := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		spec, err := generator.Generate(router)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := generator.GenerateJSON(spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSpecHandler_Cached(b *testing.B) {
+	handler := NewSpecHandler(newCacheTestRouter(), &Config{Info: Info{Title: "API", Version: "1.0.0"}})
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			b.Fatalf("unexpected status %d", w.Code)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type DownloadRequest struct {
+	ID string `path:"id" validate:"required"`
+}
+
+type DownloadHandler struct{}
+
+func (h *DownloadHandler) Handle(_ context.Context, _ DownloadRequest) (typedhttp.StreamingResponse, error) {
+	return typedhttp.StreamingResponse{Stream: strings.NewReader("data")}, nil
+}
+
+type RawDownloadHandler struct{}
+
+func (h *RawDownloadHandler) Handle(_ context.Context, _ DownloadRequest) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("data")), nil
+}
+
+func TestGenerate_StreamingResponseDocumentedAsBinary(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/reports/{id}/download", &DownloadHandler{})
+	typedhttp.GET(router, "/reports/{id}/raw", &RawDownloadHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Reports", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	for _, path := range []string{"/reports/{id}/download", "/reports/{id}/raw"} {
+		response := spec.Paths.Find(path).Get.Responses.Status(200)
+		require.NotNil(t, response, path)
+
+		assert.NotContains(t, response.Value.Content, "application/json")
+		mediaType := response.Value.Content["application/octet-stream"]
+		require.NotNil(t, mediaType, path)
+		assert.Equal(t, "string", (*mediaType.Schema.Value.Type)[0])
+		assert.Equal(t, "binary", mediaType.Schema.Value.Format)
+	}
+}
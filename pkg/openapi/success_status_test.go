@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UpsertOrderRequest struct {
+	ID   string `path:"id"`
+	Item string `json:"item"`
+}
+
+type UpsertOrderHandler struct{}
+
+func (h *UpsertOrderHandler) Handle(_ context.Context, req UpsertOrderRequest) (OrderResponse, error) {
+	return OrderResponse{ID: req.ID}, nil
+}
+
+func TestGenerate_DocumentsSuccessStatuses(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.PUT(router, "/orders/{id}", &UpsertOrderHandler{},
+		typedhttp.WithSuccessStatuses(http.StatusOK, http.StatusCreated))
+	typedhttp.POST(router, "/orders", &CreateOrderHandler{}, typedhttp.WithResponseStatus(http.StatusAccepted))
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Orders API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	upsert := spec.Paths.Find("/orders/{id}").Put
+	require.NotNil(t, upsert.Responses.Value("200"))
+	require.NotNil(t, upsert.Responses.Value("201"))
+	assert.Equal(t, "Created", *upsert.Responses.Value("201").Value.Description)
+	assert.Equal(t, upsert.Responses.Value("200").Value.Content, upsert.Responses.Value("201").Value.Content)
+
+	create := spec.Paths.Find("/orders").Post
+	assert.NotNil(t, create.Responses.Value("202"))
+	assert.Nil(t, create.Responses.Value("201"))
+}
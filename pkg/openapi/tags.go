@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Tag describes an operation tag in the top-level tags section of the document.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Order sets the position of the tag in the document, and so in Swagger UI.
+	// Lower values come first; tags with equal Order keep their configured order.
+	Order int `json:"order,omitempty"`
+}
+
+// buildTags returns the tags section: configured tags sorted by Order, followed by
+// tags that operations use without configuring them, in alphabetical order.
+func buildTags(configured []Tag, used []string) openapi3.Tags {
+	sorted := make([]Tag, len(configured))
+	copy(sorted, configured)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+
+	seen := make(map[string]bool, len(sorted))
+	tags := make(openapi3.Tags, 0, len(sorted))
+	for _, tag := range sorted {
+		if seen[tag.Name] {
+			continue
+		}
+		seen[tag.Name] = true
+		tags = append(tags, &openapi3.Tag{Name: tag.Name, Description: tag.Description})
+	}
+
+	var undeclared []string
+	for _, name := range used {
+		if !seen[name] {
+			seen[name] = true
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		tags = append(tags, &openapi3.Tag{Name: name})
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return tags
+}
@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags_SectionAndOperations(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{}, typedhttp.WithTags("users"))
+	typedhttp.POST(router, "/users", &CreateUserHandler{}, typedhttp.WithTags("users", "admin"))
+	typedhttp.PUT(router, "/users/{id}", &GetUserHandler{}, typedhttp.WithTags("legacy", "beta"))
+
+	config := &Config{
+		Info: Info{Title: "API", Version: "1.0.0"},
+		Tags: []Tag{
+			{Name: "admin", Description: "Administrative operations", Order: 2},
+			{Name: "users", Description: "User management", Order: 1},
+			{Name: "health", Description: "Service health", Order: 2},
+		},
+	}
+
+	spec, err := NewGenerator(config).Generate(router)
+	require.NoError(t, err)
+
+	var names, descriptions []string
+	for _, tag := range spec.Tags {
+		names = append(names, tag.Name)
+		descriptions = append(descriptions, tag.Description)
+	}
+	assert.Equal(t, []string{"users", "admin", "health", "beta", "legacy"}, names,
+		"configured tags by order, then undeclared tags alphabetically")
+	assert.Equal(t, []string{"User management", "Administrative operations", "Service health", "", ""}, descriptions)
+
+	assert.Equal(t, []string{"users"}, spec.Paths.Find("/users/{id}").Get.Tags)
+	assert.Equal(t, []string{"users", "admin"}, spec.Paths.Find("/users").Post.Tags)
+	assert.Equal(t, []string{"legacy", "beta"}, spec.Paths.Find("/users/{id}").Put.Tags)
+}
+
+func TestTags_NoneConfigured(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	assert.Nil(t, spec.Tags)
+	assert.Empty(t, spec.Paths.Find("/users/{id}").Get.Tags)
+}
@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithUnavailableRoutes_MarksDisabledRoutes(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+	require.NoError(t, router.SetRouteEnabled(http.MethodGet, "/users/{id}", false))
+
+	config := &Config{Info: Info{Title: "API", Version: "1.0.0"}}
+
+	spec, err := NewGenerator(config, WithUnavailableRoutes()).Generate(router)
+	require.NoError(t, err)
+
+	disabled := spec.Paths.Find("/users/{id}").Get
+	require.NotNil(t, disabled, "disabled routes stay documented")
+	assert.Equal(t, true, disabled.Extensions["x-unavailable"])
+	assert.NotNil(t, disabled.Responses.Value("503"))
+
+	enabled := spec.Paths.Find("/users").Post
+	assert.NotContains(t, enabled.Extensions, "x-unavailable")
+
+	spec, err = NewGenerator(config).Generate(router)
+	require.NoError(t, err)
+	assert.NotContains(t, spec.Paths.Find("/users/{id}").Get.Extensions, "x-unavailable")
+}
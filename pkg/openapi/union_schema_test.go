@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PaymentMethod interface {
+	paymentMethod()
+}
+
+type CardPayment struct {
+	Number string `json:"number" validate:"required"`
+	Expiry string `json:"expiry"`
+}
+
+func (CardPayment) paymentMethod() {}
+
+type BankTransfer struct {
+	IBAN string `json:"iban" validate:"required"`
+}
+
+func (BankTransfer) paymentMethod() {}
+
+func init() {
+	typedhttp.RegisterUnion[PaymentMethod]("type", map[string]PaymentMethod{
+		"card": CardPayment{},
+		"bank": BankTransfer{},
+	})
+}
+
+type CheckoutRequest struct {
+	Amount int                            `json:"amount"`
+	Method typedhttp.Union[PaymentMethod] `json:"method"`
+}
+
+type CheckoutHandler struct{}
+
+func (h *CheckoutHandler) Handle(_ context.Context, _ CheckoutRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func TestGenerate_UnionSchema(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/checkout", &CheckoutHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "Payments", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	body := spec.Paths.Find("/checkout").Post.RequestBody.Value.Content["application/json"].Schema.Value
+	method := body.Properties["method"].Value
+
+	require.NotNil(t, method.Discriminator)
+	assert.Equal(t, "type", method.Discriminator.PropertyName)
+	require.Len(t, method.OneOf, 2)
+
+	for i, variant := range []struct {
+		name     string
+		property string
+		required []string
+	}{
+		{name: "bank", property: "iban", required: []string{"type", "iban"}},
+		{name: "card", property: "number", required: []string{"type", "number", "expiry"}},
+	} {
+		schema := method.OneOf[i].Value
+		assert.Equal(t, []interface{}{variant.name}, schema.Properties["type"].Value.Enum)
+		assert.Equal(t, &openapi3.Types{"string"}, schema.Properties["type"].Value.Type)
+		assert.Contains(t, schema.Properties, variant.property)
+		assert.Equal(t, variant.required, schema.Required)
+	}
+}
+
+func TestGenerate_UnregisteredUnion(t *testing.T) {
+	type unregistered interface{ unregistered() }
+	type request struct {
+		Value typedhttp.Union[unregistered] `json:"value"`
+	}
+
+	_, err := NewGenerator(&Config{}).createSchemaFromType(reflect.TypeOf(request{}))
+	assert.ErrorContains(t, err, "no registered variants")
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// WebhooksExtension is the document extension webhooks are emitted under. OpenAPI 3.0
+// has no webhooks section; x-webhooks is understood by Redoc and similar tools. 3.1
+// documents use the standard webhooks section instead.
+const WebhooksExtension = "x-webhooks"
+
+// webhook is a request the API sends to a client-registered URL.
+type webhook struct {
+	name        string
+	method      string
+	payloadType reflect.Type
+}
+
+// AddWebhook documents a webhook the API sends, with a JSON body of payloadType.
+// Webhooks with the same name and different methods are grouped together.
+func (g *Generator) AddWebhook(name, method string, payloadType reflect.Type) {
+	g.webhooks = append(g.webhooks, webhook{name: name, method: strings.ToUpper(method), payloadType: payloadType})
+}
+
+// addWebhooks emits the registered webhooks into the document.
+func (g *Generator) addWebhooks(spec *openapi3.T) error {
+	if len(g.webhooks) == 0 {
+		return nil
+	}
+
+	webhooks := make(map[string]*openapi3.PathItem, len(g.webhooks))
+	for _, hook := range g.webhooks {
+		schema, err := g.createSchemaFromType(hook.payloadType)
+		if err != nil {
+			return fmt.Errorf("failed to create schema for webhook %s: %w", hook.name, err)
+		}
+
+		description := "Webhook received"
+		operation := &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Required: true,
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{Schema: schema},
+					},
+				},
+			},
+			Responses: openapi3.NewResponses(openapi3.WithStatus(http.StatusOK, &openapi3.ResponseRef{
+				Value: &openapi3.Response{Description: &description},
+			})),
+		}
+
+		pathItem, ok := webhooks[hook.name]
+		if !ok {
+			pathItem = &openapi3.PathItem{}
+			webhooks[hook.name] = pathItem
+		}
+		pathItem.SetOperation(hook.method, operation)
+	}
+
+	if spec.Extensions == nil {
+		spec.Extensions = make(map[string]interface{})
+	}
+	spec.Extensions[WebhooksExtension] = webhooks
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type OrderShippedEvent struct {
+	OrderID   string `json:"order_id" validate:"required"`
+	Carrier   string `json:"carrier"`
+	ItemCount int    `json:"item_count" validate:"min=1"`
+}
+
+func TestAddWebhook(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	generator := NewGenerator(&Config{Info: Info{Title: "Orders API", Version: "1.0.0"}})
+	generator.AddWebhook("orderShipped", "post", reflect.TypeOf(OrderShippedEvent{}))
+
+	spec, err := generator.Generate(router)
+	require.NoError(t, err)
+
+	webhooks, ok := spec.Extensions[WebhooksExtension].(map[string]*openapi3.PathItem)
+	require.True(t, ok)
+	require.Contains(t, webhooks, "orderShipped")
+
+	operation := webhooks["orderShipped"].Post
+	require.NotNil(t, operation)
+	body := operation.RequestBody.Value
+	assert.True(t, body.Required)
+	schema := body.Content["application/json"].Schema.Value
+	assert.True(t, schema.Type.Is("object"))
+	assert.ElementsMatch(t, []string{"order_id", "carrier", "item_count"}, keys(schema.Properties))
+	require.NotNil(t, schema.Properties["item_count"].Value.Min)
+	assert.Equal(t, float64(1), *schema.Properties["item_count"].Value.Min)
+	assert.NotNil(t, operation.Responses.Value("200"))
+
+	data, err := generator.GenerateJSON(spec)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Contains(t, doc, WebhooksExtension)
+}
+
+func TestAddWebhook_NoneRegistered(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	assert.NotContains(t, spec.Extensions, WebhooksExtension)
+}
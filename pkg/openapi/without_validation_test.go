@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unvalidatedInviteRequest struct {
+	Name  string `json:"name" validate:"required,min=2,max=50"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type unvalidatedInviteHandler struct{}
+
+func (h *unvalidatedInviteHandler) Handle(_ context.Context, req unvalidatedInviteRequest) (unvalidatedInviteRequest, error) {
+	return req, nil
+}
+
+func TestGenerate_DocumentsConstraintsWithoutValidation(t *testing.T) {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/invites", &unvalidatedInviteHandler{}, typedhttp.WithoutValidation())
+
+	spec, err := NewGenerator(&Config{Info: Info{Title: "API", Version: "1.0.0"}}).Generate(router)
+	require.NoError(t, err)
+
+	schema := spec.Paths.Find("/invites").Post.RequestBody.Value.Content["application/json"].Schema.Value
+	assert.ElementsMatch(t, []string{"name", "email"}, schema.Required)
+	assert.Equal(t, "email", schema.Properties["email"].Value.Format)
+	assert.Equal(t, uint64(2), schema.Properties["name"].Value.MinLength)
+}
@@ -2,15 +2,12 @@
 package client
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"strings"
 	"time"
 
@@ -119,129 +116,7 @@ func ExecuteTyped[T any](c *Client, ctx context.Context, req testutil.Request) (
 //
 //nolint:gocritic // Request struct size is acceptable for this usage
 func (c *Client) buildHTTPRequest(ctx context.Context, req testutil.Request) (*http.Request, error) {
-	path := c.buildRequestPath(req)
-	body, contentType, err := c.buildRequestBody(req)
-	if err != nil {
-		return nil, err
-	}
-
-	fullURL := c.baseURL + path
-	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, body)
-	if err != nil {
-		return nil, fmt.Errorf("creating HTTP request: %w", err)
-	}
-
-	c.setRequestHeaders(httpReq, req.Headers, contentType)
-	c.setRequestCookies(httpReq, req.Cookies)
-
-	return httpReq, nil
-}
-
-// buildRequestPath constructs the full path with parameters and query string.
-//
-//nolint:gocritic // Request struct size is acceptable for this usage
-func (c *Client) buildRequestPath(req testutil.Request) string {
-	// Replace path parameters
-	path := req.Path
-	for key, value := range req.PathParams {
-		placeholder := "{" + key + "}"
-		path = strings.ReplaceAll(path, placeholder, value)
-	}
-
-	// Add query parameters
-	if len(req.QueryParams) > 0 {
-		values := url.Values{}
-		for key, value := range req.QueryParams {
-			values.Set(key, value)
-		}
-		path += "?" + values.Encode()
-	}
-
-	return path
-}
-
-// buildRequestBody constructs the request body based on content type.
-//
-//nolint:gocritic // Request struct size is acceptable for this usage
-func (c *Client) buildRequestBody(req testutil.Request) (io.Reader, string, error) {
-	if req.Body == nil && len(req.Files) == 0 {
-		return nil, "", nil
-	}
-
-	if len(req.Files) > 0 {
-		return c.buildMultipartBody(req)
-	}
-
-	return c.buildJSONBody(req)
-}
-
-// buildMultipartBody creates a multipart form data body.
-//
-//nolint:gocritic // Request struct size is acceptable for this usage
-func (c *Client) buildMultipartBody(req testutil.Request) (io.Reader, string, error) {
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
-
-	// Add form fields from body if it's a map
-	if bodyMap, ok := req.Body.(map[string]string); ok {
-		for key, value := range bodyMap {
-			if err := writer.WriteField(key, value); err != nil {
-				return nil, "", fmt.Errorf("writing form field %s: %w", key, err)
-			}
-		}
-	}
-
-	// Add files
-	for fieldName, content := range req.Files {
-		part, err := writer.CreateFormFile(fieldName, fieldName)
-		if err != nil {
-			return nil, "", fmt.Errorf("creating form file %s: %w", fieldName, err)
-		}
-		if _, err := part.Write(content); err != nil {
-			return nil, "", fmt.Errorf("writing file content for %s: %w", fieldName, err)
-		}
-	}
-
-	if err := writer.Close(); err != nil {
-		return nil, "", fmt.Errorf("closing multipart writer: %w", err)
-	}
-
-	return &buf, writer.FormDataContentType(), nil
-}
-
-// buildJSONBody creates a JSON request body.
-//
-//nolint:gocritic // Request struct size is acceptable for this usage
-func (c *Client) buildJSONBody(req testutil.Request) (io.Reader, string, error) {
-	jsonData, err := json.Marshal(req.Body)
-	if err != nil {
-		return nil, "", fmt.Errorf("marshaling request body to JSON: %w", err)
-	}
-
-	return bytes.NewReader(jsonData), "application/json", nil
-}
-
-// setRequestHeaders sets headers on the HTTP request.
-func (c *Client) setRequestHeaders(httpReq *http.Request, headers map[string]string, contentType string) {
-	// Set content type if we have a body
-	if contentType != "" {
-		httpReq.Header.Set("Content-Type", contentType)
-	}
-
-	// Add custom headers
-	for key, value := range headers {
-		httpReq.Header.Set(key, value)
-	}
-}
-
-// setRequestCookies sets cookies on the HTTP request.
-func (c *Client) setRequestCookies(httpReq *http.Request, cookies map[string]string) {
-	for name, value := range cookies {
-		httpReq.AddCookie(&http.Cookie{
-			Name:  name,
-			Value: value,
-		})
-	}
+	return testutil.NewHTTPRequest(ctx, c.baseURL, req)
 }
 
 // executeHTTPRequest executes the HTTP request using httptest.ResponseRecorder for testing.
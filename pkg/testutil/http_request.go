@@ -0,0 +1,144 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// NewHTTPRequest builds the *http.Request for req against baseURL, which may be empty
+// for requests served in-process. Path parameters are substituted into the path and
+// the body is encoded as JSON, or as multipart form data when files are attached.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func NewHTTPRequest(ctx context.Context, baseURL string, req Request) (*http.Request, error) {
+	path := buildRequestPath(req)
+	body, contentType, err := buildRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fullURL := baseURL + path
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating HTTP request: %w", err)
+	}
+
+	setRequestHeaders(httpReq, req.Headers, contentType)
+	setRequestCookies(httpReq, req.Cookies)
+
+	return httpReq, nil
+}
+
+// buildRequestPath constructs the full path with parameters and query string.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func buildRequestPath(req Request) string {
+	// Replace path parameters
+	path := req.Path
+	for key, value := range req.PathParams {
+		placeholder := "{" + key + "}"
+		path = strings.ReplaceAll(path, placeholder, value)
+	}
+
+	// Add query parameters
+	if len(req.QueryParams) > 0 {
+		values := url.Values{}
+		for key, value := range req.QueryParams {
+			values.Set(key, value)
+		}
+		path += "?" + values.Encode()
+	}
+
+	return path
+}
+
+// buildRequestBody constructs the request body based on content type.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func buildRequestBody(req Request) (io.Reader, string, error) {
+	if req.Body == nil && len(req.Files) == 0 {
+		return nil, "", nil
+	}
+
+	if len(req.Files) > 0 {
+		return buildMultipartBody(req)
+	}
+
+	return buildJSONBody(req)
+}
+
+// buildMultipartBody creates a multipart form data body.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func buildMultipartBody(req Request) (io.Reader, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	// Add form fields from body if it's a map
+	if bodyMap, ok := req.Body.(map[string]string); ok {
+		for key, value := range bodyMap {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, "", fmt.Errorf("writing form field %s: %w", key, err)
+			}
+		}
+	}
+
+	// Add files
+	for fieldName, content := range req.Files {
+		part, err := writer.CreateFormFile(fieldName, fieldName)
+		if err != nil {
+			return nil, "", fmt.Errorf("creating form file %s: %w", fieldName, err)
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, "", fmt.Errorf("writing file content for %s: %w", fieldName, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("closing multipart writer: %w", err)
+	}
+
+	return &buf, writer.FormDataContentType(), nil
+}
+
+// buildJSONBody creates a JSON request body.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func buildJSONBody(req Request) (io.Reader, string, error) {
+	jsonData, err := json.Marshal(req.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshaling request body to JSON: %w", err)
+	}
+
+	return bytes.NewReader(jsonData), "application/json", nil
+}
+
+// setRequestHeaders sets headers on the HTTP request.
+func setRequestHeaders(httpReq *http.Request, headers map[string]string, contentType string) {
+	// Set content type if we have a body
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	// Add custom headers
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+}
+
+// setRequestCookies sets cookies on the HTTP request.
+func setRequestCookies(httpReq *http.Request, cookies map[string]string) {
+	for name, value := range cookies {
+		httpReq.AddCookie(&http.Cookie{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// Errors returned by Invoke before the request reaches the router.
+var (
+	ErrRouteNotRegistered = errors.New("route not registered")
+	ErrTypeMismatch       = errors.New("request or response type does not match the route")
+)
+
+// InvokeError is the error response of an invoked handler, as produced by its error
+// mapper: the status code, headers and encoded body the client would receive.
+type InvokeError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *InvokeError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.Method, e.Path, e.StatusCode, bytes.TrimSpace(e.Body))
+}
+
+// DecodeBody unmarshals the JSON error body into v.
+func (e *InvokeError) DecodeBody(v interface{}) error {
+	return json.Unmarshal(e.Body, v)
+}
+
+// Invoke runs the route registered with method and path against req, in-process and
+// through the route's full pipeline: decoding, validation, middleware, the handler and
+// error mapping. The request is built from the tags of Req: path, query, header and
+// cookie fields go to their sources and the remaining fields form the JSON body.
+//
+// A successful call returns the decoded response. An error status is returned as an
+// *InvokeError carrying the mapped error response, so tests can assert on it:
+//
+//	_, err := testutil.Invoke[CreateUserRequest, UserResponse](router, "POST", "/users", req)
+//	var invokeErr *testutil.InvokeError
+//	if errors.As(err, &invokeErr) && invokeErr.StatusCode == http.StatusBadRequest { ... }
+//
+// Req and Resp must be the request and response types of the route.
+func Invoke[Req, Resp any](router *typedhttp.TypedRouter, method, path string, req Req) (Resp, error) {
+	var resp Resp
+
+	if err := checkRoute(router, method, path, reflect.TypeOf(req), reflect.TypeOf(resp)); err != nil {
+		return resp, &RequestError{Method: method, Path: path, Err: err}
+	}
+
+	httpReq, err := newInvokeRequest(method, path, req)
+	if err != nil {
+		return resp, &RequestError{Method: method, Path: path, Err: fmt.Errorf("building HTTP request: %w", err)}
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httpReq)
+
+	if recorder.Code >= ClientErrorThreshold {
+		return resp, &InvokeError{
+			Method:     method,
+			Path:       path,
+			StatusCode: recorder.Code,
+			Header:     recorder.Header(),
+			Body:       recorder.Body.Bytes(),
+		}
+	}
+
+	if recorder.Body.Len() > 0 {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			return resp, &RequestError{Method: method, Path: path, Err: fmt.Errorf("unmarshaling JSON response: %w", err)}
+		}
+	}
+
+	return resp, nil
+}
+
+// checkRoute verifies that the route is registered with the given types.
+func checkRoute(router *typedhttp.TypedRouter, method, path string, requestType, responseType reflect.Type) error {
+	for _, reg := range router.GetHandlers() {
+		if reg.Method != method || reg.Path != path {
+			continue
+		}
+		if reg.RequestType != requestType || reg.ResponseType != responseType {
+			return fmt.Errorf("%w: route takes %v and returns %v", ErrTypeMismatch, reg.RequestType, reg.ResponseType)
+		}
+
+		return nil
+	}
+
+	return ErrRouteNotRegistered
+}
+
+// newInvokeRequest builds the request for the route template path from the tags of req.
+func newInvokeRequest(method, path string, req interface{}) (*http.Request, error) {
+	value := reflect.ValueOf(req)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return http.NewRequestWithContext(context.Background(), method, path, http.NoBody)
+	}
+
+	body, err := invokeBody(value)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		values, err := fieldStrings(value.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if name := tagName(field, "path"); name != "" {
+			segment, err := formatValue(reflect.Indirect(value.Field(i)))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(segment))
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if name := tagName(field, "query"); name != "" {
+			query[name] = values
+		}
+		if name := tagName(field, "header"); name != "" {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+		if name := tagName(field, "cookie"); name != "" {
+			cookies = append(cookies, &http.Cookie{Name: name, Value: values[0]})
+		}
+	}
+
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(context.Background(), method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		httpReq.Header[name] = values
+	}
+	for _, cookie := range cookies {
+		httpReq.AddCookie(cookie)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	return httpReq, nil
+}
+
+// invokeBody encodes the fields of value that are not bound to another source as a
+// JSON object, or returns nil when there are none.
+func invokeBody(value reflect.Value) ([]byte, error) {
+	encoded, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request body to JSON: %w", err)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &object); err != nil {
+		return nil, fmt.Errorf("marshaling request body to JSON: %w", err)
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !boundElsewhere(field) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		delete(object, name)
+	}
+
+	if len(object) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(object)
+}
+
+// boundElsewhere reports whether field is bound to a request source other than the body.
+func boundElsewhere(field reflect.StructField) bool {
+	for _, source := range []string{"path", "query", "header", "cookie", "meta", "principal"} {
+		if tagName(field, source) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tagName returns the source name in the tag key of field, without options.
+func tagName(field reflect.StructField, key string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+
+	return name
+}
+
+// fieldStrings formats a field value for a query, header or cookie. Nil pointers
+// and zero values are omitted; slices produce one string per element.
+func fieldStrings(value reflect.Value) ([]string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+	if value.IsZero() {
+		return nil, nil
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		values := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			s, err := formatValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, s)
+		}
+
+		return values, nil
+	}
+
+	s, err := formatValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{s}, nil
+}
+
+// formatValue formats a single value, preferring its text encoding.
+func formatValue(value reflect.Value) (string, error) {
+	if !value.IsValid() {
+		return "", nil
+	}
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+
+		return string(text), err
+	}
+
+	return fmt.Sprint(value.Interface()), nil
+}
@@ -0,0 +1,116 @@
+package testutil_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/testutil"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+type SearchUsersRequest struct {
+	Team   string   `path:"team"`
+	Roles  []string `query:"role"`
+	Tenant string   `header:"X-Tenant" validate:"required"`
+	Limit  int      `query:"limit" default:"10"`
+}
+
+type SearchUsersResponse struct {
+	Team   string   `json:"team"`
+	Roles  []string `json:"roles"`
+	Tenant string   `json:"tenant"`
+	Limit  int      `json:"limit"`
+}
+
+type SearchUsersHandler struct{}
+
+func (h *SearchUsersHandler) Handle(_ context.Context, req SearchUsersRequest) (SearchUsersResponse, error) {
+	return SearchUsersResponse{Team: req.Team, Roles: req.Roles, Tenant: req.Tenant, Limit: req.Limit}, nil
+}
+
+func newInvokeRouter() *typedhttp.TypedRouter {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+	typedhttp.GET(router, "/teams/{team}/users", &SearchUsersHandler{})
+
+	return router
+}
+
+func TestInvoke(t *testing.T) {
+	router := newInvokeRouter()
+
+	t.Run("binds every source and returns the typed response", func(t *testing.T) {
+		resp, err := testutil.Invoke[SearchUsersRequest, SearchUsersResponse](router, http.MethodGet, "/teams/{team}/users",
+			SearchUsersRequest{Team: "core", Roles: []string{"admin", "owner"}, Tenant: "acme"})
+		if err != nil {
+			t.Fatalf("Invoke failed: %v", err)
+		}
+
+		if resp.Team != "core" || resp.Tenant != "acme" {
+			t.Errorf("Expected team core and tenant acme, got %+v", resp)
+		}
+		if len(resp.Roles) != 2 || resp.Roles[1] != "owner" {
+			t.Errorf("Expected roles [admin owner], got %v", resp.Roles)
+		}
+		if resp.Limit != 10 {
+			t.Errorf("Expected the default limit 10, got %d", resp.Limit)
+		}
+	})
+
+	t.Run("returns the mapped validation error", func(t *testing.T) {
+		_, err := testutil.Invoke[SearchUsersRequest, SearchUsersResponse](router, http.MethodGet, "/teams/{team}/users",
+			SearchUsersRequest{Team: "core"})
+
+		var invokeErr *testutil.InvokeError
+		if !errors.As(err, &invokeErr) {
+			t.Fatalf("Expected an InvokeError, got %v", err)
+		}
+		if invokeErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", invokeErr.StatusCode)
+		}
+
+		var body struct {
+			Details map[string]string `json:"details"`
+		}
+		if err := invokeErr.DecodeBody(&body); err != nil {
+			t.Fatalf("Decoding the error body failed: %v", err)
+		}
+		if _, ok := body.Details["tenant"]; !ok {
+			t.Errorf("Expected a tenant failure, got %v", body.Details)
+		}
+	})
+
+	t.Run("rejects unknown routes and mismatched types", func(t *testing.T) {
+		_, err := testutil.Invoke[CreateUserRequest, UserResponse](router, http.MethodPut, "/users", CreateUserRequest{})
+		if !errors.Is(err, testutil.ErrRouteNotRegistered) {
+			t.Errorf("Expected ErrRouteNotRegistered, got %v", err)
+		}
+
+		_, err = testutil.Invoke[GetUserRequest, UserResponse](router, http.MethodPost, "/users", GetUserRequest{})
+		if !errors.Is(err, testutil.ErrTypeMismatch) {
+			t.Errorf("Expected ErrTypeMismatch, got %v", err)
+		}
+	})
+}
+
+func ExampleInvoke() {
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+
+	user, err := testutil.Invoke[CreateUserRequest, UserResponse](router, http.MethodPost, "/users",
+		CreateUserRequest{Name: "Jane Doe", Email: "jane@example.com", Age: 25})
+	fmt.Println(user.ID, user.Name, err)
+
+	_, err = testutil.Invoke[CreateUserRequest, UserResponse](router, http.MethodPost, "/users",
+		CreateUserRequest{Name: "Jane Doe", Email: "not-an-email", Age: 25})
+	var invokeErr *testutil.InvokeError
+	if errors.As(err, &invokeErr) {
+		fmt.Println(invokeErr.StatusCode)
+	}
+	// Output:
+	// 123 Jane Doe <nil>
+	// 400
+}
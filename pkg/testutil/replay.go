@@ -0,0 +1,60 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/pavelpascari/typedhttp/pkg/middleware/logging"
+)
+
+// ErrIncompleteRecording is returned by Replay for recordings whose request body was
+// truncated or not recorded, as replaying them would send a different request.
+var ErrIncompleteRecording = errors.New("recorded request body is incomplete")
+
+// Replay sends the request of a recording made by logging.RecordingMiddleware to
+// router, in-process, and returns the new response in the recording format so it can
+// be compared with the recorded one:
+//
+//	replayed, err := testutil.Replay(router, recording)
+//	require.NoError(t, err)
+//	assert.Equal(t, recording.Response.Status, replayed.Status)
+//	assert.JSONEq(t, recording.Response.Body.Data, replayed.Body.Data)
+//
+// Redacted headers are not sent; set them on the recording first when the route needs
+// them. Values redacted in the recorded response are present in the replayed one.
+func Replay(router http.Handler, recording *logging.Recording) (*logging.RecordedResponse, error) {
+	recorded := recording.Request
+	if recorded.Body.Truncated || recorded.Body.Data == logging.Unredactable {
+		return nil, &RequestError{Method: recorded.Method, Path: recorded.URL, Err: ErrIncompleteRecording}
+	}
+
+	body, err := recorded.Body.Bytes()
+	if err != nil {
+		return nil, &RequestError{Method: recorded.Method, Path: recorded.URL, Err: fmt.Errorf("decoding recorded body: %w", err)}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), recorded.Method, recorded.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, &RequestError{Method: recorded.Method, Path: recorded.URL, Err: fmt.Errorf("building HTTP request: %w", err)}
+	}
+	for name, values := range recorded.Header {
+		for _, value := range values {
+			if value != logging.Redacted {
+				req.Header.Add(name, value)
+			}
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	return &logging.RecordedResponse{
+		Status: recorder.Code,
+		Header: recorder.Header(),
+		Body:   logging.NewRecordedBody(recorder.Body.Bytes()),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package testutil_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/middleware/logging"
+	"github.com/pavelpascari/typedhttp/pkg/testutil"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+func TestReplay(t *testing.T) {
+	var out bytes.Buffer
+	recorder := logging.NewRecordingMiddleware(logging.NewJSONLinesSink(&out))
+
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/teams/{team}/users", &SearchUsersHandler{},
+		typedhttp.WithMiddleware(recorder.HTTPMiddleware()))
+
+	req := httptest.NewRequest(http.MethodGet, "/teams/core/users?role=admin&limit=5", http.NoBody)
+	req.Header.Set("X-Tenant", "acme")
+	req.Header.Set("Authorization", "Bearer token")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var recording logging.Recording
+	if err := json.Unmarshal(out.Bytes(), &recording); err != nil {
+		t.Fatalf("Decoding the recording failed: %v", err)
+	}
+
+	replayed, err := testutil.Replay(router, &recording)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if replayed.Status != recording.Response.Status {
+		t.Errorf("Expected status %d, got %d", recording.Response.Status, replayed.Status)
+	}
+	if !jsonEqual(t, recording.Response.Body.Data, replayed.Body.Data) {
+		t.Errorf("Expected body %s, got %s", recording.Response.Body.Data, replayed.Body.Data)
+	}
+	if !strings.Contains(replayed.Body.Data, `"tenant":"acme"`) {
+		t.Errorf("Expected the recorded headers to be replayed, got %s", replayed.Body.Data)
+	}
+}
+
+func TestReplay_IncompleteRequest(t *testing.T) {
+	bodies := map[string]logging.RecordedBody{
+		"truncated":    {Data: `{"name":`, Truncated: true},
+		"unredactable": {Data: logging.Unredactable},
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			recording := &logging.Recording{
+				Request: logging.RecordedRequest{Method: http.MethodPost, URL: "/users", Body: body},
+			}
+
+			_, err := testutil.Replay(typedhttp.NewRouter(), recording)
+			if !errors.Is(err, testutil.ErrIncompleteRecording) {
+				t.Errorf("Expected ErrIncompleteRecording, got %v", err)
+			}
+		})
+	}
+}
+
+// jsonEqual reports whether two JSON documents are equal.
+func jsonEqual(t *testing.T, expected, actual string) bool {
+	t.Helper()
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("Invalid expected JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &got); err != nil {
+		t.Fatalf("Invalid actual JSON: %v", err)
+	}
+
+	return reflect.DeepEqual(want, got)
+}
@@ -0,0 +1,172 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// RouterClient executes requests end-to-end through a real TypedRouter, so routing,
+// decoding, validation, middleware and error mapping all run as in production.
+type RouterClient struct {
+	router  *typedhttp.TypedRouter
+	server  *httptest.Server
+	timeout time.Duration
+}
+
+// RouterClientOption configures a RouterClient using the functional options pattern.
+type RouterClientOption func(*RouterClient)
+
+// WithRouterTimeout sets the default timeout for requests without a context deadline.
+func WithRouterTimeout(timeout time.Duration) RouterClientOption {
+	return func(c *RouterClient) {
+		c.timeout = timeout
+	}
+}
+
+// WithTestServer serves the router from an httptest.Server and sends requests over
+// the loopback network instead of calling the router in-process. Call Close when done.
+func WithTestServer() RouterClientOption {
+	return func(c *RouterClient) {
+		c.server = httptest.NewServer(c.router)
+	}
+}
+
+// NewRouterClient creates a client that drives the given router.
+func NewRouterClient(router *typedhttp.TypedRouter, opts ...RouterClientOption) *RouterClient {
+	client := &RouterClient{
+		router:  router,
+		timeout: DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// URL returns the base URL of the test server, or "" when requests are served in-process.
+func (c *RouterClient) URL() string {
+	if c.server == nil {
+		return ""
+	}
+
+	return c.server.URL
+}
+
+// Close shuts down the test server, if any.
+func (c *RouterClient) Close() {
+	if c.server != nil {
+		c.server.Close()
+	}
+}
+
+// Execute sends the request through the router.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func (c *RouterClient) Execute(ctx context.Context, req Request) (*Response, error) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	httpReq, err := NewHTTPRequest(ctx, c.URL(), req)
+	if err != nil {
+		return nil, &RequestError{
+			Method: req.Method,
+			Path:   req.Path,
+			Err:    fmt.Errorf("building HTTP request: %w", err),
+		}
+	}
+
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return nil, &RequestError{
+			Method: req.Method,
+			Path:   req.Path,
+			Err:    fmt.Errorf("executing HTTP request: %w", err),
+		}
+	}
+
+	return resp, nil
+}
+
+// do serves the request in-process or sends it to the test server.
+func (c *RouterClient) do(req *http.Request) (*Response, error) {
+	if c.server == nil {
+		recorder := httptest.NewRecorder()
+		c.router.ServeHTTP(recorder, req)
+
+		return &Response{
+			StatusCode: recorder.Code,
+			Headers:    recorder.Header(),
+			Raw:        recorder.Body.Bytes(),
+		}, nil
+	}
+
+	httpResp, err := c.server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	return &Response{
+		StatusCode: httpResp.StatusCode,
+		Headers:    httpResp.Header,
+		Raw:        body,
+	}, nil
+}
+
+// ExecuteTyped executes a request with any HTTPClient and decodes a JSON response body into T.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func ExecuteTyped[T any](ctx context.Context, client HTTPClient, req Request) (*TypedResponse[T], error) {
+	resp, err := client.Execute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var data T
+	if len(resp.Raw) > 0 && strings.Contains(resp.Headers.Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(resp.Raw, &data); err != nil {
+			return nil, &RequestError{
+				Method: req.Method,
+				Path:   req.Path,
+				Err:    fmt.Errorf("unmarshaling JSON response: %w", err),
+			}
+		}
+	}
+
+	return &TypedResponse[T]{
+		Response: resp,
+		Data:     data,
+	}, nil
+}
+
+// MustExecuteTyped executes a typed request and fails the test on error.
+//
+//nolint:gocritic // Request struct size is acceptable for this usage
+func MustExecuteTyped[T any](t *testing.T, client HTTPClient, req Request) *TypedResponse[T] {
+	t.Helper()
+	resp, err := ExecuteTyped[T](context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("Request %s %s failed: %v", req.Method, req.Path, err)
+	}
+
+	return resp
+}
@@ -0,0 +1,81 @@
+package testutil_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/testutil"
+	"github.com/pavelpascari/typedhttp/pkg/testutil/assert"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+func newRouterClientRouter() *typedhttp.TypedRouter {
+	tagged := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Middleware", "ran")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	router := typedhttp.NewRouter()
+	typedhttp.POST(router, "/users", &CreateUserHandler{})
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{}, typedhttp.WithMiddleware(tagged))
+
+	return router
+}
+
+func TestRouterClient(t *testing.T) {
+	clients := map[string]*testutil.RouterClient{
+		"in-process":  testutil.NewRouterClient(newRouterClientRouter()),
+		"test server": testutil.NewRouterClient(newRouterClientRouter(), testutil.WithTestServer()),
+	}
+
+	for name, routerClient := range clients {
+		t.Run(name, func(t *testing.T) {
+			defer routerClient.Close()
+
+			t.Run("routes and decodes path parameters", func(t *testing.T) {
+				req := testutil.WithPathParam(testutil.GET("/users/{id}"), "id", "42")
+				resp := testutil.MustExecuteTyped[UserResponse](t, routerClient, req)
+
+				assert.StatusOK(t, resp.Response)
+				assert.Header(t, resp.Response, "X-Middleware", "ran")
+				if resp.Data.ID != "42" {
+					t.Errorf("Expected ID 42, got %s", resp.Data.ID)
+				}
+			})
+
+			t.Run("maps validation errors", func(t *testing.T) {
+				resp := testutil.MustExecute(t, routerClient, testutil.POST("/users", CreateUserRequest{
+					Name:  "Jane Doe",
+					Email: "not-an-email",
+					Age:   25,
+				}))
+
+				assert.StatusBadRequest(t, resp)
+				assert.JSONField(t, resp, "code", "VALIDATION_ERROR")
+				assert.JSONFieldExists(t, resp, "details.email")
+			})
+
+			t.Run("reports unknown routes", func(t *testing.T) {
+				resp := testutil.MustExecute(t, routerClient, testutil.GET("/missing"))
+				assert.StatusNotFound(t, resp)
+			})
+
+			t.Run("reports unsupported methods", func(t *testing.T) {
+				resp := testutil.MustExecute(t, routerClient, testutil.DELETE("/users"))
+				assert.Status(t, resp, http.StatusMethodNotAllowed)
+			})
+		})
+	}
+}
+
+func TestRouterClient_ExecuteTypedError(t *testing.T) {
+	routerClient := testutil.NewRouterClient(newRouterClientRouter())
+
+	_, err := testutil.ExecuteTyped[UserResponse](context.Background(), routerClient, testutil.POST("/users", make(chan int)))
+	if !testutil.IsRequestError(err) {
+		t.Fatalf("Expected a RequestError, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pavelpascari/typedhttp/pkg/openapi"
+)
+
+// ValidateResponseAgainstSpec returns middleware that checks every response against
+// the documented response schema of the matched operation and reports mismatches,
+// including undocumented or missing fields, as test errors. Responses are passed
+// through unchanged, so it can wrap a router for a whole integration test:
+//
+//	spec, _ := openapi.NewGenerator(config).Generate(router)
+//	handler := testutil.ValidateResponseAgainstSpec(t, spec)(router)
+//
+// It buffers every response and is meant for tests only.
+func ValidateResponseAgainstSpec(t testing.TB, spec *openapi3.T) func(http.Handler) http.Handler {
+	t.Helper()
+
+	validator, err := openapi.NewRequestValidator(spec, openapi.WithStrictBodies())
+	if err != nil {
+		t.Fatalf("Building response validator: %v", err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := httptest.NewRecorder()
+			next.ServeHTTP(recorder, r)
+
+			err := validator.ValidateResponse(r.Context(), r, recorder.Code, recorder.Header(), recorder.Body.Bytes())
+			if err != nil {
+				t.Errorf("Response to %s %s does not match spec: %v", r.Method, r.URL.Path, err)
+			}
+
+			for name, values := range recorder.Header() {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(recorder.Code)
+			_, _ = w.Write(recorder.Body.Bytes())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package testutil_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pavelpascari/typedhttp/pkg/openapi"
+	"github.com/pavelpascari/typedhttp/pkg/testutil"
+	"github.com/pavelpascari/typedhttp/pkg/typedhttp"
+)
+
+// recordingTB captures test errors instead of failing the surrounding test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// serveAgainstUserSpec serves GET /users/42 from handler, validated against the spec
+// of the typed user routes.
+func serveAgainstUserSpec(t *testing.T, handler http.Handler) *recordingTB {
+	t.Helper()
+
+	router := typedhttp.NewRouter()
+	typedhttp.GET(router, "/users/{id}", &GetUserHandler{})
+
+	spec, err := openapi.NewGenerator(&openapi.Config{
+		Info: openapi.Info{Title: "API", Version: "1.0.0"},
+	}).Generate(router)
+	if err != nil {
+		t.Fatalf("Generating spec: %v", err)
+	}
+
+	if handler == nil {
+		handler = router
+	}
+
+	recorder := &recordingTB{TB: t}
+	rr := httptest.NewRecorder()
+	testutil.ValidateResponseAgainstSpec(recorder, spec)(handler).ServeHTTP(
+		rr, httptest.NewRequest(http.MethodGet, "/users/42", nil),
+	)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected the response to pass through with 200, got %d", rr.Code)
+	}
+
+	return recorder
+}
+
+// driftedUserHandler writes a user body that no longer matches UserResponse.
+func driftedUserHandler(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestValidateResponseAgainstSpec(t *testing.T) {
+	t.Run("conforming responses pass", func(t *testing.T) {
+		recorder := serveAgainstUserSpec(t, nil)
+
+		if len(recorder.errors) != 0 {
+			t.Errorf("Expected no spec violations, got %v", recorder.errors)
+		}
+	})
+
+	drifted := map[string]string{
+		"extra field": `{"id":"42","name":"John","email":"john@example.com",` +
+			`"created_at":"2026-10-15T00:00:00Z","nickname":"jd"}`,
+		"missing field": `{"id":"42","name":"John","created_at":"2026-10-15T00:00:00Z"}`,
+	}
+
+	for name, body := range drifted {
+		t.Run(name, func(t *testing.T) {
+			recorder := serveAgainstUserSpec(t, driftedUserHandler(body))
+
+			if len(recorder.errors) != 1 {
+				t.Errorf("Expected one spec violation, got %v", recorder.errors)
+			}
+		})
+	}
+}
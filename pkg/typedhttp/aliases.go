@@ -0,0 +1,41 @@
+package typedhttp
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldAliases returns the alternative names listed in a field's aliases tag, e.g.
+// `query:"userId" aliases:"user_id,uid"`. Query, header, cookie and form decoders try
+// the canonical name first and then each alias in order.
+func FieldAliases(field reflect.StructField) []string {
+	tag := field.Tag.Get("aliases")
+	if tag == "" {
+		return nil
+	}
+
+	var aliases []string
+	for _, alias := range strings.Split(tag, ",") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	return aliases
+}
+
+// fieldNames returns the canonical source name of a field followed by its aliases.
+func fieldNames(field *reflect.StructField, name string) []string {
+	return append([]string{name}, FieldAliases(*field)...)
+}
+
+// firstValue returns the first non-empty value found under any of names.
+func firstValue(names []string, get func(name string) string) string {
+	for _, name := range names {
+		if value := get(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,103 @@
+package typedhttp
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type aliasedUserRequest struct {
+	UserID string `query:"userId" aliases:"user_id,uid"`
+}
+
+func TestFieldAliases_Query(t *testing.T) {
+	for _, name := range []string{"userId", "user_id", "uid"} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users?"+name+"=42", nil)
+
+			result, err := NewQueryDecoder[aliasedUserRequest](nil).Decode(req)
+			require.NoError(t, err)
+			assert.Equal(t, "42", result.UserID)
+
+			combined, err := NewCombinedDecoder[aliasedUserRequest](nil).Decode(req)
+			require.NoError(t, err)
+			assert.Equal(t, "42", combined.UserID)
+		})
+	}
+
+	t.Run("canonical name wins", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users?uid=1&userId=2", nil)
+
+		result, err := NewQueryDecoder[aliasedUserRequest](nil).Decode(req)
+		require.NoError(t, err)
+		assert.Equal(t, "2", result.UserID)
+	})
+}
+
+func TestFieldAliases_HeaderCookieForm(t *testing.T) {
+	type request struct {
+		Tenant  string `header:"X-Tenant" aliases:"X-Org"`
+		Session string `cookie:"session" aliases:"sid"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Org", "acme")
+	req.AddCookie(&http.Cookie{Name: "sid", Value: "abc"})
+
+	headers, err := NewHeaderDecoder[request](nil).Decode(req)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", headers.Tenant)
+
+	cookies, err := NewCookieDecoder[request](nil).Decode(req)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", cookies.Session)
+
+	combined, err := NewCombinedDecoder[request](nil).Decode(req)
+	require.NoError(t, err)
+	assert.Equal(t, request{Tenant: "acme", Session: "abc"}, combined)
+
+	type formRequest struct {
+		Name   string                `form:"name" aliases:"full_name"`
+		Avatar *multipart.FileHeader `form:"avatar" aliases:"picture"`
+	}
+
+	form := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{"full_name": {"Ada"}}.Encode()))
+	form.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	result, err := NewFormDecoder[formRequest](nil).Decode(form)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", result.Name)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("picture", "me.png")
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("png"))
+	require.NoError(t, writer.Close())
+
+	upload := httptest.NewRequest(http.MethodPost, "/", &body)
+	upload.Header.Set("Content-Type", writer.FormDataContentType())
+
+	result, err = NewFormDecoder[formRequest](nil).Decode(upload)
+	require.NoError(t, err)
+	require.NotNil(t, result.Avatar)
+	assert.Equal(t, "me.png", result.Avatar.Filename)
+}
+
+func TestFieldAliases_Router(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/users", &weekdayHandler[aliasedUserRequest]{})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users?user_id=7", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"UserID":"7"}`, rr.Body.String())
+}
@@ -0,0 +1,232 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxBatchSize is the maximum number of operations accepted in one batch request.
+const DefaultMaxBatchSize = 20
+
+// Error variables for batch processing.
+var (
+	ErrBatchTooLarge     = errors.New("batch exceeds maximum size")
+	ErrInvalidBatch      = errors.New("invalid batch request")
+	ErrInvalidBatchEntry = errors.New("invalid batch operation")
+	ErrNestedBatch       = errors.New("batch operations cannot target a batch endpoint")
+)
+
+// batchOperationKey marks the context of a request dispatched as a batch operation.
+type batchOperationKey struct{}
+
+// BatchOperation is a single operation in a batch request.
+// Method and Path name a registered route; Path may include a query string.
+type BatchOperation struct {
+	ID      string            `json:"id,omitempty"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// BatchResult is the outcome of a single batch operation.
+type BatchResult struct {
+	ID     string          `json:"id,omitempty"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchConfig holds configuration for batch processing.
+type BatchConfig struct {
+	MaxBatchSize int
+}
+
+// BatchOption configures a BatchHandler.
+type BatchOption func(*BatchConfig)
+
+// WithMaxBatchSize limits how many operations a single batch may contain.
+func WithMaxBatchSize(size int) BatchOption {
+	return func(cfg *BatchConfig) {
+		cfg.MaxBatchSize = size
+	}
+}
+
+// BatchHandler executes a JSON array of operations against a router.
+type BatchHandler struct {
+	router *TypedRouter
+	config BatchConfig
+}
+
+// Batch creates an http.Handler that accepts a JSON array of BatchOperation and
+// responds with a JSON array of BatchResult in the same order.
+//
+// Each operation is dispatched through the router, so it goes through the same
+// decoders, validation, middleware and error mapping as a standalone request.
+// Operations are isolated: a failing or panicking operation only affects its own result.
+// An operation targeting a batch endpoint fails with 400 instead of nesting batches.
+func Batch(router *TypedRouter, opts ...BatchOption) *BatchHandler {
+	config := BatchConfig{
+		MaxBatchSize: DefaultMaxBatchSize,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &BatchHandler{
+		router: router,
+		config: config,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (b *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Context().Value(batchOperationKey{}) != nil {
+		writeErrorResponse(w, http.StatusBadRequest, ErrNestedBatch)
+
+		return
+	}
+
+	var operations []BatchOperation
+	if err := json.NewDecoder(r.Body).Decode(&operations); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("%w: %w", ErrInvalidBatch, err))
+
+		return
+	}
+
+	if len(operations) > b.config.MaxBatchSize {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("%w: %d operations, limit is %d", ErrBatchTooLarge, len(operations), b.config.MaxBatchSize))
+
+		return
+	}
+
+	results := make([]BatchResult, len(operations))
+	for i := range operations {
+		results[i] = b.dispatch(r, &operations[i])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(results)
+}
+
+// dispatch runs a single operation through the router.
+func (b *BatchHandler) dispatch(parent *http.Request, op *BatchOperation) (result BatchResult) {
+	result.ID = op.ID
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = http.StatusInternalServerError
+			result.Body = batchErrorBody("Internal server error")
+		}
+	}()
+
+	req, err := newBatchRequest(parent, op)
+	if err != nil {
+		result.Status = http.StatusBadRequest
+		result.Body = batchErrorBody(err.Error())
+
+		return result
+	}
+
+	recorder := newBatchResponseWriter()
+	b.router.ServeHTTP(recorder, req)
+
+	result.Status = recorder.status
+	result.Body = recorder.jsonBody()
+
+	return result
+}
+
+// newBatchRequest builds the internal request for an operation.
+// Headers of the batch request (such as Authorization) are inherited.
+func newBatchRequest(parent *http.Request, op *BatchOperation) (*http.Request, error) {
+	if op.Method == "" || !strings.HasPrefix(op.Path, "/") {
+		return nil, fmt.Errorf("%w: method and an absolute path are required", ErrInvalidBatchEntry)
+	}
+
+	ctx := context.WithValue(parent.Context(), batchOperationKey{}, struct{}{})
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(op.Method), op.Path, bytes.NewReader(op.Body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBatchEntry, err)
+	}
+
+	req.Header = parent.Header.Clone()
+	req.Header.Del("Content-Length")
+	req.RemoteAddr = parent.RemoteAddr
+	req.Host = parent.Host
+
+	if len(op.Body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Del("Content-Type")
+	}
+
+	for name, value := range op.Headers {
+		req.Header.Set(name, value)
+	}
+
+	return req, nil
+}
+
+// batchErrorBody encodes an error message as a result body.
+func batchErrorBody(message string) json.RawMessage {
+	body, _ := json.Marshal(ErrorResponse{Error: message})
+
+	return body
+}
+
+// batchResponseWriter buffers the response of a single batch operation.
+type batchResponseWriter struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func newBatchResponseWriter() *batchResponseWriter {
+	return &batchResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+}
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	return w.body.Write(b)
+}
+
+// jsonBody returns the buffered body as JSON, encoding non-JSON bodies as a string.
+func (w *batchResponseWriter) jsonBody() json.RawMessage {
+	body := bytes.TrimSpace(w.body.Bytes())
+	if len(body) == 0 {
+		return nil
+	}
+
+	if json.Valid(body) {
+		return body
+	}
+
+	encoded, _ := json.Marshal(string(body))
+
+	return encoded
+}
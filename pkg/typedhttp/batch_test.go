@@ -0,0 +1,166 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchGetItemRequest struct {
+	ID string `path:"id"`
+}
+
+type batchCreateItemRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type batchItem struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	User string `json:"user,omitempty"`
+}
+
+type batchGetItemHandler struct{}
+
+func (h *batchGetItemHandler) Handle(ctx context.Context, req batchGetItemRequest) (batchItem, error) {
+	if req.ID == "missing" {
+		return batchItem{}, NewNotFoundError("item", req.ID)
+	}
+	if req.ID == "boom" {
+		panic("boom")
+	}
+
+	user, _ := ctx.Value(batchUserKey{}).(string)
+
+	return batchItem{ID: req.ID, User: user}, nil
+}
+
+type batchCreateItemHandler struct{}
+
+func (h *batchCreateItemHandler) Handle(_ context.Context, req batchCreateItemRequest) (batchItem, error) {
+	return batchItem{ID: "new", Name: req.Name}, nil
+}
+
+type batchUserKey struct{}
+
+// batchAuthMiddleware copies the Authorization header into the context.
+func batchAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), batchUserKey{}, r.Header.Get("Authorization"))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func newBatchTestRouter() *TypedRouter {
+	router := NewRouter()
+	GET(router, "/items/{id}", &batchGetItemHandler{}, WithMiddleware(batchAuthMiddleware))
+	POST(router, "/items", &batchCreateItemHandler{})
+
+	return router
+}
+
+func serveBatch(t *testing.T, handler http.Handler, body string) ([]BatchResult, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer alice")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	var results []BatchResult
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	}
+
+	return results, w
+}
+
+func TestBatch_DispatchesOperationsInOrder(t *testing.T) {
+	results, w := serveBatch(t, Batch(newBatchTestRouter()), `[
+		{"id": "a", "method": "GET", "path": "/items/1"},
+		{"id": "b", "method": "POST", "path": "/items", "body": {"name": "widget"}},
+		{"id": "c", "method": "GET", "path": "/items/missing"},
+		{"id": "d", "method": "POST", "path": "/items", "body": {}},
+		{"id": "e", "method": "GET", "path": "/items/boom"},
+		{"id": "f", "method": "GET", "path": "/unknown"},
+		{"id": "g", "method": "GET", "path": "relative"},
+		{"id": "h", "method": "GET", "path": "/items/2"}
+	]`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, results, 8)
+
+	ids := make([]string, len(results))
+	statuses := make([]int, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+		statuses[i] = result.Status
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g", "h"}, ids)
+	assert.Equal(t, []int{
+		http.StatusOK,
+		http.StatusCreated,
+		http.StatusNotFound,
+		http.StatusBadRequest,
+		http.StatusInternalServerError,
+		http.StatusNotFound,
+		http.StatusBadRequest,
+		http.StatusOK,
+	}, statuses)
+
+	assert.JSONEq(t, `{"id":"1","user":"Bearer alice"}`, string(results[0].Body))
+	assert.JSONEq(t, `{"id":"new","name":"widget"}`, string(results[1].Body))
+	assert.Contains(t, string(results[3].Body), "VALIDATION_ERROR")
+	assert.JSONEq(t, `{"id":"2","user":"Bearer alice"}`, string(results[7].Body))
+}
+
+func TestBatch_PerOperationHeadersOverrideInherited(t *testing.T) {
+	results, w := serveBatch(t, Batch(newBatchTestRouter()), `[
+		{"method": "GET", "path": "/items/1", "headers": {"Authorization": "Bearer bob"}}
+	]`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, results, 1)
+	assert.JSONEq(t, `{"id":"1","user":"Bearer bob"}`, string(results[0].Body))
+}
+
+func TestBatch_RejectsOversizedBatch(t *testing.T) {
+	_, w := serveBatch(t, Batch(newBatchTestRouter(), WithMaxBatchSize(2)), `[
+		{"method": "GET", "path": "/items/1"},
+		{"method": "GET", "path": "/items/2"},
+		{"method": "GET", "path": "/items/3"}
+	]`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), ErrBatchTooLarge.Error())
+}
+
+func TestBatch_RejectsInvalidJSON(t *testing.T) {
+	_, w := serveBatch(t, Batch(newBatchTestRouter()), `{"method": "GET"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidBatch.Error())
+}
+
+func TestBatch_RejectsNestedBatch(t *testing.T) {
+	router := newBatchTestRouter()
+	router.Handle(http.MethodPost, "/batch", Batch(router))
+
+	results, w := serveBatch(t, router, `[
+		{"id": "a", "method": "GET", "path": "/items/1"},
+		{"id": "b", "method": "POST", "path": "/batch", "body": [{"method": "GET", "path": "/items/2"}]}
+	]`)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, results, 2)
+	assert.Equal(t, http.StatusOK, results[0].Status)
+	assert.Equal(t, http.StatusBadRequest, results[1].Status)
+	assert.Contains(t, string(results[1].Body), ErrNestedBatch.Error())
+}
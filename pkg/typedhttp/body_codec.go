@@ -0,0 +1,94 @@
+package typedhttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// BodyCodec serializes request and response bodies for a single media type.
+// Codecs enabled with WithBodyCodecs are selected per request from the
+// Content-Type and Accept headers, with JSON remaining the default.
+type BodyCodec interface {
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// CodecDecoder implements RequestDecoder for request bodies in a BodyCodec format.
+type CodecDecoder[T any] struct {
+	codec     BodyCodec
+	validator *validator.Validate
+}
+
+// NewCodecDecoder creates a body decoder for the codec with optional validation.
+func NewCodecDecoder[T any](validator *validator.Validate, codec BodyCodec) *CodecDecoder[T] {
+	return &CodecDecoder[T]{
+		codec:     codec,
+		validator: validator,
+	}
+}
+
+// Decode decodes the request body into the target type.
+func (d *CodecDecoder[T]) Decode(r *http.Request) (T, error) {
+	var result T
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return result, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if err := d.codec.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("invalid %s body: %w", d.codec.ContentType(), err)
+	}
+
+	if err := normalize(&result); err != nil {
+		return result, err
+	}
+
+	if d.validator != nil {
+		if err := d.validator.Struct(result); err != nil {
+			return result, newValidationErrorFromValidator("Validation failed", err)
+		}
+	}
+
+	return result, nil
+}
+
+// ContentTypes returns the content type handled by the codec.
+func (d *CodecDecoder[T]) ContentTypes() []string {
+	return []string{d.codec.ContentType()}
+}
+
+// CodecEncoder implements ResponseEncoder using a BodyCodec.
+type CodecEncoder[T any] struct {
+	codec BodyCodec
+}
+
+// NewCodecEncoder creates a response encoder for the codec.
+func NewCodecEncoder[T any](codec BodyCodec) *CodecEncoder[T] {
+	return &CodecEncoder[T]{
+		codec: codec,
+	}
+}
+
+// Encode encodes the response data with the codec.
+func (e *CodecEncoder[T]) Encode(w http.ResponseWriter, data T, statusCode int) error {
+	body, err := e.codec.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s response: %w", e.codec.ContentType(), err)
+	}
+
+	w.Header().Set("Content-Type", e.codec.ContentType())
+	w.WriteHeader(statusCode)
+	_, err = w.Write(body)
+
+	return err
+}
+
+// ContentType returns the content type produced by the codec.
+func (e *CodecEncoder[T]) ContentType() string {
+	return e.codec.ContentType()
+}
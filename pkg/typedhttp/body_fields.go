@@ -0,0 +1,107 @@
+package typedhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// bodyBinding maps the body-tagged fields of a request struct onto a struct type
+// holding only those fields, so the body cannot overwrite path, query or header fields.
+type bodyBinding struct {
+	bodyType reflect.Type
+	fields   []int // Field indexes in the request type, in bodyType field order
+}
+
+// bodyFieldTags are the struct tags carried over to the fields of a body type.
+var bodyFieldTags = []string{"validate", "example"}
+
+// BodyType returns a struct type made of the fields of requestType tagged with body,
+// using the tag value as their JSON name. It returns nil if no field has a body tag,
+// in which case the whole struct is decoded from the body as before.
+func BodyType(requestType reflect.Type) reflect.Type {
+	if binding := newBodyBinding(requestType); binding != nil {
+		return binding.bodyType
+	}
+
+	return nil
+}
+
+// newBodyBinding builds the body binding for requestType, or nil if no field has a body tag.
+func newBodyBinding(requestType reflect.Type) *bodyBinding {
+	if requestType == nil || requestType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var (
+		structFields []reflect.StructField
+		indexes      []int
+	)
+
+	for i := 0; i < requestType.NumField(); i++ {
+		field := requestType.Field(i)
+
+		bodyName, ok := field.Tag.Lookup("body")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		tag := fmt.Sprintf("json:%q", bodyName)
+		for _, key := range bodyFieldTags {
+			if value, ok := field.Tag.Lookup(key); ok {
+				tag += fmt.Sprintf(" %s:%q", key, value)
+			}
+		}
+
+		structFields = append(structFields, reflect.StructField{
+			Name: field.Name,
+			Type: field.Type,
+			Tag:  reflect.StructTag(tag),
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(structFields) == 0 {
+		return nil
+	}
+
+	return &bodyBinding{
+		bodyType: reflect.StructOf(structFields),
+		fields:   indexes,
+	}
+}
+
+// decode unmarshals the request body into the body-tagged fields of target.
+// Bodies in other formats than JSON are decoded with the codec registered for their content type.
+func (b *bodyBinding) decode(r *http.Request, codecs map[string]BodyCodec, target reflect.Value) error {
+	body := reflect.New(b.bodyType)
+	contentType := r.Header.Get("Content-Type")
+
+	if codec, ok := codecs[mediaTypeOf(contentType)]; ok {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		if err := codec.Unmarshal(data, body.Interface()); err != nil {
+			return fmt.Errorf("invalid %s body: %w", codec.ContentType(), err)
+		}
+	} else if strings.Contains(contentType, "application/json") {
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(body.Interface()); err != nil {
+			return jsonDecodeError(err, decoder)
+		}
+	} else {
+		return nil
+	}
+
+	for i, index := range b.fields {
+		if value := body.Elem().Field(i); !value.IsZero() {
+			target.Field(index).Set(value)
+		}
+	}
+
+	return nil
+}
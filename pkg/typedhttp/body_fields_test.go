@@ -0,0 +1,101 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UpdateWidgetRequest struct {
+	ID     string   `path:"id" validate:"required"`
+	DryRun bool     `query:"dry_run"`
+	Name   string   `body:"name" validate:"required"`
+	Tags   []string `body:"tags,omitempty"`
+}
+
+type WidgetResponse struct {
+	ID     string   `json:"id"`
+	DryRun bool     `json:"dry_run"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags"`
+}
+
+type updateWidgetHandler struct{}
+
+func (h *updateWidgetHandler) Handle(_ context.Context, req UpdateWidgetRequest) (WidgetResponse, error) {
+	return WidgetResponse(req), nil
+}
+
+func putWidget(t *testing.T, router *TypedRouter, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestBodyTag_BindsBodyAlongsideParams(t *testing.T) {
+	router := NewRouter()
+	PUT(router, "/widgets/{id}", &updateWidgetHandler{})
+
+	w := putWidget(t, router, "/widgets/w-1?dry_run=true", `{"name":"Sprocket","tags":["metal"]}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"id":"w-1","dry_run":true,"name":"Sprocket","tags":["metal"]}`, w.Body.String())
+}
+
+func TestBodyTag_BodyCannotOverrideParams(t *testing.T) {
+	router := NewRouter()
+	PUT(router, "/widgets/{id}", &updateWidgetHandler{})
+
+	w := putWidget(t, router, "/widgets/w-1", `{"id":"w-2","ID":"w-3","DryRun":true,"name":"Sprocket"}`)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"id":"w-1","dry_run":false,"name":"Sprocket","tags":null}`, w.Body.String())
+}
+
+func TestBodyTag_ValidatesBodyFields(t *testing.T) {
+	router := NewRouter()
+	PUT(router, "/widgets/{id}", &updateWidgetHandler{})
+
+	w := putWidget(t, router, "/widgets/w-1", `{"tags":["metal"]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = putWidget(t, router, "/widgets/w-1", `{"name":`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBodyTag_WithMsgpack(t *testing.T) {
+	router := NewRouter()
+	PUT(router, "/widgets/{id}", &updateWidgetHandler{}, WithMsgpack())
+
+	body, err := MsgpackCodec{}.Marshal(map[string]interface{}{"name": "Sprocket", "id": "w-2"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, "/widgets/w-1", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", MediaTypeMsgpack)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"id":"w-1","dry_run":false,"name":"Sprocket","tags":null}`, w.Body.String())
+}
+
+func TestBodyType(t *testing.T) {
+	assert.Nil(t, BodyType(reflect.TypeOf(ProfileRequest{})))
+
+	bodyType := BodyType(reflect.TypeOf(UpdateWidgetRequest{}))
+	require.NotNil(t, bodyType)
+	require.Equal(t, 2, bodyType.NumField())
+	assert.Equal(t, `json:"name" validate:"required"`, string(bodyType.Field(0).Tag))
+	assert.Equal(t, `json:"tags,omitempty"`, string(bodyType.Field(1).Tag))
+}
@@ -0,0 +1,168 @@
+package typedhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingEnvelope is returned by UnwrapJSONEnvelope for bodies without the envelope.
+var ErrMissingEnvelope = errors.New("request body is not wrapped in the expected envelope")
+
+// BodyTransform rewrites a raw JSON body.
+type BodyTransform func(body []byte) ([]byte, error)
+
+// bodyTransforms holds the router-wide body transforms.
+type bodyTransforms struct {
+	request  []BodyTransform
+	response []BodyTransform
+}
+
+// WithRequestBodyTransform rewrites every JSON request body before it is decoded, e.g.
+// UnwrapJSONEnvelope("data"). Transforms run in the order they are added. A failing
+// transform answers 400 Bad Request. Bodies without a Content-Type are treated as JSON;
+// empty and non-JSON bodies are left alone.
+//
+// Unlike the envelope middleware this changes the wire format only; the OpenAPI
+// document still describes the handler's own types.
+func WithRequestBodyTransform(transform BodyTransform) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.bodyTransforms.request = append(cfg.bodyTransforms.request, transform)
+	}
+}
+
+// WithResponseBodyTransform rewrites every successful JSON response body after it is
+// encoded, e.g. WrapJSONEnvelope("data"). Transforms run in the order they are added.
+// Error responses and non-JSON bodies are left alone. Responses are buffered in full,
+// so streamed responses lose their incremental delivery.
+func WithResponseBodyTransform(transform BodyTransform) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.bodyTransforms.response = append(cfg.bodyTransforms.response, transform)
+	}
+}
+
+// UnwrapJSONEnvelope returns a transform that replaces {"<field>": value} with value.
+// Bodies that are not objects holding field fail with ErrMissingEnvelope.
+func UnwrapJSONEnvelope(field string) BodyTransform {
+	return func(body []byte) ([]byte, error) {
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrMissingEnvelope, field, err)
+		}
+
+		value, ok := envelope[field]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMissingEnvelope, field)
+		}
+
+		return value, nil
+	}
+}
+
+// WrapJSONEnvelope returns a transform that replaces body with {"<field>": body}.
+func WrapJSONEnvelope(field string) BodyTransform {
+	key, _ := json.Marshal(field)
+
+	return func(body []byte) ([]byte, error) {
+		body = bytes.TrimSpace(body)
+		if len(body) == 0 {
+			body = []byte("null")
+		}
+
+		wrapped := make([]byte, 0, len(key)+len(body)+4)
+		wrapped = append(wrapped, '{')
+		wrapped = append(wrapped, key...)
+		wrapped = append(wrapped, ':')
+		wrapped = append(wrapped, body...)
+		wrapped = append(wrapped, '}', '\n')
+
+		return wrapped, nil
+	}
+}
+
+// wrap applies the transforms around next, or returns next when there are none.
+func (t bodyTransforms) wrap(next http.Handler) http.Handler {
+	if len(t.request) == 0 && len(t.response) == 0 {
+		return next
+	}
+
+	return &bodyTransformHandler{transforms: t, next: next}
+}
+
+// bodyTransformHandler applies body transforms around a route.
+type bodyTransformHandler struct {
+	transforms bodyTransforms
+	next       http.Handler
+}
+
+func (h *bodyTransformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if len(h.transforms.request) > 0 && r.Body != nil && r.Body != http.NoBody &&
+		(contentType == "" || isJSONMediaType(contentType)) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, fmt.Errorf("reading request body: %w", err))
+
+			return
+		}
+
+		if len(bytes.TrimSpace(body)) > 0 {
+			for _, transform := range h.transforms.request {
+				if body, err = transform(body); err != nil {
+					writeErrorResponse(w, http.StatusBadRequest, err)
+
+					return
+				}
+			}
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	if len(h.transforms.response) == 0 {
+		h.next.ServeHTTP(w, r)
+
+		return
+	}
+
+	buffer := newBatchResponseWriter()
+	h.next.ServeHTTP(buffer, r)
+
+	body := buffer.body.Bytes()
+	if buffer.status >= 200 && buffer.status < 300 && len(body) > 0 &&
+		isJSONMediaType(buffer.header.Get("Content-Type")) {
+		var err error
+		for _, transform := range h.transforms.response {
+			if body, err = transform(body); err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, fmt.Errorf("transforming response body: %w", err))
+
+				return
+			}
+		}
+		buffer.header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	for name, values := range buffer.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(buffer.status)
+	_, _ = w.Write(body)
+}
+
+// isJSONMediaType reports whether contentType is application/json or a +json type.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
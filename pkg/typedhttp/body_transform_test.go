@@ -0,0 +1,119 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createWidgetRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Color string `json:"color"`
+}
+
+type widgetResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type createWidgetHandler struct {
+	received createWidgetRequest
+}
+
+func (h *createWidgetHandler) Handle(_ context.Context, req createWidgetRequest) (widgetResponse, error) {
+	h.received = req
+	if req.Name == "taken" {
+		return widgetResponse{}, NewConflictError("widget already exists")
+	}
+
+	return widgetResponse{ID: "w-1", Name: req.Name, Color: req.Color}, nil
+}
+
+func newEnvelopeRouter(handler *createWidgetHandler) *TypedRouter {
+	router := NewRouter(
+		WithRequestBodyTransform(UnwrapJSONEnvelope("data")),
+		WithResponseBodyTransform(WrapJSONEnvelope("data")),
+	)
+	POST(router, "/widgets", handler)
+
+	return router
+}
+
+func postWidget(router *TypedRouter, body, contentType string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/widgets", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestBodyTransform_RoundTripsEnvelope(t *testing.T) {
+	handler := &createWidgetHandler{}
+	router := newEnvelopeRouter(handler)
+
+	w := postWidget(router, `{"data":{"name":"gear","color":"red"}}`, "application/json")
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, createWidgetRequest{Name: "gear", Color: "red"}, handler.received)
+	assert.JSONEq(t, `{"data":{"id":"w-1","name":"gear","color":"red"}}`, w.Body.String())
+	assert.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+}
+
+func TestBodyTransform_RequestWithoutContentType(t *testing.T) {
+	handler := &createWidgetHandler{}
+	router := newEnvelopeRouter(handler)
+
+	w := postWidget(router, `{"data":{"name":"gear"}}`, "")
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "gear", handler.received.Name)
+}
+
+func TestBodyTransform_MissingEnvelope(t *testing.T) {
+	router := newEnvelopeRouter(&createWidgetHandler{})
+
+	for _, body := range []string{`{"name":"gear"}`, `["gear"]`} {
+		w := postWidget(router, body, "application/json")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.Contains(t, w.Body.String(), "envelope", body)
+		assert.NotContains(t, w.Body.String(), `"data"`, "errors are not wrapped")
+	}
+}
+
+func TestBodyTransform_ErrorsAreNotWrapped(t *testing.T) {
+	router := newEnvelopeRouter(&createWidgetHandler{})
+
+	w := postWidget(router, `{"data":{"name":"taken"}}`, "application/json")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NotContains(t, w.Body.String(), `"data"`)
+}
+
+func TestBodyTransform_OrderAndNonJSONBodies(t *testing.T) {
+	handler := &createWidgetHandler{}
+	router := NewRouter(
+		WithRequestBodyTransform(UnwrapJSONEnvelope("request")),
+		WithRequestBodyTransform(UnwrapJSONEnvelope("data")),
+		WithResponseBodyTransform(WrapJSONEnvelope("data")),
+		WithResponseBodyTransform(WrapJSONEnvelope("response")),
+	)
+	POST(router, "/widgets", handler)
+
+	w := postWidget(router, `{"request":{"data":{"name":"gear"}}}`, "application/json")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"response":{"data":{"id":"w-1","name":"gear","color":""}}}`, w.Body.String())
+
+	w = postWidget(router, "name=gear", "application/x-www-form-urlencoded")
+	assert.Equal(t, "gear", handler.received.Name, "form bodies are decoded untouched")
+}
@@ -0,0 +1,44 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ContextWithBudget returns a copy of ctx whose time budget ends after total. The budget
+// is the context deadline, so it is shared by everything that runs with the context:
+// retries, downstream calls and nested timeouts draw from what is left rather than
+// starting their own clock. A budget never extends an earlier deadline of ctx.
+func ContextWithBudget(ctx context.Context, total time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, total)
+}
+
+// Budget returns the time left in the request budget of ctx, or false when ctx has no
+// deadline. An exhausted budget is reported as zero.
+func Budget(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+
+	return max(time.Until(deadline), 0), true
+}
+
+// WithTimeBudget gives every request of the handler a total time budget. It wraps the
+// handler's other middleware, so a retry middleware sees the budget and stops retrying
+// once the remaining time cannot cover another attempt.
+func WithTimeBudget(total time.Duration) HandlerOption {
+	budget := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := ContextWithBudget(r.Context(), total)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	return func(cfg *HandlerConfig) {
+		cfg.Middleware = append([]Middleware{budget}, cfg.Middleware...)
+	}
+}
@@ -0,0 +1,59 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	_, ok := Budget(context.Background())
+	assert.False(t, ok)
+
+	ctx, cancel := ContextWithBudget(context.Background(), time.Minute)
+	defer cancel()
+
+	remaining, ok := Budget(ctx)
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, remaining, float64(time.Second))
+
+	// A nested budget cannot extend the outer one
+	nested, cancelNested := ContextWithBudget(ctx, time.Hour)
+	defer cancelNested()
+
+	remaining, ok = Budget(nested)
+	require.True(t, ok)
+	assert.LessOrEqual(t, remaining, time.Minute)
+
+	expired, cancelExpired := ContextWithBudget(context.Background(), -time.Second)
+	defer cancelExpired()
+
+	remaining, ok = Budget(expired)
+	require.True(t, ok)
+	assert.Zero(t, remaining)
+}
+
+func TestWithTimeBudget_WrapsHandlerMiddleware(t *testing.T) {
+	var remaining time.Duration
+	var ok bool
+	observe := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remaining, ok = Budget(r.Context())
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	router := NewRouter()
+	GET(router, "/budget", &cachedPayloadHandler{raw: &Raw{}}, WithMiddleware(observe), WithTimeBudget(time.Minute))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/budget", http.NoBody))
+
+	require.True(t, ok)
+	assert.InDelta(t, time.Minute, remaining, float64(time.Second))
+}
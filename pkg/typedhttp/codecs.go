@@ -1,9 +1,11 @@
 package typedhttp
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -19,11 +21,13 @@ var (
 	ErrInvalidFloatValue    = errors.New("invalid float value")
 	ErrInvalidBooleanValue  = errors.New("invalid boolean value")
 	ErrUnsupportedFieldType = errors.New("unsupported field type")
+	ErrIntegerOutOfRange    = errors.New("integer out of range")
 )
 
 // JSONDecoder implements RequestDecoder for JSON content.
 type JSONDecoder[T any] struct {
 	validator *validator.Validate
+	names     *fieldNamer // renames untagged fields when a FieldNameStrategy is set
 }
 
 // NewJSONDecoder creates a new JSON decoder with optional validation.
@@ -37,30 +41,158 @@ func NewJSONDecoder[T any](validator *validator.Validate) *JSONDecoder[T] {
 func (d *JSONDecoder[T]) Decode(r *http.Request) (T, error) {
 	var result T
 
-	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
-		return result, fmt.Errorf("invalid JSON: %w", err)
+	body := r.Body
+	if d.names != nil {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return result, fmt.Errorf("failed to read request body: %w", err)
+		}
+		// Invalid documents are decoded as sent so errors point at the client's bytes
+		if renamed, err := d.names.fromWire(data, reflect.TypeOf(&result).Elem()); err == nil {
+			data = renamed
+		}
+		body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return result, jsonDecodeError(err, decoder)
+	}
+
+	if err := normalize(&result); err != nil {
+		return result, err
 	}
 
 	// Perform validation if validator is available
 	if d.validator != nil {
 		if err := d.validator.Struct(result); err != nil {
-			// Convert validator errors to ValidationError
-			validationErrors := make(map[string]string)
-			var validatorErrs validator.ValidationErrors
-			if errors.As(err, &validatorErrs) {
-				for _, validatorErr := range validatorErrs {
-					field := strings.ToLower(validatorErr.Field())
-					validationErrors[field] = validatorErr.Tag()
-				}
-			}
-
-			return result, NewValidationError("Validation failed", validationErrors)
+			return result, newValidationErrorFromValidator("Validation failed", err)
 		}
 	}
 
 	return result, nil
 }
 
+// jsonDecodeError wraps a JSON decoding error. Numbers that do not fit an integer
+// field are reported as a DecodeError carrying the JSON path of the field; other
+// syntax and type errors as a JSONError with the byte offset where decoding stopped.
+func jsonDecodeError(err error, decoder *json.Decoder) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &JSONError{
+			Offset:  syntaxErr.Offset,
+			Message: fmt.Sprintf("%s at offset %d", syntaxErr, syntaxErr.Offset),
+			Cause:   err,
+		}
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// The decoder read the whole body looking for the end of the value
+		offset := decoder.InputOffset()
+		if buffered, ok := decoder.Buffered().(*bytes.Reader); ok {
+			offset += int64(buffered.Len())
+		}
+
+		return &JSONError{
+			Offset:  offset,
+			Message: fmt.Sprintf("unexpected end of JSON input at offset %d", offset),
+			Cause:   err,
+		}
+	}
+
+	if errors.Is(err, ErrUnknownVariant) {
+		return &JSONError{
+			Offset:  decoder.InputOffset(),
+			Message: err.Error(),
+			Cause:   err,
+		}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Type == nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if !strings.HasPrefix(typeErr.Value, "number ") || !isIntegerKind(typeErr.Type.Kind()) {
+		return jsonTypeError(typeErr)
+	}
+
+	literal := strings.TrimPrefix(typeErr.Value, "number ")
+
+	var cause error
+	switch typeErr.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cause = integerLiteralError(literal, typeErr.Type, ErrInvalidIntegerValue)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cause = integerLiteralError(literal, typeErr.Type, ErrInvalidUintegerValue)
+	default:
+		return jsonTypeError(typeErr)
+	}
+
+	return newDecodeError(SourceJSON, typeErr.Field, typeErr.Field, "invalid JSON", cause)
+}
+
+// isIntegerKind reports whether kind is a signed or unsigned integer.
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonTypeError describes a JSON value of the wrong type for its field.
+func jsonTypeError(typeErr *json.UnmarshalTypeError) *JSONError {
+	got := typeErr.Value
+	if fields := strings.Fields(got); len(fields) > 0 {
+		got = fields[0] // "number 1.5" -> "number"
+	}
+
+	subject := "body"
+	if typeErr.Field != "" {
+		subject = fmt.Sprintf("field %q", typeErr.Field)
+	}
+
+	return &JSONError{
+		Offset: typeErr.Offset,
+		Field:  typeErr.Field,
+		Message: fmt.Sprintf("%s must be %s, got %s at offset %d",
+			subject, jsonTypeName(typeErr.Type), got, typeErr.Offset),
+		Cause: typeErr,
+	}
+}
+
+// jsonTypeName names the JSON type a Go type is decoded from.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	default:
+		return t.String()
+	}
+}
+
+// integerLiteralError explains why a JSON number literal cannot be stored in an integer type.
+func integerLiteralError(literal string, target reflect.Type, invalid error) error {
+	if strings.ContainsAny(literal, ".eE") {
+		return fmt.Errorf("%w: %s", invalid, literal)
+	}
+
+	return fmt.Errorf("%w: %s overflows %s", ErrIntegerOutOfRange, literal, target)
+}
+
 // ContentTypes returns the supported content types for JSON decoding.
 func (d *JSONDecoder[T]) ContentTypes() []string {
 	return []string{"application/json"}
@@ -86,6 +218,10 @@ func (d *QueryDecoder[T]) Decode(r *http.Request) (T, error) {
 	resultValue := reflect.ValueOf(&result).Elem()
 	resultType := resultValue.Type()
 
+	// Keep binding after a conversion error so every bad field is reported
+	var decodeErrs []*DecodeError
+	query := r.URL.Query()
+
 	for i := 0; i < resultType.NumField(); i++ {
 		field := resultType.Field(i)
 		fieldValue := resultValue.Field(i)
@@ -101,8 +237,21 @@ func (d *QueryDecoder[T]) Decode(r *http.Request) (T, error) {
 			queryName = strings.ToLower(field.Name)
 		}
 
+		if isNestedQueryType(field.Type) {
+			style, err := FieldQueryStyle(field)
+			if err == nil {
+				err = decodeNestedQuery(query, queryName, style, fieldValue)
+			}
+			if err != nil {
+				decodeErrs = append(decodeErrs,
+					newDecodeError(SourceQuery, field.Name, queryName, "failed to set field "+field.Name, err))
+			}
+
+			continue
+		}
+
 		// Get the value from query parameters
-		queryValue := r.URL.Query().Get(queryName)
+		queryValue := firstValue(fieldNames(&field, queryName), query.Get)
 		if queryValue == "" {
 			// Check for default value
 			if defaultValue := field.Tag.Get("default"); defaultValue != "" {
@@ -114,24 +263,23 @@ func (d *QueryDecoder[T]) Decode(r *http.Request) (T, error) {
 
 		// Set the field value based on its type
 		if err := setFieldValue(fieldValue, queryValue); err != nil {
-			return result, fmt.Errorf("failed to set field %s: %w", field.Name, err)
+			decodeErrs = append(decodeErrs,
+				newDecodeError(SourceQuery, field.Name, queryName, "failed to set field "+field.Name, err))
 		}
 	}
 
+	if err := normalize(&result); err != nil {
+		return result, err
+	}
+
 	// Perform validation if validator is available
+	var validationErr error
 	if d.validator != nil {
-		if err := d.validator.Struct(result); err != nil {
-			validationErrors := make(map[string]string)
-			var validatorErrs validator.ValidationErrors
-			if errors.As(err, &validatorErrs) {
-				for _, validatorErr := range validatorErrs {
-					field := strings.ToLower(validatorErr.Field())
-					validationErrors[field] = validatorErr.Tag()
-				}
-			}
+		validationErr = d.validator.Struct(result)
+	}
 
-			return result, NewValidationError("Validation failed", validationErrors)
-		}
+	if err := aggregateRequestErrors("Validation failed", decodeErrs, validationErr); err != nil {
+		return result, err
 	}
 
 	return result, nil
@@ -185,7 +333,9 @@ func setFieldValue(fieldValue reflect.Value, value string) error {
 }
 
 // JSONEncoder implements ResponseEncoder for JSON content.
-type JSONEncoder[T any] struct{}
+type JSONEncoder[T any] struct {
+	names *fieldNamer // renames untagged fields when a FieldNameStrategy is set
+}
 
 // NewJSONEncoder creates a new JSON encoder.
 func NewJSONEncoder[T any]() *JSONEncoder[T] {
@@ -194,6 +344,10 @@ func NewJSONEncoder[T any]() *JSONEncoder[T] {
 
 // Encode encodes the response data as JSON and writes it to the response writer.
 func (e *JSONEncoder[T]) Encode(w http.ResponseWriter, data T, statusCode int) error {
+	if e.names != nil {
+		return e.encodeRenamed(w, data, statusCode)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -204,6 +358,29 @@ func (e *JSONEncoder[T]) Encode(w http.ResponseWriter, data T, statusCode int) e
 	return nil
 }
 
+// encodeRenamed encodes data with untagged fields named by the field name strategy.
+// The document is built before the status is written, so failures can still be reported.
+func (e *JSONEncoder[T]) encodeRenamed(w http.ResponseWriter, data T, statusCode int) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON response: %w", err)
+	}
+
+	t := reflect.TypeOf(&data).Elem()
+	if t.Kind() == reflect.Interface {
+		t = reflect.TypeOf(data)
+	}
+	if encoded, err = e.names.toWire(encoded, t); err != nil {
+		return fmt.Errorf("failed to encode JSON response: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(append(encoded, '\n'))
+
+	return err
+}
+
 // ContentType returns the content type for JSON encoding.
 func (e *JSONEncoder[T]) ContentType() string {
 	return "application/json"
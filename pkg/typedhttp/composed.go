@@ -0,0 +1,99 @@
+package typedhttp
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// Composed is a request made of a fragment shared by many endpoints, such as the
+// authentication headers, and the endpoint's own Params. Both parts are bound from
+// the same request with their usual tags and validated separately; their failures
+// are reported together. Only Params reads the request body, so Shared is bound from
+// the path, query, headers, cookies and request metadata.
+//
+// Declare an alias to reuse a fragment across handlers:
+//
+//	type Authed[P any] = typedhttp.Composed[AuthContext, P]
+//
+//	func (h *GetOrderHandler) Handle(ctx context.Context, req Authed[GetOrderParams]) (Order, error)
+//
+// The OpenAPI document lists the parameters of both parts.
+type Composed[Shared, Params any] struct {
+	Shared Shared
+	Params Params
+}
+
+// RequestParts returns the types of the shared fragment and the endpoint parameters.
+func (Composed[Shared, Params]) RequestParts() (shared, params reflect.Type) {
+	return reflect.TypeOf((*Shared)(nil)).Elem(), reflect.TypeOf((*Params)(nil)).Elem()
+}
+
+// newDecoder implements composedRequest.
+func (Composed[Shared, Params]) newDecoder(config *HandlerConfig) interface{} {
+	return &composedDecoder[Shared, Params]{
+		shared: newCachedDecoder[Shared](config),
+		params: newCachedDecoder[Params](config),
+	}
+}
+
+// composedRequest is implemented by Composed, whose decoder is built from its parts.
+type composedRequest interface {
+	newDecoder(config *HandlerConfig) interface{}
+}
+
+// composedDecoder decodes both parts of a Composed request.
+type composedDecoder[Shared, Params any] struct {
+	shared RequestDecoder[Shared]
+	params RequestDecoder[Params]
+}
+
+// Decode implements RequestDecoder.
+func (d *composedDecoder[Shared, Params]) Decode(r *http.Request) (Composed[Shared, Params], error) {
+	// The body belongs to Params
+	sharedRequest := r.WithContext(r.Context())
+	sharedRequest.Body = http.NoBody
+	sharedRequest.ContentLength = 0
+
+	shared, sharedErr := d.shared.Decode(sharedRequest)
+	params, paramsErr := d.params.Decode(r)
+
+	return Composed[Shared, Params]{Shared: shared, Params: params}, joinValidationErrors(sharedErr, paramsErr)
+}
+
+// ContentTypes implements RequestDecoder.
+func (d *composedDecoder[Shared, Params]) ContentTypes() []string {
+	return d.params.ContentTypes()
+}
+
+// joinValidationErrors merges two decode results. Validation failures are combined,
+// keeping the first failure of a field; any other error is returned as is.
+func joinValidationErrors(first, second error) error {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+
+	var firstErr, secondErr *ValidationError
+	if !errors.As(first, &firstErr) {
+		return first
+	}
+	if !errors.As(second, &secondErr) {
+		return second
+	}
+
+	if firstErr.Fields == nil {
+		firstErr.Fields = make(map[string]string, len(secondErr.Fields))
+	}
+	for field, message := range secondErr.Fields {
+		if _, failed := firstErr.Fields[field]; !failed {
+			firstErr.Fields[field] = message
+		}
+	}
+	firstErr.DecodeErrors = append(firstErr.DecodeErrors, secondErr.DecodeErrors...)
+	firstErr.validatorErrs = append(firstErr.validatorErrs, secondErr.validatorErrs...)
+
+	return first
+}
@@ -0,0 +1,102 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type authContext struct {
+	Token    string `header:"Authorization" validate:"required"`
+	TenantID string `header:"X-Tenant-ID"`
+}
+
+type authed[P any] = Composed[authContext, P]
+
+type getInvoiceParams struct {
+	ID string `path:"id" validate:"required"`
+}
+
+type createInvoiceParams struct {
+	Customer string `json:"customer" validate:"required"`
+	Amount   int    `json:"amount" validate:"min=1"`
+}
+
+type invoiceResponse struct {
+	ID       string `json:"id"`
+	Customer string `json:"customer,omitempty"`
+	Token    string `json:"token"`
+	TenantID string `json:"tenant_id"`
+}
+
+type getInvoiceHandler struct{}
+
+func (h *getInvoiceHandler) Handle(_ context.Context, req authed[getInvoiceParams]) (invoiceResponse, error) {
+	return invoiceResponse{ID: req.Params.ID, Token: req.Shared.Token, TenantID: req.Shared.TenantID}, nil
+}
+
+type createInvoiceHandler struct{}
+
+func (h *createInvoiceHandler) Handle(_ context.Context, req authed[createInvoiceParams]) (invoiceResponse, error) {
+	return invoiceResponse{ID: "inv-1", Customer: req.Params.Customer, Token: req.Shared.Token}, nil
+}
+
+func newInvoiceRouter() *TypedRouter {
+	router := NewRouter()
+	GET(router, "/invoices/{id}", &getInvoiceHandler{})
+	POST(router, "/invoices", &createInvoiceHandler{})
+
+	return router
+}
+
+func TestComposed_BindsBothParts(t *testing.T) {
+	router := newInvoiceRouter()
+
+	r := httptest.NewRequest(http.MethodGet, "/invoices/42", http.NoBody)
+	r.Header.Set("Authorization", "Bearer abc")
+	r.Header.Set("X-Tenant-ID", "acme")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp invoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, invoiceResponse{ID: "42", Token: "Bearer abc", TenantID: "acme"}, resp)
+}
+
+func TestComposed_BodyBelongsToParams(t *testing.T) {
+	router := newInvoiceRouter()
+
+	r := httptest.NewRequest(http.MethodPost, "/invoices", strings.NewReader(`{"customer":"globex","amount":10}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer abc")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var resp invoiceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "globex", resp.Customer)
+	assert.Equal(t, "Bearer abc", resp.Token)
+}
+
+func TestComposed_ReportsFailuresOfBothParts(t *testing.T) {
+	router := newInvoiceRouter()
+
+	r := httptest.NewRequest(http.MethodPost, "/invoices", strings.NewReader(`{"amount":10}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp.Details, "token")
+	assert.Contains(t, resp.Details, "customer")
+}
@@ -0,0 +1,27 @@
+package typedhttp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// checkConsumes returns a 415 error when r carries a body whose media type is not one
+// of consumes. Ranges such as "image/*" are honored. Requests without a body pass.
+func checkConsumes(r *http.Request, consumes []string) error {
+	if r.ContentLength == 0 && (r.Body == nil || r.Body == http.NoBody) {
+		return nil
+	}
+
+	mediaType := mediaTypeOf(r.Header.Get("Content-Type"))
+	if mediaType != "" {
+		for _, accepted := range consumes {
+			if mediaTypeMatches(accepted, mediaType) {
+				return nil
+			}
+		}
+	}
+
+	return NewHTTPError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+		"request body must be one of: "+strings.Join(consumes, ", ")).
+		WithHeader("Accept", strings.Join(consumes, ", "))
+}
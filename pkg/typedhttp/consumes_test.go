@@ -0,0 +1,107 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type avatarUploadRequest struct {
+	UserID string `path:"id"`
+	Title  string `form:"title" validate:"required"`
+}
+
+type avatarUploadHandler struct {
+	calls int
+}
+
+func (h *avatarUploadHandler) Handle(_ context.Context, req avatarUploadRequest) (map[string]string, error) {
+	h.calls++
+
+	return map[string]string{"title": req.Title}, nil
+}
+
+func TestWithConsumes_RejectsOtherMediaTypes(t *testing.T) {
+	handler := &avatarUploadHandler{}
+	router := NewRouter()
+	PUT(router, "/users/{id}/avatar", handler, WithConsumes("multipart/form-data"))
+
+	t.Run("JSON body is rejected with 415", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/users/7/avatar", strings.NewReader(`{"title": "me"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		assert.Equal(t, "multipart/form-data", w.Header().Get("Accept"))
+		assert.Contains(t, w.Body.String(), "UNSUPPORTED_MEDIA_TYPE")
+		assert.Zero(t, handler.calls)
+	})
+
+	t.Run("body without a content type is rejected", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/users/7/avatar", strings.NewReader(`title=me`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("multipart body is accepted", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		require.NoError(t, writer.WriteField("title", "me"))
+		require.NoError(t, writer.Close())
+
+		r := httptest.NewRequest(http.MethodPut, "/users/7/avatar", &body)
+		r.Header.Set("Content-Type", writer.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"title": "me"}`, w.Body.String())
+	})
+}
+
+func TestCheckConsumes(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		consumes    []string
+		allowed     bool
+	}{
+		{name: "exact match with parameters", contentType: "application/json; charset=utf-8", body: "{}", consumes: []string{"application/json"}, allowed: true},
+		{name: "media range", contentType: "image/png", body: "png", consumes: []string{"image/*"}, allowed: true},
+		{name: "case insensitive", contentType: "Application/JSON", body: "{}", consumes: []string{"application/json"}, allowed: true},
+		{name: "mismatch", contentType: "text/plain", body: "hi", consumes: []string{"application/json", "image/*"}},
+		{name: "no body", consumes: []string{"application/json"}, allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.body == "" {
+				r = httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+			}
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			err := checkConsumes(r, tt.consumes)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				var httpErr *HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, http.StatusUnsupportedMediaType, httpErr.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+)
+
+// ContextEnricher derives the request context from the request, typically by adding
+// per-request values such as a tenant ID or feature flags with context.WithValue.
+// Returning nil keeps the current context.
+//
+// Enrichers run inside the handler's middleware chain, so values set by middleware
+// such as authentication are already in r.Context(). They run immediately before the
+// request is decoded, router-level enrichers first, and the resulting context is the one
+// passed to decoders, typed middleware and the handler.
+type ContextEnricher func(r *http.Request) context.Context
+
+// WithContextEnricher adds a context enricher to the handler.
+func WithContextEnricher(enricher ContextEnricher) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.ContextEnrichers = append(cfg.ContextEnrichers, enricher)
+	}
+}
+
+// WithContextEnrichers adds context enrichers to every route of the router.
+func WithContextEnrichers(enrichers ...ContextEnricher) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.ContextEnrichers = append(cfg.ContextEnrichers, enrichers...)
+	}
+}
+
+// enrichContext applies the enrichers in order.
+func enrichContext(r *http.Request, enrichers []ContextEnricher) *http.Request {
+	for _, enrich := range enrichers {
+		if ctx := enrich(r); ctx != nil {
+			r = r.WithContext(ctx)
+		}
+	}
+
+	return r
+}
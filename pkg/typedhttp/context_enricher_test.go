@@ -0,0 +1,82 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+type userKey struct{}
+
+type regionKey struct{}
+
+type tenantRequest struct {
+	ID string `path:"id"`
+}
+
+type tenantResponse struct {
+	Tenant string `json:"tenant"`
+	User   string `json:"user,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+type tenantHandler struct{}
+
+func (h *tenantHandler) Handle(ctx context.Context, _ tenantRequest) (tenantResponse, error) {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	user, _ := ctx.Value(userKey{}).(string)
+	region, _ := ctx.Value(regionKey{}).(string)
+
+	return tenantResponse{Tenant: tenant, User: user, Region: region}, nil
+}
+
+func tenantFromHeader(r *http.Request) context.Context {
+	return context.WithValue(r.Context(), tenantKey{}, r.Header.Get("X-Tenant-ID"))
+}
+
+func TestWithContextEnricher_InjectsTenant(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/accounts/{id}", &tenantHandler{}, WithContextEnricher(tenantFromHeader))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1", http.NoBody)
+	req.Header.Set("X-Tenant-ID", "acme")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"tenant":"acme"}`, w.Body.String())
+}
+
+func TestWithContextEnricher_RunsAfterMiddleware(t *testing.T) {
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey{}, "alice")))
+		})
+	}
+	tenantFromUser := func(r *http.Request) context.Context {
+		user, _ := r.Context().Value(userKey{}).(string)
+
+		return context.WithValue(r.Context(), tenantKey{}, user+"-tenant")
+	}
+
+	router := NewRouter(WithContextEnrichers(func(r *http.Request) context.Context {
+		return context.WithValue(r.Context(), regionKey{}, "eu")
+	}))
+	GET(router, "/accounts/{id}", &tenantHandler{},
+		WithMiddleware(auth),
+		WithContextEnricher(tenantFromUser),
+		WithContextEnricher(func(*http.Request) context.Context { return nil }),
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/accounts/1", http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"tenant":"alice-tenant","user":"alice","region":"eu"}`, w.Body.String())
+}
@@ -0,0 +1,90 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CookieInspectionRequest struct {
+	SessionID string         `cookie:"session_id"`
+	Cookies   []*http.Cookie `cookie:"*"`
+}
+
+func newCookieInspectionRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/cookies", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "abc123"})
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "light"})
+
+	return req
+}
+
+func assertCapturedCookies(t *testing.T, cookies []*http.Cookie) {
+	t.Helper()
+
+	require.Len(t, cookies, 3)
+	assert.Equal(t, "session_id", cookies[0].Name)
+	assert.Equal(t, "abc123", cookies[0].Value)
+	assert.Equal(t, "theme", cookies[1].Name)
+	assert.Equal(t, "dark", cookies[1].Value)
+	assert.Equal(t, "theme", cookies[2].Name)
+	assert.Equal(t, "light", cookies[2].Value)
+}
+
+func TestCookieDecoder_CatchAll(t *testing.T) {
+	result, err := NewCookieDecoder[CookieInspectionRequest](nil).Decode(newCookieInspectionRequest())
+
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", result.SessionID)
+	assertCapturedCookies(t, result.Cookies)
+}
+
+func TestCookieDecoder_CatchAllWithoutCookies(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/cookies", http.NoBody)
+
+	result, err := NewCookieDecoder[CookieInspectionRequest](nil).Decode(req)
+
+	require.NoError(t, err)
+	assert.Nil(t, result.Cookies)
+}
+
+func TestCookieDecoder_CatchAllRequiresCookieSlice(t *testing.T) {
+	type invalidCatchAll struct {
+		Cookies []string `cookie:"*"`
+	}
+
+	_, err := NewCookieDecoder[invalidCatchAll](nil).Decode(newCookieInspectionRequest())
+
+	var decErr *DecodeError
+	require.True(t, errors.As(err, &decErr))
+	assert.Equal(t, DecodeKindUnsupportedType, decErr.Kind)
+}
+
+type cookieInspectionHandler struct {
+	captured []*http.Cookie
+}
+
+func (h *cookieInspectionHandler) Handle(_ context.Context, req CookieInspectionRequest) (map[string]int, error) {
+	h.captured = req.Cookies
+
+	return map[string]int{"count": len(req.Cookies)}, nil
+}
+
+func TestRouter_CookieCatchAll(t *testing.T) {
+	handler := &cookieInspectionHandler{}
+	router := NewRouter()
+	GET(router, "/cookies", handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCookieInspectionRequest())
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"count":3}`, w.Body.String())
+	assertCapturedCookies(t, handler.captured)
+}
@@ -1,15 +1,17 @@
 package typedhttp
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
-	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// CookieCatchAll is the cookie tag name that captures every request cookie
+// into a []*http.Cookie field.
+const CookieCatchAll = "*"
+
 // CookieDecoder implements RequestDecoder for HTTP cookies.
 type CookieDecoder[T any] struct {
 	validator *validator.Validate
@@ -26,11 +28,16 @@ func NewCookieDecoder[T any](validator *validator.Validate) *CookieDecoder[T] {
 func (d *CookieDecoder[T]) Decode(r *http.Request) (T, error) {
 	var result T
 
-	if err := d.processCookieFields(r, &result); err != nil {
+	decodeErrs, err := d.processCookieFields(r, &result)
+	if err != nil {
+		return result, err
+	}
+
+	if err := normalize(&result); err != nil {
 		return result, err
 	}
 
-	if err := d.validateCookieResult(result); err != nil {
+	if err := d.validateCookieResult(result, decodeErrs); err != nil {
 		return result, err
 	}
 
@@ -38,7 +45,9 @@ func (d *CookieDecoder[T]) Decode(r *http.Request) (T, error) {
 }
 
 // processCookieFields processes all cookie fields using reflection.
-func (d *CookieDecoder[T]) processCookieFields(r *http.Request, result *T) error {
+// Conversion errors are collected so that every field is attempted.
+func (d *CookieDecoder[T]) processCookieFields(r *http.Request, result *T) ([]*DecodeError, error) {
+	var decodeErrs []*DecodeError
 	resultValue := reflect.ValueOf(result).Elem()
 	resultType := resultValue.Type()
 
@@ -55,26 +64,35 @@ func (d *CookieDecoder[T]) processCookieFields(r *http.Request, result *T) error
 			continue
 		}
 
-		if err := d.processCookieField(r, &field, fieldValue, cookieName); err != nil {
-			return err
+		var err error
+		if cookieName == CookieCatchAll {
+			err = setCookieCatchAll(r, &field, fieldValue)
+		} else {
+			err = d.processCookieField(r, &field, fieldValue, cookieName)
+		}
+
+		if err != nil {
+			if err = collectDecodeError(&decodeErrs, err); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return nil
+	return decodeErrs, nil
 }
 
 // processCookieField processes a single cookie field.
 func (d *CookieDecoder[T]) processCookieField(
 	r *http.Request, field *reflect.StructField, fieldValue reflect.Value, cookieName string,
 ) error {
-	cookieValue := d.getCookieValue(r, cookieName, field.Tag.Get("default"))
+	cookieValue := d.getCookieValue(r, fieldNames(field, cookieName), field.Tag.Get("default"))
 	if cookieValue == "" {
 		return nil
 	}
 
 	processedValue, err := d.processCookieValue(field, cookieValue, fieldValue.Type())
 	if err != nil {
-		return fmt.Errorf("failed to process cookie %s: %w", cookieName, err)
+		return newDecodeError(SourceCookie, field.Name, cookieName, "failed to process cookie "+cookieName, err)
 	}
 
 	if processedValue != nil {
@@ -84,18 +102,17 @@ func (d *CookieDecoder[T]) processCookieField(
 	}
 
 	if err := setFieldValueFromString(fieldValue, cookieValue); err != nil {
-		return fmt.Errorf("failed to set cookie field %s: %w", field.Name, err)
+		return newDecodeError(SourceCookie, field.Name, cookieName, "failed to set cookie field "+field.Name, err)
 	}
 
 	return nil
 }
 
-// getCookieValue retrieves a cookie value with default fallback.
-func (d *CookieDecoder[T]) getCookieValue(r *http.Request, cookieName, defaultValue string) string {
-	var cookieValue string
-	if cookie, err := r.Cookie(cookieName); err == nil {
-		cookieValue = cookie.Value
-	}
+// getCookieValue retrieves the first cookie found under names, with default fallback.
+func (d *CookieDecoder[T]) getCookieValue(r *http.Request, names []string, defaultValue string) string {
+	cookieValue := firstValue(names, func(name string) string {
+		return cookieByName(r, name)
+	})
 
 	if cookieValue == "" && defaultValue != "" {
 		cookieValue = handleDefaultValue(defaultValue)
@@ -104,6 +121,15 @@ func (d *CookieDecoder[T]) getCookieValue(r *http.Request, cookieName, defaultVa
 	return cookieValue
 }
 
+// cookieByName returns the value of the named cookie, or "" if it is absent.
+func cookieByName(r *http.Request, name string) string {
+	if cookie, err := r.Cookie(name); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
+
 // processCookieValue applies transformations and formats to cookie values.
 func (d *CookieDecoder[T]) processCookieValue(
 	field *reflect.StructField, cookieValue string, fieldType reflect.Type,
@@ -134,26 +160,14 @@ func (d *CookieDecoder[T]) processCookieValue(
 	return nil, nil
 }
 
-// validateCookieResult validates the final cookie result.
-func (d *CookieDecoder[T]) validateCookieResult(result T) error {
-	if d.validator == nil {
-		return nil
-	}
-
-	if err := d.validator.Struct(result); err != nil {
-		validationErrors := make(map[string]string)
-		var validatorErrs validator.ValidationErrors
-		if errors.As(err, &validatorErrs) {
-			for _, validatorErr := range validatorErrs {
-				field := strings.ToLower(validatorErr.Field())
-				validationErrors[field] = validatorErr.Tag()
-			}
-		}
-
-		return NewValidationError("Cookie validation failed", validationErrors)
+// validateCookieResult validates the final cookie result, reporting conversion errors with it.
+func (d *CookieDecoder[T]) validateCookieResult(result T, decodeErrs []*DecodeError) error {
+	var validationErr error
+	if d.validator != nil {
+		validationErr = d.validator.Struct(result)
 	}
 
-	return nil
+	return aggregateRequestErrors("Cookie validation failed", decodeErrs, validationErr)
 }
 
 // ContentTypes returns the supported content types for cookie decoding.
@@ -161,6 +175,20 @@ func (d *CookieDecoder[T]) ContentTypes() []string {
 	return []string{"*/*"} // Cookies work with any content type
 }
 
+// setCookieCatchAll stores all request cookies into a []*http.Cookie field.
+func setCookieCatchAll(r *http.Request, field *reflect.StructField, fieldValue reflect.Value) error {
+	if fieldValue.Type() != reflect.TypeOf([]*http.Cookie{}) {
+		return newDecodeError(SourceCookie, field.Name, CookieCatchAll, "failed to set cookie field "+field.Name,
+			fmt.Errorf("%w: %s (cookie:\"*\" requires []*http.Cookie)", ErrUnsupportedFieldType, fieldValue.Type()))
+	}
+
+	if cookies := r.Cookies(); len(cookies) > 0 {
+		fieldValue.Set(reflect.ValueOf(cookies))
+	}
+
+	return nil
+}
+
 // GetAllCookies returns all cookies from the request as a map for debugging/inspection.
 func GetAllCookies(r *http.Request) map[string]string {
 	cookies := make(map[string]string)
@@ -0,0 +1,116 @@
+package typedhttp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrInvalidCursor is returned by DecodeCursor for malformed or tampered tokens.
+// The default error mapper answers it with 400 Bad Request.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorSecret holds the key set by SetCursorSecret, nil when cursors are unsigned.
+var cursorSecret atomic.Pointer[[]byte]
+
+// SetCursorSecret signs cursors created by EncodeCursor with an HMAC-SHA256 of the
+// secret, and makes DecodeCursor reject tokens without a valid signature. Pass nil
+// to go back to unsigned cursors. Call it once at startup.
+func SetCursorSecret(secret []byte) {
+	if len(secret) == 0 {
+		cursorSecret.Store(nil)
+		return
+	}
+
+	key := append([]byte(nil), secret...)
+	cursorSecret.Store(&key)
+}
+
+// EncodeCursor encodes pagination state, such as the last seen id and sort key,
+// into an opaque URL-safe token.
+func EncodeCursor(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	if secret := cursorSecret.Load(); secret != nil {
+		token += "." + base64.RawURLEncoding.EncodeToString(signCursor(*secret, token))
+	}
+
+	return token, nil
+}
+
+// DecodeCursor decodes a token created by EncodeCursor into the value pointed to by into.
+func DecodeCursor(token string, into any) error {
+	payload := token
+	if secret := cursorSecret.Load(); secret != nil {
+		var signature string
+		var found bool
+		payload, signature, found = strings.Cut(token, ".")
+		if !found {
+			return fmt.Errorf("%w: missing signature", ErrInvalidCursor)
+		}
+
+		given, err := base64.RawURLEncoding.DecodeString(signature)
+		if err != nil || !hmac.Equal(given, signCursor(*secret, payload)) {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+		}
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if err := json.Unmarshal(data, into); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return nil
+}
+
+// signCursor returns the HMAC of an encoded cursor payload.
+func signCursor(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
+
+// Page is a page of results in cursor pagination. Clients pass Next or Prev back
+// to fetch the adjacent page; an empty token means there is no such page.
+type Page[T any] struct {
+	Items []T    `json:"items"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+}
+
+// NewPage creates a page of items with cursor tokens encoding next and prev.
+// Pass nil for a cursor when there is no adjacent page in that direction.
+func NewPage[T any](items []T, next, prev any) (Page[T], error) {
+	if items == nil {
+		items = []T{}
+	}
+	page := Page[T]{Items: items}
+
+	var err error
+	if next != nil {
+		if page.Next, err = EncodeCursor(next); err != nil {
+			return Page[T]{}, err
+		}
+	}
+	if prev != nil {
+		if page.Prev, err = EncodeCursor(prev); err != nil {
+			return Page[T]{}, err
+		}
+	}
+
+	return page, nil
+}
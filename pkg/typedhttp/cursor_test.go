@@ -0,0 +1,132 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderCursor struct {
+	LastID    int64  `json:"id"`
+	CreatedAt string `json:"created_at"`
+}
+
+func useCursorSecret(t *testing.T, secret string) {
+	t.Helper()
+	SetCursorSecret([]byte(secret))
+	t.Cleanup(func() { SetCursorSecret(nil) })
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	for _, secret := range []string{"", "cursor-secret"} {
+		t.Run("secret="+secret, func(t *testing.T) {
+			useCursorSecret(t, secret)
+			want := orderCursor{LastID: 42, CreatedAt: "2024-03-01T10:00:00Z"}
+
+			token, err := EncodeCursor(want)
+			require.NoError(t, err)
+			assert.NotContains(t, token, "42", "the token is opaque")
+			assert.Equal(t, secret != "", strings.Contains(token, "."), "signed tokens carry a signature")
+
+			var got orderCursor
+			require.NoError(t, DecodeCursor(token, &got))
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestDecodeCursor_RejectsTamperedTokens(t *testing.T) {
+	useCursorSecret(t, "cursor-secret")
+
+	token, err := EncodeCursor(orderCursor{LastID: 42})
+	require.NoError(t, err)
+	payload, signature, _ := strings.Cut(token, ".")
+
+	SetCursorSecret(nil)
+	forged, err := EncodeCursor(orderCursor{LastID: 1})
+	require.NoError(t, err)
+	SetCursorSecret([]byte("cursor-secret"))
+
+	tests := map[string]string{
+		"forged payload":    forged + "." + signature,
+		"missing signature": payload,
+		"altered signature": payload + "." + strings.Repeat("A", len(signature)),
+		"not base64":        "!!!." + signature,
+		"empty":             "",
+	}
+
+	for name, tampered := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got orderCursor
+			assert.ErrorIs(t, DecodeCursor(tampered, &got), ErrInvalidCursor)
+		})
+	}
+}
+
+func TestDecodeCursor_UnsignedMalformed(t *testing.T) {
+	var got orderCursor
+	assert.ErrorIs(t, DecodeCursor("bm90IGpzb24", &got), ErrInvalidCursor, "valid base64 of invalid JSON")
+	assert.ErrorIs(t, DecodeCursor("%%%", &got), ErrInvalidCursor)
+}
+
+type listOrdersRequest struct {
+	Cursor string `query:"cursor"`
+}
+
+type listOrdersHandler struct{}
+
+func (h *listOrdersHandler) Handle(_ context.Context, req listOrdersRequest) (Page[int64], error) {
+	var cursor orderCursor
+	if req.Cursor != "" {
+		if err := DecodeCursor(req.Cursor, &cursor); err != nil {
+			return Page[int64]{}, err
+		}
+	}
+
+	items := []int64{cursor.LastID + 1, cursor.LastID + 2}
+
+	return NewPage(items, orderCursor{LastID: items[1]}, orderCursor{LastID: cursor.LastID})
+}
+
+func TestPage_CursorPagination(t *testing.T) {
+	useCursorSecret(t, "cursor-secret")
+	router := NewRouter()
+	GET(router, "/orders", &listOrdersHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var first Page[int64]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+	assert.Equal(t, []int64{1, 2}, first.Items)
+	require.NotEmpty(t, first.Next)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders?cursor="+first.Next, http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var second Page[int64]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+	assert.Equal(t, []int64{3, 4}, second.Items)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders?cursor=tampered", http.NoBody))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CURSOR")
+}
+
+func TestNewPage_OmitsMissingCursors(t *testing.T) {
+	page, err := NewPage[string](nil, nil, nil)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(page)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[]}`, string(data))
+}
@@ -0,0 +1,123 @@
+package typedhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decodeErrorHeaderRequest struct {
+	Limit int `header:"X-Limit"`
+}
+
+type decodeErrorCookieRequest struct {
+	Remember bool `cookie:"remember"`
+}
+
+type decodeErrorFormRequest struct {
+	Price float64 `form:"price"`
+}
+
+type decodeErrorQueryRequest struct {
+	Page uint `query:"page"`
+}
+
+type decodeErrorCombinedRequest struct {
+	Count int    `header:"X-Count" query:"count"`
+	Name  string `query:"name"`
+}
+
+func TestDecodeError_Header(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("X-Limit", "ten")
+
+	_, err := NewHeaderDecoder[decodeErrorHeaderRequest](validator.New()).Decode(req)
+	require.Error(t, err)
+
+	var decErr *DecodeError
+	require.True(t, errors.As(err, &decErr))
+	assert.Equal(t, SourceHeader, decErr.Source)
+	assert.Equal(t, "Limit", decErr.Field)
+	assert.Equal(t, "X-Limit", decErr.Param)
+	assert.Equal(t, DecodeKindInvalidInteger, decErr.Kind)
+	assert.ErrorIs(t, err, ErrInvalidIntegerValue)
+	assert.Equal(t, "failed to set header field Limit: invalid integer value: ten", err.Error())
+}
+
+func TestDecodeError_Cookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: "remember", Value: "maybe"})
+
+	_, err := NewCookieDecoder[decodeErrorCookieRequest](validator.New()).Decode(req)
+
+	var decErr *DecodeError
+	require.True(t, errors.As(err, &decErr))
+	assert.Equal(t, SourceCookie, decErr.Source)
+	assert.Equal(t, "remember", decErr.Param)
+	assert.Equal(t, DecodeKindInvalidBoolean, decErr.Kind)
+	assert.Equal(t, "failed to set cookie field Remember: invalid boolean value: maybe", err.Error())
+}
+
+func TestDecodeError_Form(t *testing.T) {
+	form := url.Values{"price": {"cheap"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	_, err := NewFormDecoder[decodeErrorFormRequest](validator.New()).Decode(req)
+
+	var decErr *DecodeError
+	require.True(t, errors.As(err, &decErr))
+	assert.Equal(t, SourceForm, decErr.Source)
+	assert.Equal(t, "Price", decErr.Field)
+	assert.Equal(t, "price", decErr.Param)
+	assert.Equal(t, DecodeKindInvalidFloat, decErr.Kind)
+}
+
+func TestDecodeError_Query(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?page=-1", http.NoBody)
+
+	_, err := NewQueryDecoder[decodeErrorQueryRequest](validator.New()).Decode(req)
+
+	var decErr *DecodeError
+	require.True(t, errors.As(err, &decErr))
+	assert.Equal(t, SourceQuery, decErr.Source)
+	assert.Equal(t, "page", decErr.Param)
+	assert.Equal(t, DecodeKindInvalidUinteger, decErr.Kind)
+	assert.Equal(t, "failed to set field Page: invalid unsigned integer value: -1", err.Error())
+}
+
+func TestDecodeError_CombinedDecoderReportsWinningSource(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?count=abc&name=x", http.NoBody)
+
+	_, err := NewCombinedDecoder[decodeErrorCombinedRequest](validator.New()).Decode(req)
+
+	var decErr *DecodeError
+	require.True(t, errors.As(err, &decErr))
+	assert.Equal(t, SourceQuery, decErr.Source)
+	assert.Equal(t, "count", decErr.Param)
+	assert.Equal(t, DecodeKindInvalidInteger, decErr.Kind)
+}
+
+func TestDefaultErrorMapper_DecodeError(t *testing.T) {
+	err := newDecodeError(SourceHeader, "Limit", "X-Limit", "failed to set header field Limit",
+		ErrInvalidIntegerValue)
+
+	statusCode, response := (&DefaultErrorMapper{}).MapError(err)
+
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	errorResp, ok := response.(ErrorResponse)
+	require.True(t, ok)
+	assert.Equal(t, "DECODE_ERROR", errorResp.Code)
+	assert.Equal(t, map[string]string{
+		"source": "header",
+		"param":  "X-Limit",
+		"kind":   "invalid_integer",
+	}, errorResp.Details)
+}
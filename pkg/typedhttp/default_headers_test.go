@@ -0,0 +1,67 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDefaultResponseHeaders(t *testing.T) {
+	router := NewRouter(WithDefaultResponseHeaders(map[string]string{
+		"x-api-version": "v1",
+		"Cache-Control": "no-store",
+	}))
+	GET(router, "/items/{id}", &metricsTestHandler{})
+	GET(router, "/limited/{id}", &metricsTestHandler{
+		err: NewHTTPError(http.StatusTooManyRequests, "RATE_LIMITED", "slow down").WithHeader("Cache-Control", "max-age=5"),
+	})
+	GET(router, "/cached/{id}", &metricsTestHandler{}, WithMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			next.ServeHTTP(w, r)
+		})
+	}))
+
+	t.Run("applied to normal responses", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/42", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "v1", rr.Header().Get("X-Api-Version"))
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	})
+
+	t.Run("route overrides", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/cached/42", nil))
+
+		assert.Equal(t, []string{"max-age=60"}, rr.Header().Values("Cache-Control"))
+		assert.Equal(t, "v1", rr.Header().Get("X-Api-Version"))
+	})
+
+	t.Run("applied to unmatched routes", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "v1", rr.Header().Get("X-Api-Version"))
+	})
+
+	t.Run("outer middleware wins", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("X-Api-Version", "v2")
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/items/42", nil))
+
+		assert.Equal(t, "v2", rr.Header().Get("X-Api-Version"))
+	})
+
+	t.Run("error headers override", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/limited/1", nil))
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, []string{"max-age=5"}, rr.Header().Values("Cache-Control"))
+	})
+}
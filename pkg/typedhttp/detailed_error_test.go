@@ -0,0 +1,67 @@
+package typedhttp
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsupportedPlanError is a business error that lists the accepted values.
+type unsupportedPlanError struct {
+	plan string
+}
+
+func (e *unsupportedPlanError) Error() string {
+	return "unsupported plan " + e.plan
+}
+
+func (e *unsupportedPlanError) Details() map[string]any {
+	return map[string]any{"field": "plan", "allowed_values": []string{"free", "pro"}}
+}
+
+func TestDetailedError_UnknownTypeHidesDetails(t *testing.T) {
+	w := serveError(t, &unsupportedPlanError{plan: "gold"})
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"Internal server error","code":"INTERNAL_ERROR"}`, w.Body.String())
+}
+
+func TestWithDetails_PlainErrorStaysInternal(t *testing.T) {
+	w := serveError(t, WithDetails(errors.New("db down"), map[string]any{"retry": true}))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "db down")
+	assert.JSONEq(t, `{"error":"Internal server error","code":"INTERNAL_ERROR"}`, w.Body.String())
+}
+
+func TestWithDetails_ServerErrorHidesDetails(t *testing.T) {
+	err := WithDetails(NewHTTPError(http.StatusServiceUnavailable, "MAINTENANCE", "down for maintenance"), map[string]any{"host": "db-1"})
+
+	w := serveError(t, err)
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotContains(t, w.Body.String(), "db-1")
+}
+
+func TestWithDetails_KeepsStatusAndCode(t *testing.T) {
+	err := WithDetails(NewConflictError("plan already active"), map[string]any{"allowed_values": []string{"pro"}})
+
+	w := serveError(t, err)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{
+		"error": "plan already active",
+		"code": "CONFLICT",
+		"details": {"allowed_values": ["pro"]}
+	}`, w.Body.String())
+}
+
+func TestDetailedError_PlainErrorsHaveNoDetails(t *testing.T) {
+	w := serveError(t, errors.New("database unavailable"))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"Internal server error","code":"INTERNAL_ERROR"}`, w.Body.String())
+}
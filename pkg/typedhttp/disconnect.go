@@ -0,0 +1,23 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// clientGone reports whether the client of r has disconnected, in which case no response
+// should be written: the connection is dead and writing to it only produces errors. Only
+// cancellation counts. An expired deadline, e.g. from WithTimeBudget, still leaves a
+// client waiting for the timeout response.
+func clientGone(r *http.Request) bool {
+	if !errors.Is(r.Context().Err(), context.Canceled) {
+		return false
+	}
+
+	slog.DebugContext(r.Context(), "client disconnected, skipping response",
+		"method", r.Method, "path", r.URL.Path)
+
+	return true
+}
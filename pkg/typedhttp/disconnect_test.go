@@ -0,0 +1,116 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type slowLookupRequest struct {
+	ID string `path:"id"`
+}
+
+type slowLookupResponse struct {
+	ID string `json:"id"`
+}
+
+// slowLookupHandler simulates work during which the client goes away.
+type slowLookupHandler struct {
+	cancel func()
+	err    error
+}
+
+func (h *slowLookupHandler) Handle(_ context.Context, req slowLookupRequest) (slowLookupResponse, error) {
+	if h.cancel != nil {
+		h.cancel()
+	}
+	if h.err != nil {
+		return slowLookupResponse{}, h.err
+	}
+
+	return slowLookupResponse{ID: req.ID}, nil
+}
+
+// failingWriter fails every write, like a connection the client has closed.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+
+	return 0, errors.New("write: broken pipe")
+}
+
+func (w *failingWriter) WriteHeader(int) {
+	w.writes++
+}
+
+func TestClientDisconnect(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(cancel func()) *slowLookupHandler
+	}{
+		{
+			name: "response after disconnect",
+			handler: func(cancel func()) *slowLookupHandler {
+				return &slowLookupHandler{cancel: cancel}
+			},
+		},
+		{
+			name: "handler returns the cancellation error",
+			handler: func(cancel func()) *slowLookupHandler {
+				return &slowLookupHandler{cancel: cancel, err: context.Canceled}
+			},
+		},
+		{
+			name: "disconnected before decoding",
+			handler: func(func()) *slowLookupHandler {
+				return &slowLookupHandler{}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			handler := tt.handler(cancel)
+			if handler.cancel == nil {
+				cancel()
+			}
+
+			router := NewRouter()
+			GET(router, "/items/{id}", handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/42", nil).WithContext(ctx)
+			w := &failingWriter{ResponseRecorder: httptest.NewRecorder()}
+
+			require.NotPanics(t, func() { router.ServeHTTP(w, req) })
+			assert.Zero(t, w.writes)
+		})
+	}
+}
+
+func TestClientDisconnect_DeadlineStillAnswered(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &slowLookupHandler{err: context.DeadlineExceeded})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.GreaterOrEqual(t, w.Code, http.StatusBadRequest)
+	assert.NotEmpty(t, strings.TrimSpace(w.Body.String()))
+}
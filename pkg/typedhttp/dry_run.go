@@ -0,0 +1,51 @@
+package typedhttp
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// DryRunResponse is the body of a dry run: the request as it would have reached Handle.
+type DryRunResponse struct {
+	Valid   bool        `json:"valid"`
+	Request interface{} `json:"request"`
+}
+
+// WithDryRun lets clients check their input without committing it. When the query
+// parameter param is present and truthy, e.g. ?dry_run=true or a bare ?dry_run, the
+// request is decoded, validated and passed through typed request middleware, then
+// answered with 200 and a DryRunResponse instead of calling Handle. Invalid input gets
+// the usual error response.
+//
+// Only Handle is skipped. Middleware, context enrichers and typed request middleware
+// still run, so dry runs are side-effect free only for handlers that keep their side
+// effects in Handle. The echoed request is encoded like a response body; tag fields
+// that must not be echoed, such as credentials, with json:"-".
+func WithDryRun(param string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.DryRunParam = param
+	}
+}
+
+// isDryRun reports whether the dry run flag param is set on r.
+func isDryRun(r *http.Request, param string) bool {
+	values, ok := r.URL.Query()[param]
+	if !ok {
+		return false
+	}
+	if len(values) == 0 || values[0] == "" {
+		return true
+	}
+
+	dryRun, err := strconv.ParseBool(values[0])
+
+	return err == nil && dryRun
+}
+
+// writeDryRun answers a dry run with the validated request.
+func (h *HTTPHandler[TRequest, TResponse]) writeDryRun(w http.ResponseWriter, r *http.Request, req TRequest) {
+	encoder := NewJSONEncoder[DryRunResponse]()
+	if err := encoder.Encode(w, DryRunResponse{Valid: true, Request: req}, http.StatusOK); err != nil {
+		h.handleError(w, r, err)
+	}
+}
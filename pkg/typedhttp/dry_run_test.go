@@ -0,0 +1,104 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registrationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+func (r *registrationRequest) Normalize() error {
+	r.Email = strings.ToLower(r.Email)
+
+	return nil
+}
+
+type registrationResponse struct {
+	ID string `json:"id"`
+}
+
+type registrationHandler struct {
+	calls int
+}
+
+func (h *registrationHandler) Handle(_ context.Context, _ registrationRequest) (registrationResponse, error) {
+	h.calls++
+
+	return registrationResponse{ID: "u-1"}, nil
+}
+
+func TestWithDryRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		body     string
+		status   int
+		response string
+		calls    int
+	}{
+		{
+			name:     "dry run returns the validated request",
+			target:   "/signups?dry_run=true",
+			body:     `{"email": "Jane@Example.com", "name": "Jane"}`,
+			status:   http.StatusOK,
+			response: `{"valid": true, "request": {"email": "jane@example.com", "name": "Jane"}}`,
+		},
+		{
+			name:     "bare flag",
+			target:   "/signups?dry_run",
+			body:     `{"email": "jane@example.com", "name": "Jane"}`,
+			status:   http.StatusOK,
+			response: `{"valid": true, "request": {"email": "jane@example.com", "name": "Jane"}}`,
+		},
+		{
+			name:   "dry run reports validation failures",
+			target: "/signups?dry_run=1",
+			body:   `{"email": "not-an-email"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:     "false flag executes the handler",
+			target:   "/signups?dry_run=false",
+			body:     `{"email": "jane@example.com", "name": "Jane"}`,
+			status:   http.StatusCreated,
+			response: `{"id": "u-1"}`,
+			calls:    1,
+		},
+		{
+			name:     "without the flag the handler runs",
+			target:   "/signups",
+			body:     `{"email": "jane@example.com", "name": "Jane"}`,
+			status:   http.StatusCreated,
+			response: `{"id": "u-1"}`,
+			calls:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &registrationHandler{}
+			router := NewRouter()
+			POST(router, "/signups", handler, WithDryRun("dry_run"))
+
+			r := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.response != "" {
+				assert.JSONEq(t, tt.response, w.Body.String())
+			}
+			assert.Equal(t, tt.calls, handler.calls)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package typedhttp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// RegisterDynamic registers a handler whose request and response types are only known
+// at runtime, such as one loaded from a plugin. The route is listed by GetHandlers with
+// requestType and responseType, so OpenAPI generation and stub servers treat it like a
+// typed route.
+//
+// The router cannot check that handler actually decodes requestType or writes
+// responseType; the documented contract is only as accurate as the types passed in.
+// Decoding, validation, encoding and error mapping are the handler's job, so options
+// that configure them (WithDecoder, WithBodyCodecs, response interceptors, typed
+// middleware) have no effect here, and router-wide interceptors and enrichers are not
+// applied. WithMiddleware, documentation and observability options work as usual, and
+// panics are recovered like in typed routes, mapped by WithErrorMapper if given,
+// unless the router uses WithoutPanicRecovery.
+// Build handler with NewHTTPHandler inside the plugin to get typed decoding.
+func (r *TypedRouter) RegisterDynamic(
+	method, path string,
+	handler http.Handler,
+	requestType, responseType reflect.Type,
+	opts ...HandlerOption,
+) {
+	if requestType == nil || responseType == nil {
+		panic(fmt.Sprintf("typedhttp: dynamic route %s %s needs request and response types", method, path))
+	}
+
+	config := &HandlerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	for i := len(config.Middleware) - 1; i >= 0; i-- {
+		handler = config.Middleware[i](handler)
+	}
+
+	handler = r.recoverPanics(handler, opts...)
+
+	r.registerHandler(method, path, handler, requestType, responseType, config, dynamicStub(responseType, opts))
+}
+
+// dynamicStub answers stub server requests for a dynamic route without decoding them.
+func dynamicStub(responseType reflect.Type, opts []HandlerOption) func(response interface{}) http.Handler {
+	return func(response interface{}) http.Handler {
+		stub := &stubHandler[struct{}, any]{response: response}
+
+		switch v := response.(type) {
+		case nil:
+			if responseType.Kind() == reflect.Ptr {
+				stub.response = reflect.New(responseType.Elem()).Interface()
+			} else {
+				stub.response = reflect.Zero(responseType).Interface()
+			}
+		case error:
+			stub.response, stub.err = nil, v
+		}
+
+		return NewHTTPHandler[struct{}, any](stub, opts...)
+	}
+}
@@ -0,0 +1,109 @@
+package typedhttp
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pluginGreetingRequest struct {
+	Name string `path:"name"`
+}
+
+type pluginGreetingResponse struct {
+	Message string `json:"message"`
+}
+
+// pluginGreetingHandler stands in for a handler loaded from a plugin.
+func pluginGreetingHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pluginGreetingResponse{Message: "hello " + r.PathValue("name")})
+	})
+}
+
+func TestRegisterDynamic(t *testing.T) {
+	tagged := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Plugin", HandlerNameFromContext(r.Context()))
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	router := NewRouter()
+	router.RegisterDynamic(http.MethodGet, "/greetings/{name}", pluginGreetingHandler(),
+		reflect.TypeOf(pluginGreetingRequest{}), reflect.TypeOf(pluginGreetingResponse{}),
+		WithMiddleware(tagged), WithHandlerName("plugins.greet"), WithTags("plugins"),
+	)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/greetings/ada", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"message":"hello ada"}`, rr.Body.String())
+	assert.Equal(t, "plugins.greet", rr.Header().Get("X-Plugin"))
+
+	handlers := router.GetHandlers()
+	require.Len(t, handlers, 1)
+	assert.Equal(t, reflect.TypeOf(pluginGreetingRequest{}), handlers[0].RequestType)
+	assert.Equal(t, reflect.TypeOf(pluginGreetingResponse{}), handlers[0].ResponseType)
+	assert.Equal(t, []string{"plugins"}, handlers[0].Metadata.Tags)
+}
+
+func TestRegisterDynamic_StubServer(t *testing.T) {
+	router := NewRouter()
+	router.RegisterDynamic(http.MethodGet, "/greetings/{name}", pluginGreetingHandler(),
+		reflect.TypeOf(pluginGreetingRequest{}), reflect.TypeOf(pluginGreetingResponse{}))
+
+	stub := NewStubServer(router, WithStubResponse(http.MethodGet, "/greetings/{name}",
+		pluginGreetingResponse{Message: "stubbed"}))
+
+	rr := httptest.NewRecorder()
+	stub.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/greetings/ada", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"message":"stubbed"}`, rr.Body.String())
+}
+
+func TestRegisterDynamic_RequiresTypes(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRouter().RegisterDynamic(http.MethodGet, "/x", pluginGreetingHandler(), nil, nil)
+	})
+}
+
+func TestRegisterDynamic_RecoversPanics(t *testing.T) {
+	panicking := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("plugin failed")
+	})
+
+	router := NewRouter(WithPanicLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	router.RegisterDynamic(http.MethodGet, "/greetings/{name}", panicking,
+		reflect.TypeOf(pluginGreetingRequest{}), reflect.TypeOf(pluginGreetingResponse{}))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/greetings/ada", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.JSONEq(t, `{"error":"Internal server error","code":"INTERNAL_ERROR"}`, rr.Body.String())
+}
+
+func TestRegisterDynamic_WithoutPanicRecovery(t *testing.T) {
+	panicking := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("plugin failed")
+	})
+
+	router := NewRouter(WithoutPanicRecovery())
+	router.RegisterDynamic(http.MethodGet, "/greetings/{name}", panicking,
+		reflect.TypeOf(pluginGreetingRequest{}), reflect.TypeOf(pluginGreetingResponse{}))
+
+	assert.PanicsWithValue(t, "plugin failed", func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/greetings/ada", nil))
+	})
+}
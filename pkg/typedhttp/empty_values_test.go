@@ -0,0 +1,73 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Empty query and form values are treated as missing: defaults apply and
+// required validation fails, exactly as if the parameter had been omitted.
+
+type EmptyQueryRequest struct {
+	Page int      `query:"page" default:"1"`
+	Sort string   `query:"sort" default:"created_at"`
+	Name string   `query:"name" validate:"required"`
+	Tags []string `query:"tags"`
+}
+
+type EmptyFormRequest struct {
+	Title    string `form:"title" validate:"required"`
+	Category string `form:"category" default:"general"`
+}
+
+func TestEmptyValues_QueryDefaultsApply(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?page=&sort=&name=widget&tags=", http.NoBody)
+
+	for name, decoder := range map[string]RequestDecoder[EmptyQueryRequest]{
+		"query":    NewQueryDecoder[EmptyQueryRequest](validator.New()),
+		"combined": NewCombinedDecoder[EmptyQueryRequest](validator.New()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := decoder.Decode(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, result.Page)
+			assert.Equal(t, "created_at", result.Sort)
+			assert.Nil(t, result.Tags, "an empty value leaves a slice nil")
+		})
+	}
+}
+
+func TestEmptyValues_QueryRequiredFails(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?name=", http.NoBody)
+
+	_, err := NewCombinedDecoder[EmptyQueryRequest](validator.New()).Decode(req)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, "required", valErr.Fields["name"])
+}
+
+func TestEmptyValues_Form(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return req
+	}
+
+	result, err := NewFormDecoder[EmptyFormRequest](validator.New()).Decode(newRequest("title=Lamp&category="))
+	require.NoError(t, err)
+	assert.Equal(t, "general", result.Category)
+
+	_, err = NewFormDecoder[EmptyFormRequest](validator.New()).Decode(newRequest("title=&category=home"))
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, "required", valErr.Fields["title"])
+}
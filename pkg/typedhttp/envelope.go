@@ -118,6 +118,20 @@ func (m *ResponseEnvelopeMiddleware[TResponse]) After(ctx context.Context, resp
 	return envelope, nil
 }
 
+// ResponseHeaders implements ResponseHeaderModifier. When request IDs are included, the
+// X-Request-ID header carrying the same identifier as meta.request_id is documented.
+func (m *ResponseEnvelopeMiddleware[TResponse]) ResponseHeaders() []ResponseHeaderSpec {
+	if !m.includeMeta || !m.includeRequestID {
+		return nil
+	}
+
+	return []ResponseHeaderSpec{{
+		Name:        "X-Request-ID",
+		Schema:      openapi3.NewStringSchema(),
+		Description: "Identifier of the request, also returned as meta.request_id",
+	}}
+}
+
 // ModifyResponseSchema implements ResponseSchemaModifier, transforming the OpenAPI schema
 // to reflect the envelope structure that will be returned to clients.
 func (m *ResponseEnvelopeMiddleware[TResponse]) ModifyResponseSchema(ctx context.Context, originalSchema *openapi3.SchemaRef) (*openapi3.SchemaRef, error) {
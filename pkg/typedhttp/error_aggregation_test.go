@@ -0,0 +1,90 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SignupForm struct {
+	Name   string `form:"name" validate:"required"`
+	Email  string `form:"email" validate:"required,email"`
+	Age    int    `form:"age" validate:"required,min=18"`
+	Active bool   `form:"active"`
+}
+
+type signupHandler struct{}
+
+func (h *signupHandler) Handle(_ context.Context, req SignupForm) (SignupForm, error) {
+	return req, nil
+}
+
+func newSignupRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return req
+}
+
+func TestErrorAggregation_FormReportsAllFields(t *testing.T) {
+	decoders := map[string]RequestDecoder[SignupForm]{
+		"form":     NewFormDecoder[SignupForm](validator.New()),
+		"combined": NewCombinedDecoder[SignupForm](validator.New()),
+	}
+
+	for name, decoder := range decoders {
+		t.Run(name, func(t *testing.T) {
+			_, err := decoder.Decode(newSignupRequest("name=Jane&email=not-an-email&age=old&active=maybe"))
+
+			var valErr *ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, map[string]string{
+				"email":  "email",
+				"age":    string(DecodeKindInvalidInteger),
+				"active": string(DecodeKindInvalidBoolean),
+			}, valErr.Fields)
+			assert.Len(t, valErr.DecodeErrors, 2)
+
+			var decErr *DecodeError
+			require.ErrorAs(t, err, &decErr)
+			assert.Equal(t, SourceForm, decErr.Source)
+		})
+	}
+}
+
+func TestErrorAggregation_RouterResponse(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/signup", &signupHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newSignupRequest("email=not-an-email&age=old"))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "VALIDATION_ERROR", resp.Code)
+	assert.Equal(t, map[string]interface{}{
+		"name":  "required",
+		"email": "email",
+		"age":   string(DecodeKindInvalidInteger),
+	}, resp.Details)
+}
+
+func TestErrorAggregation_SingleConversionErrorUnchanged(t *testing.T) {
+	_, err := NewFormDecoder[SignupForm](validator.New()).Decode(newSignupRequest("name=Jane&email=jane@example.com&age=old"))
+
+	var decErr *DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Equal(t, "Age", decErr.Field)
+
+	_, aggregated := err.(*ValidationError) //nolint:errorlint // checking the top-level type
+	assert.False(t, aggregated, "a lone conversion error is not wrapped")
+}
@@ -0,0 +1,93 @@
+package typedhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlCodec encodes through JSON so YAML keys follow the json struct tags.
+type yamlCodec struct{}
+
+func (yamlCodec) ContentType() string { return "application/yaml" }
+
+func (yamlCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(generic)
+}
+
+func (yamlCodec) Unmarshal(data []byte, v interface{}) error {
+	return yaml.Unmarshal(data, v)
+}
+
+// failingCodec cannot represent any value.
+type failingCodec struct{}
+
+func (failingCodec) ContentType() string                 { return "application/x-failing" }
+func (failingCodec) Marshal(interface{}) ([]byte, error) { return nil, errors.New("unsupported") }
+func (failingCodec) Unmarshal([]byte, interface{}) error { return errors.New("unsupported") }
+
+func TestHandleError_NegotiatesYAML(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{err: NewNotFoundError("item", "42")}, WithBodyCodecs(yamlCodec{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody)
+	req.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+
+	var body ErrorResponse
+	require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "item with id '42' not found", body.Error)
+}
+
+func TestHandleError_DefaultsToJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		codec  BodyCodec
+		accept string
+	}{
+		{name: "json preferred", codec: yamlCodec{}, accept: "application/json"},
+		{name: "no accept header", codec: yamlCodec{}},
+		{name: "codec cannot encode the error", codec: failingCodec{}, accept: "application/x-failing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+			GET(router, "/items/{id}", &metricsTestHandler{err: NewNotFoundError("item", "42")}, WithBodyCodecs(tt.codec))
+
+			req := httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var body ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.NotEmpty(t, body.Error)
+		})
+	}
+}
@@ -1,24 +1,62 @@
 package typedhttp
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 )
 
 // Common error types that can be used across applications
 
 // ValidationError represents a request validation error.
 type ValidationError struct {
-	Message string            `json:"message"`
-	Fields  map[string]string `json:"fields,omitempty"`
+	Message      string            `json:"message"`
+	Fields       map[string]string `json:"fields,omitempty"`
+	Translations map[string]string `json:"translations,omitempty"` // Localized messages, set by Translate
+	// DecodeErrors holds the values that could not be converted, reported together with
+	// the validation failures of the remaining fields.
+	DecodeErrors []*DecodeError `json:"-"`
+
+	validatorErrs validator.ValidationErrors
 }
 
 func (e *ValidationError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the aggregated conversion errors, so errors.As finds a DecodeError.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.DecodeErrors))
+	for i, decErr := range e.DecodeErrors {
+		errs[i] = decErr
+	}
+
+	return errs
+}
+
+// Translate fills Translations with localized messages for each failed field.
+// It is a no-op for errors that were not produced by the validator.
+func (e *ValidationError) Translate(trans ut.Translator) {
+	if len(e.validatorErrs) == 0 || trans == nil {
+		return
+	}
+
+	e.Translations = make(map[string]string, len(e.validatorErrs)+len(e.DecodeErrors))
+	for _, validatorErr := range e.validatorErrs {
+		e.Translations[strings.ToLower(validatorErr.Field())] = validatorErr.Translate(trans)
+	}
+
+	// Conversion errors have no translations; keep them so no field goes missing
+	for _, decErr := range e.DecodeErrors {
+		e.Translations[strings.ToLower(decErr.Field)] = string(decErr.Kind)
+	}
+}
+
 // NewValidationError creates a new validation error.
 func NewValidationError(message string, fields map[string]string) *ValidationError {
 	return &ValidationError{
@@ -27,6 +65,179 @@ func NewValidationError(message string, fields map[string]string) *ValidationErr
 	}
 }
 
+// newValidationErrorFromValidator converts validator errors into a ValidationError,
+// keeping the original errors so they can be translated later.
+func newValidationErrorFromValidator(message string, err error) *ValidationError {
+	fields := make(map[string]string)
+
+	var validatorErrs validator.ValidationErrors
+	if errors.As(err, &validatorErrs) {
+		for _, validatorErr := range validatorErrs {
+			fields[strings.ToLower(validatorErr.Field())] = validatorErr.Tag()
+		}
+	}
+
+	validationErr := NewValidationError(message, fields)
+	validationErr.validatorErrs = validatorErrs
+
+	return validationErr
+}
+
+// collectDecodeError records err if it is a DecodeError so that binding can continue
+// with the next field. Any other error is returned unchanged.
+func collectDecodeError(decodeErrs *[]*DecodeError, err error) error {
+	var decErr *DecodeError
+	if errors.As(err, &decErr) {
+		*decodeErrs = append(*decodeErrs, decErr)
+
+		return nil
+	}
+
+	return err
+}
+
+// aggregateRequestErrors reports conversion and validation failures together.
+// A single conversion error with no other failure is returned as is. Otherwise the
+// result is a ValidationError listing every failed field; validation failures of
+// fields that could not be converted are dropped, as they only restate the problem.
+func aggregateRequestErrors(message string, decodeErrs []*DecodeError, validationErr error) error {
+	if len(decodeErrs) == 0 {
+		if validationErr == nil {
+			return nil
+		}
+
+		return newValidationErrorFromValidator(message, validationErr)
+	}
+
+	unconverted := make(map[string]bool, len(decodeErrs))
+	for _, decErr := range decodeErrs {
+		unconverted[decErr.Field] = true
+	}
+
+	var validatorErrs, remaining validator.ValidationErrors
+	errors.As(validationErr, &validatorErrs)
+	for _, validatorErr := range validatorErrs {
+		if !unconverted[validatorErr.StructField()] {
+			remaining = append(remaining, validatorErr)
+		}
+	}
+
+	if len(decodeErrs) == 1 && len(remaining) == 0 {
+		return decodeErrs[0]
+	}
+
+	aggregated := newValidationErrorFromValidator(message, remaining)
+	for _, decErr := range decodeErrs {
+		aggregated.Fields[strings.ToLower(decErr.Field)] = string(decErr.Kind)
+	}
+	aggregated.DecodeErrors = decodeErrs
+
+	return aggregated
+}
+
+// DecodeErrorKind classifies why a request value could not be decoded.
+type DecodeErrorKind string
+
+const (
+	DecodeKindInvalidInteger  DecodeErrorKind = "invalid_integer"
+	DecodeKindInvalidUinteger DecodeErrorKind = "invalid_unsigned_integer"
+	DecodeKindInvalidFloat    DecodeErrorKind = "invalid_float"
+	DecodeKindInvalidBoolean  DecodeErrorKind = "invalid_boolean"
+	DecodeKindOutOfRange      DecodeErrorKind = "out_of_range"
+	DecodeKindInvalidIP       DecodeErrorKind = "invalid_ip"
+	DecodeKindInvalidTime     DecodeErrorKind = "invalid_time"
+	DecodeKindInvalidUUID     DecodeErrorKind = "invalid_uuid"
+	DecodeKindUnsupportedType DecodeErrorKind = "unsupported_type"
+	DecodeKindTransform       DecodeErrorKind = "transform"
+	DecodeKindFormat          DecodeErrorKind = "format"
+	DecodeKindInvalid         DecodeErrorKind = "invalid"
+)
+
+// JSONError represents a malformed JSON request body: invalid syntax, a truncated
+// document, or a value of the wrong type for its field.
+type JSONError struct {
+	Offset  int64  // Byte offset in the body where decoding stopped
+	Field   string // Dotted path of the mistyped field, empty for syntax errors
+	Message string // Human-readable description including the offset
+	Cause   error  // Underlying encoding/json error
+}
+
+func (e *JSONError) Error() string {
+	return "invalid JSON: " + e.Message
+}
+
+// Unwrap returns the underlying encoding/json error.
+func (e *JSONError) Unwrap() error {
+	return e.Cause
+}
+
+// DecodeError represents a failure to convert a raw request value before validation.
+// The message keeps the decoder's historic text; use errors.As to inspect the fields.
+type DecodeError struct {
+	Source SourceType      // Where the value came from (path, header, cookie, form, query, json)
+	Field  string          // Go struct field name, or the dotted field path for JSON
+	Param  string          // Path, header, cookie, form or query parameter name, or the JSON field path
+	Kind   DecodeErrorKind // Classification derived from Cause
+	Cause  error           // Underlying conversion error
+
+	message string
+}
+
+func (e *DecodeError) Error() string {
+	if e.message == "" {
+		return e.Cause.Error()
+	}
+
+	return e.message + ": " + e.Cause.Error()
+}
+
+// Unwrap returns the underlying conversion error.
+func (e *DecodeError) Unwrap() error {
+	return e.Cause
+}
+
+// newDecodeError creates a DecodeError whose message is prefixed with message.
+func newDecodeError(source SourceType, field, param, message string, cause error) *DecodeError {
+	return &DecodeError{
+		Source:  source,
+		Field:   field,
+		Param:   param,
+		Kind:    decodeErrorKind(cause),
+		Cause:   cause,
+		message: message,
+	}
+}
+
+// decodeErrorKind classifies a conversion error by its sentinel.
+func decodeErrorKind(err error) DecodeErrorKind {
+	switch {
+	case errors.Is(err, ErrInvalidIntegerValue):
+		return DecodeKindInvalidInteger
+	case errors.Is(err, ErrInvalidUintegerValue):
+		return DecodeKindInvalidUinteger
+	case errors.Is(err, ErrIntegerOutOfRange):
+		return DecodeKindOutOfRange
+	case errors.Is(err, ErrInvalidFloatValue):
+		return DecodeKindInvalidFloat
+	case errors.Is(err, ErrInvalidBooleanValue):
+		return DecodeKindInvalidBoolean
+	case errors.Is(err, ErrInvalidIPAddress):
+		return DecodeKindInvalidIP
+	case errors.Is(err, ErrInvalidTimeValue), errors.Is(err, ErrInvalidUnixTimestamp):
+		return DecodeKindInvalidTime
+	case errors.Is(err, ErrInvalidUUID):
+		return DecodeKindInvalidUUID
+	case errors.Is(err, ErrUnsupportedFieldType):
+		return DecodeKindUnsupportedType
+	case errors.Is(err, ErrUnknownTransformation):
+		return DecodeKindTransform
+	case errors.Is(err, ErrFormatNotSupported):
+		return DecodeKindFormat
+	default:
+		return DecodeKindInvalid
+	}
+}
+
 // NotFoundError represents a resource not found error.
 type NotFoundError struct {
 	Resource string `json:"resource"`
@@ -99,6 +310,58 @@ func NewForbiddenError(message string) *ForbiddenError {
 	}
 }
 
+// HTTPError is an error with an explicit HTTP status, error code and response headers,
+// for statuses that have no dedicated error type such as 429 Too Many Requests.
+type HTTPError struct {
+	StatusCode int         `json:"-"`
+	Code       string      `json:"code,omitempty"`
+	Message    string      `json:"message"`
+	Headers    http.Header `json:"-"`
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return http.StatusText(e.StatusCode)
+}
+
+// WithHeader adds a response header, e.g. Retry-After or WWW-Authenticate, and returns e.
+func (e *HTTPError) WithHeader(key, value string) *HTTPError {
+	if e.Headers == nil {
+		e.Headers = make(http.Header)
+	}
+	e.Headers.Add(key, value)
+
+	return e
+}
+
+// NewHTTPError creates an error that is sent with the given status, code and message.
+func NewHTTPError(status int, code, message string) *HTTPError {
+	return &HTTPError{
+		StatusCode: status,
+		Code:       code,
+		Message:    message,
+	}
+}
+
+// writeErrorHeaders copies the headers of an HTTPError in err's chain to the response.
+func writeErrorHeaders(header http.Header, err error) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return
+	}
+
+	// Error headers replace router defaults and middleware values for the same key
+	for key, values := range httpErr.Headers {
+		header.Del(key)
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+}
+
 // ErrorResponse represents a standardized error response.
 type ErrorResponse struct {
 	Error     string      `json:"error"`
@@ -107,17 +370,115 @@ type ErrorResponse struct {
 	RequestID string      `json:"request_id,omitempty"`
 }
 
+// DetailedError is an error carrying machine-readable details, such as the values a
+// field accepts. Error mappers put them in the details field of the error response.
+type DetailedError interface {
+	error
+	Details() map[string]any
+}
+
+// detailedError attaches details to a wrapped error.
+type detailedError struct {
+	err     error
+	details map[string]any
+}
+
+func (e *detailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *detailedError) Unwrap() error {
+	return e.err
+}
+
+func (e *detailedError) Details() map[string]any {
+	return e.details
+}
+
+// WithDetails returns a DetailedError that wraps err, keeping its status and code.
+func WithDetails(err error, details map[string]any) error {
+	return &detailedError{err: err, details: details}
+}
+
 // DefaultErrorMapper provides a default implementation of ErrorMapper.
 type DefaultErrorMapper struct{}
 
 // MapError maps application errors to HTTP status codes and responses.
+// Details of a DetailedError are included in client errors (4xx) unless the error type
+// sets its own; the status, code and message are those of the error it wraps, so a
+// DetailedError of no known type is still a generic internal error and its details,
+// like its message, are not exposed.
 func (m *DefaultErrorMapper) MapError(err error) (statusCode int, response interface{}) {
+	statusCode, errResponse := mapDefaultError(err)
+
+	var detailed DetailedError
+	if !errors.As(err, &detailed) {
+		return statusCode, errResponse
+	}
+
+	if errResponse.Details == nil && statusCode < http.StatusInternalServerError {
+		errResponse.Details = detailed.Details()
+	}
+
+	return statusCode, errResponse
+}
+
+// mapDefaultError maps the error types known to the package.
+func mapDefaultError(err error) (int, ErrorResponse) {
 	var valErr *ValidationError
 	if errors.As(err, &valErr) {
+		// Prefer localized messages when the error has been translated
+		details := valErr.Fields
+		if len(valErr.Translations) > 0 {
+			details = valErr.Translations
+		}
+
 		return http.StatusBadRequest, ErrorResponse{
 			Error:   "Validation failed",
 			Code:    "VALIDATION_ERROR",
-			Details: valErr.Fields,
+			Details: details,
+		}
+	}
+
+	var decErr *DecodeError
+	if errors.As(err, &decErr) {
+		return http.StatusBadRequest, ErrorResponse{
+			Error: decErr.Error(),
+			Code:  "DECODE_ERROR",
+			Details: map[string]string{
+				"source": string(decErr.Source),
+				"param":  decErr.Param,
+				"kind":   string(decErr.Kind),
+			},
+		}
+	}
+
+	var jsonErr *JSONError
+	if errors.As(err, &jsonErr) {
+		details := map[string]interface{}{"offset": jsonErr.Offset}
+		if jsonErr.Field != "" {
+			details["field"] = jsonErr.Field
+		}
+
+		return http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid JSON in request body: " + jsonErr.Message,
+			Code:    "INVALID_JSON",
+			Details: details,
+		}
+	}
+
+	if errors.Is(err, ErrInvalidCursor) {
+		return http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_CURSOR",
+		}
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, ErrorResponse{
+			Error: httpErr.Error(),
+			Code:  httpErr.Code,
 		}
 	}
 
@@ -169,3 +530,10 @@ func (m *DefaultErrorMapper) MapError(err error) (statusCode int, response inter
 		Code:  "INTERNAL_ERROR",
 	}
 }
+
+// writeErrorResponse writes err as an ErrorResponse for failures outside a typed handler.
+func writeErrorResponse(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,406 @@
+package typedhttp
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// FieldNameStrategy derives the JSON name of a struct field that has no json tag from
+// its Go name. SnakeCase and CamelCase are provided.
+type FieldNameStrategy func(goName string) string
+
+// WithFieldNameStrategy names untagged request and response fields with strategy, e.g.
+// WithFieldNameStrategy(typedhttp.SnakeCase) encodes UserName as "user_name". It applies
+// to the JSON decoder and encoder of every route and to the generated OpenAPI schemas.
+// Fields with a json tag keep the tagged name, and fields bound to another source such
+// as a path or query parameter are not body fields.
+func WithFieldNameStrategy(strategy FieldNameStrategy) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.FieldNameStrategy = strategy
+	}
+}
+
+// FieldNameStrategy returns the strategy set with WithFieldNameStrategy, or nil.
+func (r *TypedRouter) FieldNameStrategy() FieldNameStrategy {
+	return r.config.FieldNameStrategy
+}
+
+// SnakeCase converts a Go name to snake_case, keeping initialisms together:
+// UserName is "user_name" and HTTPStatusCode is "http_status_code".
+func SnakeCase(goName string) string {
+	return joinWords(splitGoName(goName), "_", strings.ToLower)
+}
+
+// CamelCase converts a Go name to lower camelCase: UserName is "userName" and
+// HTTPStatusCode is "httpStatusCode".
+func CamelCase(goName string) string {
+	words := splitGoName(goName)
+	if len(words) == 0 {
+		return ""
+	}
+
+	first := strings.ToLower(words[0])
+	rest := joinWords(words[1:], "", func(word string) string {
+		return strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+	})
+
+	return first + rest
+}
+
+// splitGoName splits a Go identifier into words at case changes, treating runs of
+// capitals as one word: "HTTPStatusCode" becomes HTTP, Status, Code.
+func splitGoName(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := unicode.IsLower(prev) && unicode.IsUpper(cur) ||
+			unicode.IsLetter(prev) && unicode.IsDigit(cur) ||
+			unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) ||
+			cur == '_'
+		if boundary {
+			if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+				words = append(words, word)
+			}
+			start = i
+		}
+	}
+	if word := strings.Trim(string(runes[start:]), "_"); word != "" {
+		words = append(words, word)
+	}
+
+	return words
+}
+
+func joinWords(words []string, sep string, transform func(string) string) string {
+	for i, word := range words {
+		words[i] = transform(word)
+	}
+
+	return strings.Join(words, sep)
+}
+
+// sourceTags are the tags that bind a field to a request source other than the JSON body.
+var sourceTags = []string{"path", "query", "header", "cookie", "form", "body", "meta", "principal"}
+
+// JSONFieldName returns the JSON object key of a struct field: the name in its json tag,
+// or strategy applied to the Go name for untagged fields. It reports false for fields
+// that are not encoded under a name of their own: unexported, tagged "-", embedded
+// untagged structs, untagged fields when strategy is nil, untagged fields bound to
+// another request source, and fields bound to the authenticated principal.
+func JSONFieldName(field reflect.StructField, strategy FieldNameStrategy) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+	if _, ok := field.Tag.Lookup("principal"); ok {
+		return "", false
+	}
+
+	tag, tagged := field.Tag.Lookup("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, field.IsExported()
+	}
+
+	if field.Anonymous || strategy == nil || !field.IsExported() {
+		return "", false
+	}
+	if !tagged {
+		for _, source := range sourceTags {
+			if _, ok := field.Tag.Lookup(source); ok {
+				return "", false
+			}
+		}
+	}
+
+	return strategy(field.Name), true
+}
+
+// wireFieldName returns the key a struct field is encoded under in the JSON bodies of
+// a route using strategy, which may be nil. embedded is set instead for untagged embedded
+// structs, whose fields are promoted. It reports false for fields that are not encoded.
+func wireFieldName(field reflect.StructField, strategy FieldNameStrategy) (name string, embedded, ok bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	tagName, _, _ := strings.Cut(tag, ",")
+	if field.Anonymous && tagName == "" {
+		embeddedType := field.Type
+		if embeddedType.Kind() == reflect.Ptr {
+			embeddedType = embeddedType.Elem()
+		}
+		if embeddedType.Kind() == reflect.Struct {
+			return "", true, true
+		}
+	}
+	if !field.IsExported() {
+		return "", false, false
+	}
+
+	if name, ok := JSONFieldName(field, strategy); ok {
+		return name, false, true
+	}
+	// Fields the strategy does not rename keep the name encoding/json gives them
+	if tagName != "" {
+		return tagName, false, true
+	}
+
+	return field.Name, false, true
+}
+
+// hasStrategyNamedFields reports whether t has a body field named by strategy.
+func hasStrategyNamedFields(t reflect.Type, strategy FieldNameStrategy) bool {
+	if strategy == nil || t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+			continue
+		}
+		if _, ok := JSONFieldName(field, strategy); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldNamer rewrites the object keys of JSON documents between the names encoding/json
+// uses for untagged fields (the Go name) and the names chosen by a FieldNameStrategy.
+type fieldNamer struct {
+	strategy FieldNameStrategy
+	tables   sync.Map // fieldTableKey -> map[string]fieldRename
+}
+
+type fieldTableKey struct {
+	t      reflect.Type
+	toWire bool
+}
+
+// fieldRename is the new key of an object member and the type of its value.
+type fieldRename struct {
+	name string
+	typ  reflect.Type
+}
+
+func newFieldNamer(strategy FieldNameStrategy) *fieldNamer {
+	if strategy == nil {
+		return nil
+	}
+
+	return &fieldNamer{strategy: strategy}
+}
+
+// toWire renames the keys of data, encoded from a value of type t, to the wire names.
+func (n *fieldNamer) toWire(data []byte, t reflect.Type) ([]byte, error) {
+	return n.rewrite(data, t, true)
+}
+
+// fromWire renames the keys of data received for type t to the names encoding/json
+// decodes into t.
+func (n *fieldNamer) fromWire(data []byte, t reflect.Type) ([]byte, error) {
+	return n.rewrite(data, t, false)
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// customJSON reports whether values of t control their own encoding.
+func customJSON(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{jsonMarshalerType, jsonUnmarshalerType, textMarshalerType} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *fieldNamer) rewrite(data []byte, t reflect.Type, toWire bool) ([]byte, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || customJSON(t) {
+		return data, nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return data, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if trimmed[0] != '{' {
+			return data, nil
+		}
+		table := n.table(t, toWire)
+
+		return n.rewriteObject(trimmed, func(key string) (string, reflect.Type) {
+			if rename, ok := table[key]; ok {
+				return rename.name, rename.typ
+			}
+
+			return key, nil
+		}, toWire)
+	case reflect.Map:
+		if trimmed[0] != '{' {
+			return data, nil
+		}
+		elem := t.Elem()
+
+		return n.rewriteObject(trimmed, func(key string) (string, reflect.Type) {
+			return key, elem
+		}, toWire)
+	case reflect.Slice, reflect.Array:
+		if trimmed[0] != '[' {
+			return data, nil
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			rewritten, err := n.rewrite(item, t.Elem(), toWire)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = rewritten
+		}
+
+		return json.Marshal(items)
+	default:
+		return data, nil
+	}
+}
+
+// rewriteObject renames the members of a JSON object, keeping their order, and rewrites
+// each value for the type returned by rename.
+func (n *fieldNamer) rewriteObject(
+	data []byte, rename func(key string) (string, reflect.Type), toWire bool,
+) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		name, valueType := rename(key)
+		if valueType != nil {
+			if value, err = n.rewrite(value, valueType, toWire); err != nil {
+				return nil, err
+			}
+		}
+
+		encodedName, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		out.Write(encodedName)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	out.WriteByte('}')
+
+	return out.Bytes(), nil
+}
+
+// table returns the key renames for objects of struct type t, including fields promoted
+// from embedded structs. Tagged fields map to themselves so their values are still
+// rewritten.
+func (n *fieldNamer) table(t reflect.Type, toWire bool) map[string]fieldRename {
+	key := fieldTableKey{t: t, toWire: toWire}
+	if cached, ok := n.tables.Load(key); ok {
+		return cached.(map[string]fieldRename)
+	}
+
+	table := make(map[string]fieldRename)
+	n.collectFields(t, toWire, table, map[reflect.Type]bool{})
+	n.tables.Store(key, table)
+
+	return table
+}
+
+func (n *fieldNamer) collectFields(t reflect.Type, toWire bool, table map[string]fieldRename, seen map[reflect.Type]bool) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if name, _, _ := strings.Cut(tag, ","); field.Anonymous && name == "" && tag != "-" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				embedded = append(embedded, embeddedType)
+
+				continue
+			}
+		}
+
+		wireName, ok := JSONFieldName(field, n.strategy)
+		if !ok {
+			continue
+		}
+
+		// encoding/json uses the Go name for untagged fields
+		goName := wireName
+		if name, _, _ := strings.Cut(tag, ","); name == "" {
+			goName = field.Name
+		}
+
+		from, to := goName, wireName
+		if !toWire {
+			from, to = wireName, goName
+		}
+		if _, exists := table[from]; !exists {
+			table[from] = fieldRename{name: to, typ: field.Type}
+		}
+	}
+
+	// Promoted fields lose to fields declared at a shallower depth
+	for _, embeddedType := range embedded {
+		n.collectFields(embeddedType, toWire, table, seen)
+	}
+}
@@ -0,0 +1,156 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type namedAddress struct {
+	StreetName string
+	PostCode   string `json:"zip"`
+}
+
+type namedAudit struct {
+	CreatedBy string
+}
+
+type updateProfileRequest struct {
+	ID           string `path:"id"`
+	UserName     string `validate:"required"`
+	DisplayName  string `json:"nickname"`
+	HomeAddress  *namedAddress
+	PhoneNumbers []string
+}
+
+type profileResponse struct {
+	namedAudit
+	UserID      string
+	UserName    string
+	DisplayName string `json:"nickname"`
+	HomeAddress namedAddress
+	Tags        map[string]namedAddress
+	UpdatedAt   time.Time
+	Internal    string `json:"-"`
+}
+
+type updateProfileHandler struct {
+	received updateProfileRequest
+}
+
+func (h *updateProfileHandler) Handle(_ context.Context, req updateProfileRequest) (profileResponse, error) {
+	h.received = req
+
+	return profileResponse{
+		namedAudit:  namedAudit{CreatedBy: "admin"},
+		UserID:      req.ID,
+		UserName:    req.UserName,
+		DisplayName: req.DisplayName,
+		HomeAddress: *req.HomeAddress,
+		Tags:        map[string]namedAddress{"Work": {StreetName: "Main St"}},
+		UpdatedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Internal:    "secret",
+	}, nil
+}
+
+func TestWithFieldNameStrategy_SnakeCase(t *testing.T) {
+	handler := &updateProfileHandler{}
+	router := NewRouter(WithFieldNameStrategy(SnakeCase))
+	PUT(router, "/profiles/{id}", handler)
+
+	body := `{"user_name":"jane","nickname":"J","home_address":{"street_name":"Rua Augusta","zip":"1100"},` +
+		`"phone_numbers":["+351"]}`
+	req := httptest.NewRequest(http.MethodPut, "/profiles/p-1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, updateProfileRequest{
+		ID:           "p-1",
+		UserName:     "jane",
+		DisplayName:  "J",
+		HomeAddress:  &namedAddress{StreetName: "Rua Augusta", PostCode: "1100"},
+		PhoneNumbers: []string{"+351"},
+	}, handler.received)
+
+	assert.JSONEq(t, `{
+		"created_by": "admin",
+		"user_id": "p-1",
+		"user_name": "jane",
+		"nickname": "J",
+		"home_address": {"street_name": "Rua Augusta", "zip": "1100"},
+		"tags": {"Work": {"street_name": "Main St", "zip": ""}},
+		"updated_at": "2024-01-02T03:04:05Z"
+	}`, w.Body.String())
+}
+
+func TestWithFieldNameStrategy_ValidationAndErrors(t *testing.T) {
+	router := NewRouter(WithFieldNameStrategy(SnakeCase))
+	PUT(router, "/profiles/{id}", &updateProfileHandler{})
+
+	for _, body := range []string{`{"nickname":"J"}`, `{"user_name":`} {
+		req := httptest.NewRequest(http.MethodPut, "/profiles/p-1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+type renameRequest struct {
+	NewName string
+	Reason  string `json:"reason"`
+}
+
+type renameHandler struct {
+	received renameRequest
+}
+
+func (h *renameHandler) Handle(_ context.Context, req renameRequest) (renameRequest, error) {
+	h.received = req
+
+	return req, nil
+}
+
+func TestWithFieldNameStrategy_JSONOnlyRequest(t *testing.T) {
+	handler := &renameHandler{}
+	router := NewRouter(WithFieldNameStrategy(CamelCase))
+	POST(router, "/rename", handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/rename", strings.NewReader(`{"newName":"b","reason":"typo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, renameRequest{NewName: "b", Reason: "typo"}, handler.received)
+	assert.JSONEq(t, `{"newName":"b","reason":"typo"}`, w.Body.String())
+}
+
+func TestFieldNameStrategies(t *testing.T) {
+	tests := []struct {
+		goName string
+		snake  string
+		camel  string
+	}{
+		{goName: "UserName", snake: "user_name", camel: "userName"},
+		{goName: "ID", snake: "id", camel: "id"},
+		{goName: "UserID", snake: "user_id", camel: "userId"},
+		{goName: "HTTPStatusCode", snake: "http_status_code", camel: "httpStatusCode"},
+		{goName: "Address2", snake: "address_2", camel: "address2"},
+		{goName: "Name", snake: "name", camel: "name"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.snake, SnakeCase(tt.goName), tt.goName)
+		assert.Equal(t, tt.camel, CamelCase(tt.goName), tt.goName)
+	}
+}
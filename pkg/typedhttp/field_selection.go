@@ -0,0 +1,248 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultFieldsParam is the query parameter read by WithFieldSelection unless overridden.
+const DefaultFieldsParam = "fields"
+
+// FieldSelectionConfig configures sparse fieldsets.
+type FieldSelectionConfig struct {
+	// Param is the query parameter holding the comma-separated selection.
+	Param string
+}
+
+// FieldSelectionOption configures WithFieldSelection.
+type FieldSelectionOption func(*FieldSelectionConfig)
+
+// WithFieldsParam reads the selection from the named query parameter.
+func WithFieldsParam(name string) FieldSelectionOption {
+	return func(c *FieldSelectionConfig) {
+		c.Param = name
+	}
+}
+
+// WithFieldSelection lets clients request a subset of the JSON response fields, e.g.
+// ?fields=id,email or ?fields=id,user.email for nested objects. Names are the JSON
+// names of the response fields, as renamed by the router's FieldNameStrategy; selections
+// on arrays apply to each element. Unknown names are rejected with 400 before the
+// response is written. Requests without the parameter get the full response.
+func WithFieldSelection(opts ...FieldSelectionOption) HandlerOption {
+	config := FieldSelectionConfig{Param: DefaultFieldsParam}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(cfg *HandlerConfig) {
+		selection := &fieldSelection{config: config, names: cfg.FieldNameStrategy}
+		cfg.Middleware = append(cfg.Middleware, selection.middleware)
+		cfg.ResponseInterceptors = append(cfg.ResponseInterceptors, ResponseInterceptor[any](selection))
+	}
+}
+
+// fieldSelectionKey is the context key for the selection of the current request.
+type fieldSelectionKey struct{}
+
+// fieldTree is a parsed selection; a nil subtree selects the whole field.
+type fieldTree map[string]fieldTree
+
+// selectedFields holds the selection of one request.
+type selectedFields struct {
+	tree fieldTree
+	// checked is set once the selection has been validated against the response type
+	checked bool
+}
+
+// fieldSelection checks the selection against the response type as an interceptor and
+// prunes the encoded body as HTTP middleware.
+type fieldSelection struct {
+	config FieldSelectionConfig
+	names  FieldNameStrategy // Field names of the route, nil for encoding/json names
+}
+
+func (s *fieldSelection) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := r.URL.Query().Get(s.config.Param)
+		if raw == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		selected := &selectedFields{tree: parseFieldSelection(raw)}
+		buffer := newBatchResponseWriter()
+		next.ServeHTTP(buffer, r.WithContext(context.WithValue(r.Context(), fieldSelectionKey{}, selected)))
+
+		body := buffer.body.Bytes()
+		if selected.checked && buffer.status < http.StatusMultipleChoices &&
+			strings.Contains(buffer.header.Get("Content-Type"), "json") {
+			if pruned, err := pruneJSON(body, selected.tree); err == nil {
+				body = pruned
+				buffer.header.Set("Content-Length", strconv.Itoa(len(body)))
+			}
+		}
+
+		for name, values := range buffer.header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(buffer.status)
+		_, _ = w.Write(body)
+	})
+}
+
+// Intercept implements ResponseInterceptor[any].
+func (s *fieldSelection) Intercept(ctx context.Context, resp any) (any, error) {
+	selected, ok := ctx.Value(fieldSelectionKey{}).(*selectedFields)
+	if !ok {
+		return resp, nil
+	}
+
+	if unknown := unknownFields(reflect.TypeOf(resp), selected.tree, "", s.names); len(unknown) > 0 {
+		return resp, NewHTTPError(http.StatusBadRequest, "INVALID_FIELDS",
+			fmt.Sprintf("Unknown fields in %s: %s", s.config.Param, strings.Join(unknown, ", ")))
+	}
+	selected.checked = true
+
+	return resp, nil
+}
+
+// parseFieldSelection parses "id,user.email,user.name" into a tree.
+func parseFieldSelection(raw string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		node := tree
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			if i == len(segments)-1 {
+				// Selecting a whole field overrides any narrower selection
+				node[segment] = nil
+
+				break
+			}
+
+			child, seen := node[segment]
+			if seen && child == nil {
+				// The whole field is already selected
+				break
+			}
+			if !seen {
+				child = fieldTree{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+
+	return tree
+}
+
+// unknownFields returns the selected paths that t does not have, sorted.
+func unknownFields(t reflect.Type, tree fieldTree, prefix string, names FieldNameStrategy) []string {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	// Map keys and dynamic values are only known at runtime
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var unknown []string
+	for name, subtree := range tree {
+		field, ok := jsonField(t, name, names)
+		if !ok {
+			unknown = append(unknown, prefix+name)
+
+			continue
+		}
+		if subtree != nil {
+			unknown = append(unknown, unknownFields(field.Type, subtree, prefix+name+".", names)...)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+// jsonField finds the field of struct type t encoded under name, including fields
+// promoted from embedded structs.
+func jsonField(t reflect.Type, name string, names FieldNameStrategy) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldName, embedded, ok := wireFieldName(field, names)
+		if !ok {
+			continue
+		}
+
+		if embedded {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if promoted, ok := jsonField(embeddedType, name, names); ok {
+				return promoted, true
+			}
+
+			continue
+		}
+
+		if fieldName == name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// pruneJSON keeps only the selected fields of a JSON document.
+func pruneJSON(body []byte, tree fieldTree) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(pruneValue(document, tree))
+}
+
+func pruneValue(value interface{}, tree fieldTree) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(tree))
+		for name, subtree := range tree {
+			child, ok := typed[name]
+			if !ok {
+				continue
+			}
+			if subtree != nil {
+				child = pruneValue(child, subtree)
+			}
+			pruned[name] = child
+		}
+
+		return pruned
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = pruneValue(item, tree)
+		}
+
+		return typed
+	default:
+		return value
+	}
+}
@@ -0,0 +1,149 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sparseAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type sparseUser struct {
+	Email   string        `json:"email"`
+	Name    string        `json:"name"`
+	Address sparseAddress `json:"address"`
+}
+
+type sparseOrder struct {
+	ID    string       `json:"id"`
+	Total int          `json:"total"`
+	User  sparseUser   `json:"user"`
+	Lines []sparseLine `json:"lines"`
+}
+
+type sparseLine struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type sparseOrderHandler struct{}
+
+func (h *sparseOrderHandler) Handle(_ context.Context, _ struct{}) (sparseOrder, error) {
+	return sparseOrder{
+		ID:    "o-1",
+		Total: 42,
+		User: sparseUser{
+			Email:   "jane@example.com",
+			Name:    "Jane",
+			Address: sparseAddress{City: "Lisbon", Country: "PT"},
+		},
+		Lines: []sparseLine{{SKU: "a", Quantity: 1}, {SKU: "b", Quantity: 2}},
+	}, nil
+}
+
+func serveSparseOrder(t *testing.T, target string, opts ...FieldSelectionOption) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := NewRouter()
+	GET(router, "/orders", &sparseOrderHandler{}, WithFieldSelection(opts...))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return rr
+}
+
+func TestWithFieldSelection_TopLevel(t *testing.T) {
+	rr := serveSparseOrder(t, "/orders?fields=id,total")
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":"o-1","total":42}`, rr.Body.String())
+}
+
+func TestWithFieldSelection_Nested(t *testing.T) {
+	rr := serveSparseOrder(t, "/orders?fields=id,user.email,user.address.city,lines.sku")
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"id": "o-1",
+		"user": {"email": "jane@example.com", "address": {"city": "Lisbon"}},
+		"lines": [{"sku": "a"}, {"sku": "b"}]
+	}`, rr.Body.String())
+}
+
+func TestWithFieldSelection_WholeFieldWins(t *testing.T) {
+	rr := serveSparseOrder(t, "/orders?fields=user.email,user")
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"user":{"email":"jane@example.com","name":"Jane","address":{"city":"Lisbon","country":"PT"}}}`,
+		rr.Body.String())
+}
+
+func TestWithFieldSelection_WithoutParamReturnsEverything(t *testing.T) {
+	rr := serveSparseOrder(t, "/orders")
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"country":"PT"`)
+	assert.Contains(t, rr.Body.String(), `"quantity":2`)
+}
+
+func TestWithFieldSelection_UnknownFields(t *testing.T) {
+	rr := serveSparseOrder(t, "/orders?fields=id,user.phone,secret")
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "INVALID_FIELDS")
+	assert.Contains(t, rr.Body.String(), "secret, user.phone")
+}
+
+func TestWithFieldSelection_CustomParam(t *testing.T) {
+	rr := serveSparseOrder(t, "/orders?select=id&fields=total", WithFieldsParam("select"))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":"o-1"}`, rr.Body.String())
+}
+
+type namedProfile struct {
+	UserName string
+	Email    string `json:"email_address"`
+	Internal string `json:"-"`
+}
+
+type namedProfileHandler struct{}
+
+func (h *namedProfileHandler) Handle(_ context.Context, _ struct{}) (namedProfile, error) {
+	return namedProfile{UserName: "jane", Email: "jane@example.com", Internal: "x"}, nil
+}
+
+func TestWithFieldSelection_FieldNameStrategy(t *testing.T) {
+	router := NewRouter(WithFieldNameStrategy(SnakeCase))
+	GET(router, "/profile", &namedProfileHandler{}, WithFieldSelection())
+
+	tests := []struct {
+		target   string
+		wantCode int
+		wantBody string
+	}{
+		{target: "/profile?fields=user_name", wantCode: http.StatusOK, wantBody: `{"user_name":"jane"}`},
+		{target: "/profile?fields=user_name,email_address", wantCode: http.StatusOK,
+			wantBody: `{"user_name":"jane","email_address":"jane@example.com"}`},
+		{target: "/profile?fields=UserName", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			require.Equal(t, tt.wantCode, rr.Code, rr.Body.String())
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
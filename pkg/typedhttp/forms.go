@@ -61,11 +61,16 @@ func (d *FormDecoder[T]) Decode(r *http.Request) (T, error) {
 		return result, err
 	}
 
-	if err := d.processFormFields(r, &result); err != nil {
+	decodeErrs, err := d.processFormFields(r, &result)
+	if err != nil {
 		return result, err
 	}
 
-	if err := d.validateResult(result); err != nil {
+	if err := normalize(&result); err != nil {
+		return result, err
+	}
+
+	if err := d.validateResult(result, decodeErrs); err != nil {
 		return result, err
 	}
 
@@ -91,7 +96,9 @@ func (d *FormDecoder[T]) parseFormData(r *http.Request) error {
 }
 
 // processFormFields processes all form fields using reflection.
-func (d *FormDecoder[T]) processFormFields(r *http.Request, result *T) error {
+// Conversion errors are collected so that every field is attempted.
+func (d *FormDecoder[T]) processFormFields(r *http.Request, result *T) ([]*DecodeError, error) {
+	var decodeErrs []*DecodeError
 	resultValue := reflect.ValueOf(result).Elem()
 	resultType := resultValue.Type()
 
@@ -109,11 +116,13 @@ func (d *FormDecoder[T]) processFormFields(r *http.Request, result *T) error {
 		}
 
 		if err := d.processFormField(r, &field, fieldValue, formName); err != nil {
-			return err
+			if err = collectDecodeError(&decodeErrs, err); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return nil
+	return decodeErrs, nil
 }
 
 // processFormField processes a single form field.
@@ -121,12 +130,13 @@ func (d *FormDecoder[T]) processFormField(
 	r *http.Request, field *reflect.StructField, fieldValue reflect.Value, formName string,
 ) error {
 	// Handle file uploads
+	names := fieldNames(field, formName)
 	if d.isFileUploadField(fieldValue) {
-		return d.handleFileUpload(r, fieldValue, formName)
+		return d.handleFileUpload(r, fieldValue, names)
 	}
 
 	// Get form value
-	formValue := d.getFormValue(r, formName, field.Tag.Get("default"))
+	formValue := d.getFormValue(r, names, field.Tag.Get("default"))
 	if formValue == "" {
 		return nil
 	}
@@ -145,7 +155,7 @@ func (d *FormDecoder[T]) processFormField(
 	// Apply transformations and formats
 	processedValue, err := d.processFieldValue(field, formValue, fieldValue.Type())
 	if err != nil {
-		return fmt.Errorf("failed to process form field %s: %w", formName, err)
+		return newDecodeError(SourceForm, field.Name, formName, "failed to process form field "+formName, err)
 	}
 
 	if processedValue != nil {
@@ -156,7 +166,7 @@ func (d *FormDecoder[T]) processFormField(
 
 	// Set the field value based on its type
 	if err := setFieldValueFromString(fieldValue, formValue); err != nil {
-		return fmt.Errorf("failed to set form field %s: %w", field.Name, err)
+		return newDecodeError(SourceForm, field.Name, formName, "failed to set form field "+field.Name, err)
 	}
 
 	return nil
@@ -168,8 +178,8 @@ func (d *FormDecoder[T]) isFileUploadField(fieldValue reflect.Value) bool {
 		fieldValue.Type() == reflect.TypeOf([]*multipart.FileHeader{})
 }
 
-// handleFileUpload handles file upload fields.
-func (d *FormDecoder[T]) handleFileUpload(r *http.Request, fieldValue reflect.Value, formName string) error {
+// handleFileUpload binds the files of the first part name found under names.
+func (d *FormDecoder[T]) handleFileUpload(r *http.Request, fieldValue reflect.Value, names []string) error {
 	if !d.allowFiles {
 		return ErrFileUploadsNotAllowed
 	}
@@ -178,8 +188,13 @@ func (d *FormDecoder[T]) handleFileUpload(r *http.Request, fieldValue reflect.Va
 		return nil
 	}
 
-	files, exists := r.MultipartForm.File[formName]
-	if !exists {
+	var files []*multipart.FileHeader
+	for _, name := range names {
+		if files = r.MultipartForm.File[name]; len(files) > 0 {
+			break
+		}
+	}
+	if len(files) == 0 {
 		return nil
 	}
 
@@ -194,15 +209,9 @@ func (d *FormDecoder[T]) handleFileUpload(r *http.Request, fieldValue reflect.Va
 	return nil
 }
 
-// getFormValue retrieves a form value with default fallback.
-func (d *FormDecoder[T]) getFormValue(r *http.Request, formName, defaultValue string) string {
-	var formValue string
-	if r.Form != nil {
-		formValues := r.Form[formName]
-		if len(formValues) > 0 {
-			formValue = formValues[0]
-		}
-	}
+// getFormValue retrieves the first form value found under names, with default fallback.
+func (d *FormDecoder[T]) getFormValue(r *http.Request, names []string, defaultValue string) string {
+	formValue := firstValue(names, r.Form.Get)
 
 	if formValue == "" && defaultValue != "" {
 		formValue = handleDefaultValue(defaultValue)
@@ -262,26 +271,14 @@ func (d *FormDecoder[T]) processFieldValue(
 	return nil, nil
 }
 
-// validateResult performs validation on the final result.
-func (d *FormDecoder[T]) validateResult(result T) error {
-	if d.validator == nil {
-		return nil
+// validateResult performs validation on the final result, reporting conversion errors with it.
+func (d *FormDecoder[T]) validateResult(result T, decodeErrs []*DecodeError) error {
+	var validationErr error
+	if d.validator != nil {
+		validationErr = d.validator.Struct(result)
 	}
 
-	if err := d.validator.Struct(result); err != nil {
-		validationErrors := make(map[string]string)
-		var validatorErrs validator.ValidationErrors
-		if errors.As(err, &validatorErrs) {
-			for _, validatorErr := range validatorErrs {
-				field := strings.ToLower(validatorErr.Field())
-				validationErrors[field] = validatorErr.Tag()
-			}
-		}
-
-		return NewValidationError("Form validation failed", validationErrors)
-	}
-
-	return nil
+	return aggregateRequestErrors("Form validation failed", decodeErrs, validationErr)
 }
 
 // ContentTypes returns the supported content types for form decoding.
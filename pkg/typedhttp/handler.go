@@ -3,7 +3,11 @@ package typedhttp
 import (
 	"context"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/netip"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // Handler represents the core business logic interface (transport-agnostic).
@@ -36,11 +40,14 @@ type ErrorMapper interface {
 }
 
 // StreamingResponse represents a response that should be streamed to the client.
+// The router writes Stream directly instead of JSON-encoding the response and
+// closes it afterwards if it implements io.Closer.
 type StreamingResponse struct {
 	ContentType string
 	Filename    string
 	Stream      io.Reader
 	StatusCode  int
+	Size        int64 // Content-Length, written only when greater than zero
 }
 
 // Middleware represents HTTP middleware following the standard Go pattern.
@@ -58,10 +65,50 @@ type HandlerConfig struct {
 	TypedMiddleware []MiddlewareEntry // Typed middleware entries
 	Metadata        OpenAPIMetadata
 	Observability   ObservabilityConfig
+	// ResponseInterceptors holds ResponseInterceptor values in execution order.
+	ResponseInterceptors []interface{}
+	// BodyCodecs are additional body formats negotiated alongside JSON.
+	BodyCodecs []BodyCodec
+	// Translator localizes validation error messages from the Accept-Language header.
+	Translator *Translator
+	// MaxMultipartMemory limits the bytes of a multipart form kept in memory before
+	// file parts spill to disk. Zero means MaxFormMemory.
+	MaxMultipartMemory int64
+	// ResponseStatus overrides the method default success status. Zero means unset.
+	ResponseStatus int
+	// SuccessStatuses lists every success status the route may return, for documentation.
+	SuccessStatuses []int
+	// ContextEnrichers populate the request context before decoding.
+	ContextEnrichers []ContextEnricher
+	// SkipValidation binds request fields without running the validator.
+	SkipValidation bool
+	// Validator validates decoded requests. Nil means the package-wide default validator.
+	Validator *validator.Validate
+	// Produces lists additional response media types, for documentation.
+	Produces []string
+	// Consumes lists the accepted request body media types. Empty accepts any.
+	Consumes []string
+	// Hide405 answers unregistered methods on the route's path with 404 instead of 405.
+	Hide405 bool
+	// Name is the logical name of the handler for logs, metrics and traces.
+	// Empty means the route pattern.
+	Name string
+	// FieldNameStrategy names untagged JSON body fields. Nil uses the Go field names.
+	FieldNameStrategy FieldNameStrategy
+	// StructValidators run after the validate tags, in order, on every decoded request.
+	StructValidators []StructValidator
+	// TrustedProxies are the proxies whose X-Forwarded-For header is used for meta:"remote_ip".
+	TrustedProxies []netip.Prefix
+	// DryRunParam names the query flag that validates a request without calling Handle.
+	// Empty disables dry runs.
+	DryRunParam string
+	// ResponseHeaders documents the headers set on responses, for OpenAPI generation.
+	ResponseHeaders []ResponseHeaderSpec
 }
 
 // OpenAPIMetadata contains metadata for OpenAPI specification generation.
 type OpenAPIMetadata struct {
+	OperationID string                  `json:"operation_id,omitempty"`
 	Summary     string                  `json:"summary,omitempty"`
 	Description string                  `json:"description,omitempty"`
 	Tags        []string                `json:"tags,omitempty"`
@@ -112,4 +159,10 @@ type ObservabilityConfig struct {
 	Logging         bool                   `json:"logging,omitempty"`
 	TraceAttributes map[string]interface{} `json:"trace_attributes,omitempty"`
 	MetricLabels    map[string]string      `json:"metric_labels,omitempty"`
+
+	// Providers used when the matching feature is enabled. A nil Logger falls
+	// back to slog.Default(); nil MetricsCollector and Tracer are no-ops.
+	Logger           *slog.Logger          `json:"-"`
+	MetricsCollector RouteMetricsCollector `json:"-"`
+	Tracer           Tracer                `json:"-"`
 }
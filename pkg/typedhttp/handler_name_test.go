@@ -0,0 +1,78 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type handlerNameTestHandler struct {
+	seen string
+}
+
+func (h *handlerNameTestHandler) Handle(ctx context.Context, req metricsTestRequest) (metricsTestResponse, error) {
+	h.seen = HandlerNameFromContext(ctx)
+
+	return metricsTestResponse{ID: req.ID}, nil
+}
+
+func TestWithHandlerName(t *testing.T) {
+	var hookLogs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&hookLogs, nil))
+	loggingHook := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			logger.InfoContext(r.Context(), "served", "handler", HandlerNameFromContext(r.Context()))
+		})
+	}
+
+	var logs bytes.Buffer
+	metrics := newMockRouteMetrics()
+	tracer := &recordingTracer{}
+	handler := &handlerNameTestHandler{}
+
+	router := NewRouter()
+	GET(router, "/v1/items/{id}", handler,
+		WithHandlerName("items.get"),
+		WithMiddleware(loggingHook),
+		WithDefaultObservability(),
+		WithObservabilityLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		WithObservabilityMetrics(metrics),
+		WithObservabilityTracer(tracer),
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/items/42", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, "items.get", handler.seen)
+	assert.Contains(t, hookLogs.String(), `"handler":"items.get"`)
+	assert.Contains(t, logs.String(), `"handler":"items.get"`)
+	assert.Contains(t, logs.String(), `"route":"/v1/items/{id}"`)
+	assert.Equal(t, 1, metrics.requests["items.get"])
+	require.Len(t, tracer.spans, 1)
+	assert.Equal(t, "items.get", tracer.spans[0].operation)
+
+	require.Len(t, router.GetHandlers(), 1)
+	assert.Equal(t, "items.get", router.GetHandlers()[0].Name)
+}
+
+func TestHandlerNameFromContext_FallsBackToPattern(t *testing.T) {
+	handler := &handlerNameTestHandler{}
+
+	router := NewRouter()
+	GET(router, "/items/{id}", handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, "GET /items/{id}", handler.seen)
+	assert.Empty(t, HandlerNameFromContext(context.Background()))
+}
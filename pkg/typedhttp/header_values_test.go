@@ -0,0 +1,68 @@
+package typedhttp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type multiHeaderRequest struct {
+	Things    []string `header:"X-Thing"`
+	Accept    []string `header:"Accept" header_values:"split"`
+	Versions  []int    `header:"X-Version" header_values:"split"`
+	Languages []string `header:"X-Lang" header_values:"split" default:"en,fr"`
+	ClientIP  net.IP   `header:"X-Client-IP"`
+}
+
+func TestHeaderDecoder_MultiValue(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Add("X-Thing", "a")
+	req.Header.Add("X-Thing", "b, c")
+	req.Header.Add("X-Thing", "d")
+	req.Header.Add("Accept", "application/json, text/html")
+	req.Header.Add("Accept", "text/plain")
+	req.Header.Set("X-Version", "1,2")
+	req.Header.Set("X-Client-IP", "10.0.0.1")
+
+	result, err := NewHeaderDecoder[multiHeaderRequest](nil).Decode(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b, c", "d"}, result.Things, "repeated keeps one element per header")
+	assert.Equal(t, []string{"application/json", "text/html", "text/plain"}, result.Accept)
+	assert.Equal(t, []int{1, 2}, result.Versions)
+	assert.Equal(t, []string{"en", "fr"}, result.Languages)
+	assert.Equal(t, "10.0.0.1", result.ClientIP.String())
+}
+
+func TestCombinedDecoder_MultiValueHeaders(t *testing.T) {
+	type request struct {
+		ID     string   `path:"id"`
+		Things []string `header:"X-Thing"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/items/7", http.NoBody)
+	req.Header.Add("X-Thing", "one")
+	req.Header.Add("X-Thing", "two")
+	req.Header.Add("X-Thing", "three")
+
+	result, err := NewCombinedDecoder[request](nil).Decode(req)
+	require.NoError(t, err)
+	assert.Equal(t, "7", result.ID)
+	assert.Equal(t, []string{"one", "two", "three"}, result.Things)
+}
+
+func TestHeaderDecoder_MultiValueConversionError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("X-Version", "1,two")
+
+	_, err := NewHeaderDecoder[multiHeaderRequest](nil).Decode(req)
+
+	var decodeErr *DecodeError
+	require.True(t, errors.As(err, &decodeErr), "got %v", err)
+	assert.Equal(t, "X-Version", decodeErr.Param)
+}
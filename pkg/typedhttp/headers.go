@@ -20,6 +20,7 @@ var (
 	ErrUnknownTransformation = errors.New("unknown transformation")
 	ErrFormatNotSupported    = errors.New("format not supported for type")
 	ErrInvalidUnixTimestamp  = errors.New("invalid unix timestamp")
+	ErrInvalidUUID           = errors.New("invalid UUID")
 )
 
 // HeaderDecoder implements RequestDecoder for HTTP headers.
@@ -38,11 +39,16 @@ func NewHeaderDecoder[T any](validator *validator.Validate) *HeaderDecoder[T] {
 func (d *HeaderDecoder[T]) Decode(r *http.Request) (T, error) {
 	var result T
 
-	if err := d.processHeaderFields(r, &result); err != nil {
+	decodeErrs, err := d.processHeaderFields(r, &result)
+	if err != nil {
+		return result, err
+	}
+
+	if err := normalize(&result); err != nil {
 		return result, err
 	}
 
-	if err := d.validateHeaderResult(result); err != nil {
+	if err := d.validateHeaderResult(result, decodeErrs); err != nil {
 		return result, err
 	}
 
@@ -50,7 +56,9 @@ func (d *HeaderDecoder[T]) Decode(r *http.Request) (T, error) {
 }
 
 // processHeaderFields processes all header fields using reflection.
-func (d *HeaderDecoder[T]) processHeaderFields(r *http.Request, result *T) error {
+// Conversion errors are collected so that every field is attempted.
+func (d *HeaderDecoder[T]) processHeaderFields(r *http.Request, result *T) ([]*DecodeError, error) {
+	var decodeErrs []*DecodeError
 	resultValue := reflect.ValueOf(result).Elem()
 	resultType := resultValue.Type()
 
@@ -68,25 +76,36 @@ func (d *HeaderDecoder[T]) processHeaderFields(r *http.Request, result *T) error
 		}
 
 		if err := d.processHeaderField(r, &field, fieldValue, headerName); err != nil {
-			return err
+			if err = collectDecodeError(&decodeErrs, err); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return nil
+	return decodeErrs, nil
 }
 
 // processHeaderField processes a single header field.
 func (d *HeaderDecoder[T]) processHeaderField(
 	r *http.Request, field *reflect.StructField, fieldValue reflect.Value, headerName string,
 ) error {
-	headerValue := d.getHeaderValue(r, headerName, field.Tag.Get("default"))
+	if isHeaderSlice(fieldValue.Type()) {
+		values := headerValues(r, fieldNames(field, headerName), field.Tag.Get("header_values"), field.Tag.Get("default"))
+		if err := setHeaderSlice(fieldValue, values, field.Tag.Get("transform")); err != nil {
+			return newDecodeError(SourceHeader, field.Name, headerName, "failed to set header field "+field.Name, err)
+		}
+
+		return nil
+	}
+
+	headerValue := d.getHeaderValue(r, fieldNames(field, headerName), field.Tag.Get("default"))
 	if headerValue == "" {
 		return nil
 	}
 
 	processedValue, transformedValue, err := d.processHeaderValue(field, headerValue, fieldValue.Type())
 	if err != nil {
-		return fmt.Errorf("failed to process header %s: %w", headerName, err)
+		return newDecodeError(SourceHeader, field.Name, headerName, "failed to process header "+headerName, err)
 	}
 
 	if processedValue != nil {
@@ -102,15 +121,15 @@ func (d *HeaderDecoder[T]) processHeaderField(
 	}
 
 	if err := setFieldValueFromString(fieldValue, valueToUse); err != nil {
-		return fmt.Errorf("failed to set header field %s: %w", field.Name, err)
+		return newDecodeError(SourceHeader, field.Name, headerName, "failed to set header field "+field.Name, err)
 	}
 
 	return nil
 }
 
-// getHeaderValue retrieves a header value with default fallback.
-func (d *HeaderDecoder[T]) getHeaderValue(r *http.Request, headerName, defaultValue string) string {
-	headerValue := r.Header.Get(headerName)
+// getHeaderValue retrieves the first header found under names, with default fallback.
+func (d *HeaderDecoder[T]) getHeaderValue(r *http.Request, names []string, defaultValue string) string {
+	headerValue := firstValue(names, r.Header.Get)
 	if headerValue == "" && defaultValue != "" {
 		headerValue = handleDefaultValue(defaultValue)
 	}
@@ -118,6 +137,77 @@ func (d *HeaderDecoder[T]) getHeaderValue(r *http.Request, headerName, defaultVa
 	return headerValue
 }
 
+// Modes of the header_values tag for slice fields.
+const (
+	// HeaderValuesRepeated collects one element per occurrence of a repeated header.
+	HeaderValuesRepeated = "repeated"
+	// HeaderValuesSplit also splits every occurrence on commas, e.g. "Accept: a, b".
+	HeaderValuesSplit = "split"
+)
+
+// isHeaderSlice reports whether a header field collects multiple values. net.IP is a
+// byte slice but decodes from a single value.
+func isHeaderSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t != reflect.TypeOf(net.IP{})
+}
+
+// headerValues returns every value of the first header present under names for a slice
+// field. A comma-separated default applies when the header is absent.
+func headerValues(r *http.Request, names []string, mode, defaultValue string) []string {
+	var values []string
+	for _, name := range names {
+		if values = r.Header.Values(name); len(values) > 0 {
+			break
+		}
+	}
+	if len(values) == 0 {
+		if defaultValue == "" {
+			return nil
+		}
+		values, mode = []string{handleDefaultValue(defaultValue)}, HeaderValuesSplit
+	}
+
+	if mode != HeaderValuesSplit {
+		return values
+	}
+
+	var split []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+
+	return split
+}
+
+// setHeaderSlice converts each header value into an element of the slice field.
+func setHeaderSlice(fieldValue reflect.Value, values []string, transform string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	slice := reflect.MakeSlice(fieldValue.Type(), len(values), len(values))
+	for i, value := range values {
+		if transform != "" {
+			transformed, err := applyTransformation(transform, value)
+			if err != nil {
+				return err
+			}
+			value = transformed
+		}
+
+		if err := setFieldValueFromString(slice.Index(i), value); err != nil {
+			return err
+		}
+	}
+	fieldValue.Set(slice)
+
+	return nil
+}
+
 // processHeaderValue applies transformations and formats to header values.
 // Returns (processedValue, transformedString, error).
 // processedValue is non-nil if the value was fully processed (formats, special types).
@@ -177,26 +267,14 @@ func (d *HeaderDecoder[T]) processHeaderValue(
 	return nil, "", nil
 }
 
-// validateHeaderResult validates the final header result.
-func (d *HeaderDecoder[T]) validateHeaderResult(result T) error {
-	if d.validator == nil {
-		return nil
-	}
-
-	if err := d.validator.Struct(result); err != nil {
-		validationErrors := make(map[string]string)
-		var validatorErrs validator.ValidationErrors
-		if errors.As(err, &validatorErrs) {
-			for _, validatorErr := range validatorErrs {
-				field := strings.ToLower(validatorErr.Field())
-				validationErrors[field] = validatorErr.Tag()
-			}
-		}
-
-		return NewValidationError("Header validation failed", validationErrors)
+// validateHeaderResult validates the final header result, reporting conversion errors with it.
+func (d *HeaderDecoder[T]) validateHeaderResult(result T, decodeErrs []*DecodeError) error {
+	var validationErr error
+	if d.validator != nil {
+		validationErr = d.validator.Struct(result)
 	}
 
-	return nil
+	return aggregateRequestErrors("Header validation failed", decodeErrs, validationErr)
 }
 
 // ContentTypes returns the supported content types for header decoding.
@@ -338,11 +416,39 @@ func applyFormat(format, value string, targetType reflect.Type) (interface{}, er
 	switch {
 	case targetType == reflect.TypeOf(time.Time{}):
 		return parseTimeWithFormat(format, value)
+	case format == "uuid" && targetType.Kind() == reflect.String:
+		if !isUUID(value) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUUID, value)
+		}
+
+		return reflect.ValueOf(value).Convert(targetType).Interface(), nil
 	default:
 		return nil, fmt.Errorf("%w: %s for type %s", ErrFormatNotSupported, format, targetType)
 	}
 }
 
+// isUUID reports whether value is a UUID in its canonical 8-4-4-4-12 hex form.
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+
+	for i, c := range value {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // parseTimeWithFormat parses time strings with various formats.
 func parseTimeWithFormat(format, value string) (time.Time, error) {
 	switch format {
@@ -387,8 +493,8 @@ func parseTimeWithFormat(format, value string) (time.Time, error) {
 		return t, nil
 
 	default:
-		// Try custom format
-		t, err := time.Parse(format, value)
+		// Try a registered format, then the format as a layout
+		t, err := time.Parse(timeLayout(format), value)
 		if err != nil {
 			return time.Time{}, fmt.Errorf("failed to parse custom time format %s: %w", format, err)
 		}
@@ -0,0 +1,53 @@
+package typedhttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveError(t *testing.T, err error) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{err: err})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/1", http.NoBody))
+
+	return w
+}
+
+func TestHTTPError_UnauthorizedWithChallenge(t *testing.T) {
+	err := NewHTTPError(http.StatusUnauthorized, "TOKEN_EXPIRED", "access token expired").
+		WithHeader("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+
+	w := serveError(t, err)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="api", error="invalid_token"`, w.Header().Get("WWW-Authenticate"))
+	assert.JSONEq(t, `{"error":"access token expired","code":"TOKEN_EXPIRED"}`, w.Body.String())
+}
+
+func TestHTTPError_TooManyRequestsWithRetryAfter(t *testing.T) {
+	err := NewHTTPError(http.StatusTooManyRequests, "RATE_LIMITED", "").WithHeader("Retry-After", "30")
+
+	w := serveError(t, fmt.Errorf("quota check: %w", err))
+
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"Too Many Requests","code":"RATE_LIMITED"}`, w.Body.String())
+}
+
+func TestDefaultErrorMapper_HTTPError(t *testing.T) {
+	mapper := &DefaultErrorMapper{}
+
+	status, response := mapper.MapError(NewHTTPError(http.StatusPaymentRequired, "PAYMENT_REQUIRED", "upgrade your plan"))
+
+	assert.Equal(t, http.StatusPaymentRequired, status)
+	assert.Equal(t, ErrorResponse{Error: "upgrade your plan", Code: "PAYMENT_REQUIRED"}, response)
+}
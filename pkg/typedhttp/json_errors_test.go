@@ -0,0 +1,83 @@
+package typedhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDecoder_MalformedBodyPosition(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantOffset  int64
+		wantField   string
+		wantMessage string
+	}{
+		{
+			name:        "syntax error",
+			body:        `{"id": 1, "entry": {amount: 5}}`,
+			wantOffset:  21,
+			wantMessage: "invalid character 'a' looking for beginning of object key string at offset 21",
+		},
+		{
+			name:        "type mismatch",
+			body:        `{"id": 1, "entry": {"amount": "five"}}`,
+			wantOffset:  36,
+			wantField:   "entry.amount",
+			wantMessage: `field "entry.amount" must be a number, got string at offset 36`,
+		},
+		{
+			name:        "wrong body type",
+			body:        `[1, 2]`,
+			wantOffset:  1,
+			wantMessage: "body must be an object, got array at offset 1",
+		},
+		{
+			name:        "truncated document",
+			body:        `{"id": 1, "entry": {`,
+			wantOffset:  20,
+			wantMessage: "unexpected end of JSON input at offset 20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeLedger(t, tt.body)
+
+			var jsonErr *JSONError
+			require.ErrorAs(t, err, &jsonErr)
+			assert.Equal(t, tt.wantOffset, jsonErr.Offset)
+			assert.Equal(t, tt.wantField, jsonErr.Field)
+			assert.Equal(t, tt.wantMessage, jsonErr.Message)
+			assert.Equal(t, "invalid JSON: "+tt.wantMessage, err.Error())
+		})
+	}
+}
+
+func TestJSONError_MapsToBadRequest(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/signup", &normalizedSignupHandler{})
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"email": "a@b.co", "name": 42}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp struct {
+		Error   string                 `json:"error"`
+		Code    string                 `json:"code"`
+		Details map[string]interface{} `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "INVALID_JSON", resp.Code)
+	assert.Equal(t, `Invalid JSON in request body: field "name" must be a string, got number at offset 30`, resp.Error)
+	assert.Equal(t, map[string]interface{}{"offset": float64(30), "field": "name"}, resp.Details)
+}
@@ -0,0 +1,95 @@
+package typedhttp
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type LedgerEntry struct {
+	Amount  int64  `json:"amount"`
+	Counter uint64 `json:"counter"`
+}
+
+type LedgerRequest struct {
+	ID    int64       `json:"id"`
+	Entry LedgerEntry `json:"entry"`
+}
+
+func decodeLedger(t *testing.T, body string) (LedgerRequest, error) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/ledger", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	return NewJSONDecoder[LedgerRequest](nil).Decode(req)
+}
+
+func TestJSONDecoder_LargeIntegersDecodeExactly(t *testing.T) {
+	id := int64(math.MaxInt64 - 1)
+	body := `{"id":` + strconv.FormatInt(id, 10) +
+		`,"entry":{"amount":` + strconv.FormatInt(math.MinInt64, 10) +
+		`,"counter":` + strconv.FormatUint(math.MaxUint64, 10) + `}}`
+
+	result, err := decodeLedger(t, body)
+
+	require.NoError(t, err)
+	assert.Equal(t, id, result.ID)
+	assert.Equal(t, int64(math.MinInt64), result.Entry.Amount)
+	assert.Equal(t, uint64(math.MaxUint64), result.Entry.Counter)
+}
+
+func TestJSONDecoder_IntegerOutOfRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		param string
+		kind  DecodeErrorKind
+	}{
+		{
+			name:  "int64 overflow",
+			body:  `{"id":9223372036854775808}`,
+			param: "id",
+			kind:  DecodeKindOutOfRange,
+		},
+		{
+			name:  "nested uint64 overflow",
+			body:  `{"entry":{"counter":18446744073709551616}}`,
+			param: "entry.counter",
+			kind:  DecodeKindOutOfRange,
+		},
+		{
+			name:  "negative unsigned",
+			body:  `{"entry":{"counter":-1}}`,
+			param: "entry.counter",
+			kind:  DecodeKindOutOfRange,
+		},
+		{
+			name:  "fractional integer",
+			body:  `{"id":1.5}`,
+			param: "id",
+			kind:  DecodeKindInvalidInteger,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeLedger(t, tt.body)
+			require.Error(t, err)
+
+			var decErr *DecodeError
+			require.True(t, errors.As(err, &decErr))
+			assert.Equal(t, SourceJSON, decErr.Source)
+			assert.Equal(t, tt.param, decErr.Param)
+			assert.Equal(t, tt.kind, decErr.Kind)
+			assert.True(t, strings.HasPrefix(err.Error(), "invalid JSON: "))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"reflect"
+)
+
+// DefaultJSONStreamFlushEvery is the number of elements written between flushes of a
+// JSONStream that does not set FlushEvery.
+const DefaultJSONStreamFlushEvery = 100
+
+// JSONStream is a response that the router writes as a JSON array one element at a time,
+// so large lists never have to be held in memory. Build it with StreamSeq, StreamSeq2 or
+// StreamChannel.
+//
+// The status and headers are sent with the first element. An error yielded before that
+// is handled like a handler error; once the array has started, an error or a canceled
+// request aborts the connection so the client sees a truncated, invalid document rather
+// than a short list that looks complete.
+type JSONStream[T any] struct {
+	// Items yields the array elements. A non-nil error ends the stream.
+	Items iter.Seq2[T, error]
+	// FlushEvery flushes the response after this many elements; zero means
+	// DefaultJSONStreamFlushEvery. Use 1 for slow producers.
+	FlushEvery int
+	// StatusCode defaults to 200 OK.
+	StatusCode int
+}
+
+// StreamSeq streams the elements of seq.
+func StreamSeq[T any](seq iter.Seq[T]) JSONStream[T] {
+	return JSONStream[T]{Items: func(yield func(T, error) bool) {
+		for item := range seq {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}}
+}
+
+// StreamSeq2 streams the elements of seq, stopping at the first error.
+func StreamSeq2[T any](seq iter.Seq2[T, error]) JSONStream[T] {
+	return JSONStream[T]{Items: seq}
+}
+
+// StreamChannel streams the values received from ch until it is closed. The producer
+// should stop sending once the request context is done, since the router stops
+// receiving when the client goes away.
+func StreamChannel[T any](ch <-chan T) JSONStream[T] {
+	return JSONStream[T]{Items: func(yield func(T, error) bool) {
+		for item := range ch {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}}
+}
+
+// ItemType returns the element type, for documentation generators.
+func (s JSONStream[T]) ItemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// writeJSONStream implements jsonStreamWriter.
+func (s JSONStream[T]) writeJSONStream(ctx context.Context, w http.ResponseWriter) error {
+	flushEvery := s.FlushEvery
+	if flushEvery <= 0 {
+		flushEvery = DefaultJSONStreamFlushEvery
+	}
+
+	status := s.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	controller := http.NewResponseController(w)
+	started := false
+	start := func() {
+		started = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Del("Content-Length")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte{'['})
+	}
+
+	count := 0
+	if s.Items != nil {
+		for item, err := range s.Items {
+			if err == nil {
+				err = ctx.Err()
+			}
+			if err != nil {
+				if !started {
+					return err
+				}
+				abortJSONStream(ctx, err)
+			}
+
+			encoded, err := json.Marshal(item)
+			if err != nil {
+				if !started {
+					return err
+				}
+				abortJSONStream(ctx, err)
+			}
+
+			if !started {
+				start()
+			} else {
+				_, _ = w.Write([]byte{','})
+			}
+			if _, err := w.Write(encoded); err != nil {
+				abortJSONStream(ctx, err)
+			}
+
+			count++
+			if count%flushEvery == 0 {
+				_ = controller.Flush()
+			}
+		}
+	}
+
+	if !started {
+		start()
+	}
+	_, _ = w.Write([]byte{']'})
+	_ = controller.Flush()
+
+	return nil
+}
+
+// abortJSONStream ends a stream whose headers are already sent. The error is recorded on
+// the request span and the connection is dropped, which is the only way to tell the
+// client that the array is incomplete.
+func abortJSONStream(ctx context.Context, err error) {
+	SpanFromContext(ctx).SetError(err)
+	panic(http.ErrAbortHandler)
+}
+
+// jsonStreamWriter is implemented by JSONStream instantiations.
+type jsonStreamWriter interface {
+	writeJSONStream(ctx context.Context, w http.ResponseWriter) error
+}
+
+// writeJSONStreamResponse writes resp as a streamed JSON array if it is a JSONStream.
+// It reports whether it was; the error is set if the stream failed before it started.
+func writeJSONStreamResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) (bool, error) {
+	stream, ok := resp.(jsonStreamWriter)
+	if !ok || isNilPointer(resp) {
+		return false, nil
+	}
+
+	return true, stream.writeJSONStream(ctx, w)
+}
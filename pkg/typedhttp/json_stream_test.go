@@ -0,0 +1,178 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamedItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type listItemsRequest struct {
+	Count int `query:"count"`
+}
+
+// itemStreamHandler streams req.Count items, yielding err at item failAt when set.
+type itemStreamHandler struct {
+	failAt int
+	err    error
+}
+
+func (h *itemStreamHandler) Handle(_ context.Context, req listItemsRequest) (JSONStream[streamedItem], error) {
+	stream := StreamSeq2(func(yield func(streamedItem, error) bool) {
+		for i := 1; i <= req.Count; i++ {
+			if i == h.failAt {
+				yield(streamedItem{}, h.err)
+
+				return
+			}
+			if !yield(streamedItem{ID: i, Name: "item-" + strconv.Itoa(i)}, nil) {
+				return
+			}
+		}
+	})
+	stream.FlushEvery = 2
+
+	return stream, nil
+}
+
+func TestJSONStream_Framing(t *testing.T) {
+	tests := []struct {
+		count    int
+		expected string
+	}{
+		{count: 0, expected: `[]`},
+		{count: 1, expected: `[{"id":1,"name":"item-1"}]`},
+		{count: 3, expected: `[{"id":1,"name":"item-1"},{"id":2,"name":"item-2"},{"id":3,"name":"item-3"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.count), func(t *testing.T) {
+			router := NewRouter()
+			GET(router, "/items", &itemStreamHandler{})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?count="+strconv.Itoa(tt.count), http.NoBody))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected, w.Body.String())
+			assert.True(t, json.Valid(w.Body.Bytes()))
+		})
+	}
+}
+
+func TestJSONStream_FlushesPeriodically(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items", &itemStreamHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?count=5", http.NoBody))
+
+	assert.True(t, w.Flushed)
+	var items []streamedItem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	assert.Len(t, items, 5)
+}
+
+func TestJSONStream_ErrorBeforeFirstItem(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items", &itemStreamHandler{
+		failAt: 1,
+		err:    NewNotFoundError("catalog", "missing"),
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?count=3", http.NoBody))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NotContains(t, w.Body.String(), "[")
+}
+
+func TestJSONStream_ErrorMidStreamAbortsConnection(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items", &itemStreamHandler{failAt: 3, err: errors.New("database went away")})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/items?count=5")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.Error(t, err, "the client must see the stream fail")
+	assert.False(t, json.Valid(body))
+}
+
+type channelStreamHandler struct{}
+
+func (h *channelStreamHandler) Handle(_ context.Context, _ struct{}) (JSONStream[string], error) {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for _, name := range []string{"a", "b", "c"} {
+			ch <- name
+		}
+	}()
+
+	return StreamChannel(ch), nil
+}
+
+type seqStreamHandler struct{}
+
+func (h *seqStreamHandler) Handle(_ context.Context, _ struct{}) (JSONStream[int], error) {
+	stream := StreamSeq(slices.Values([]int{1, 2, 3}))
+	stream.StatusCode = http.StatusPartialContent
+
+	return stream, nil
+}
+
+func TestJSONStream_Sources(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/names", &channelStreamHandler{})
+	GET(router, "/numbers", &seqStreamHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/names", http.NoBody))
+	assert.Equal(t, `["a","b","c"]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/numbers", http.NoBody))
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, `[1,2,3]`, w.Body.String())
+}
+
+func TestJSONStream_StopsWhenRequestCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var seq iter.Seq[int] = func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if i == 2 {
+				cancel()
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		_, _ = writeJSONStreamResponse(ctx, w, StreamSeq(seq))
+	})
+	assert.Equal(t, `[0,1`, w.Body.String())
+}
@@ -0,0 +1,128 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownMetaField is returned when a meta tag names unknown request metadata.
+var ErrUnknownMetaField = errors.New("unknown meta field")
+
+// Request metadata that fields can bind with the meta tag, e.g. `meta:"remote_ip"`.
+const (
+	MetaMethod    = "method"     // The request method
+	MetaPath      = "path"       // The request path, without the query string
+	MetaRemoteIP  = "remote_ip"  // The client IP; see WithTrustedProxies
+	MetaUserAgent = "user_agent" // The User-Agent header
+)
+
+// WithTrustedProxies sets the proxies whose X-Forwarded-For header is trusted when
+// resolving meta:"remote_ip". When the connection comes from a trusted proxy, the
+// client IP is the last X-Forwarded-For entry that is not itself a trusted proxy.
+// Without trusted proxies X-Forwarded-For is ignored, as any client can set it.
+func WithTrustedProxies(proxies ...netip.Prefix) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.TrustedProxies = append(cfg.TrustedProxies, proxies...)
+	}
+}
+
+// metaField is a struct field bound to request metadata.
+type metaField struct {
+	index int
+	name  string
+}
+
+// newMetaFields returns the meta-tagged fields of requestType.
+func newMetaFields(requestType reflect.Type) []metaField {
+	if requestType == nil || requestType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []metaField
+	for i := 0; i < requestType.NumField(); i++ {
+		field := requestType.Field(i)
+		if name := field.Tag.Get("meta"); name != "" && field.IsExported() {
+			fields = append(fields, metaField{index: i, name: name})
+		}
+	}
+
+	return fields
+}
+
+// bindMeta sets the meta-tagged fields of result from r. It runs after the body is
+// decoded, so a body can never override request metadata.
+func bindMeta(r *http.Request, fields []metaField, trustedProxies []netip.Prefix, result reflect.Value) error {
+	for _, field := range fields {
+		var value string
+		switch field.name {
+		case MetaMethod:
+			value = r.Method
+		case MetaPath:
+			value = r.URL.Path
+		case MetaRemoteIP:
+			value = remoteIP(r, trustedProxies)
+		case MetaUserAgent:
+			value = r.UserAgent()
+		default:
+			return fmt.Errorf("%w: %s", ErrUnknownMetaField, field.name)
+		}
+
+		if err := setFieldValueFromString(result.Field(field.index), value); err != nil {
+			return fmt.Errorf("failed to set meta field %s: %w", field.name, err)
+		}
+	}
+
+	return nil
+}
+
+// remoteIP returns the client IP of r, walking X-Forwarded-For from the nearest hop
+// while the hops are trusted proxies.
+func remoteIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+	if !isTrustedProxy(peer, trustedProxies) {
+		return peer
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrustedProxy(hop, trustedProxies) {
+			return hop
+		}
+		peer = hop
+	}
+
+	return peer
+}
+
+// isTrustedProxy reports whether ip is within one of the trusted proxy prefixes.
+func isTrustedProxy(ip string, trustedProxies []netip.Prefix) bool {
+	if len(trustedProxies) == 0 {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
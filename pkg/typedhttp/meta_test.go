@@ -0,0 +1,156 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditRequest struct {
+	ID        string `path:"id"`
+	Method    string `meta:"method"`
+	Path      string `meta:"path"`
+	RemoteIP  string `meta:"remote_ip"`
+	UserAgent string `meta:"user_agent"`
+}
+
+type auditHandler struct {
+	got auditRequest
+}
+
+func (h *auditHandler) Handle(_ context.Context, req auditRequest) (struct{}, error) {
+	h.got = req
+
+	return struct{}{}, nil
+}
+
+func TestMeta_PopulatesRequestMetadata(t *testing.T) {
+	handler := &auditHandler{}
+	router := NewRouter()
+	PUT(router, "/documents/{id}", handler)
+
+	r := httptest.NewRequest(http.MethodPut, "/documents/7?draft=true", http.NoBody)
+	r.RemoteAddr = "198.51.100.4:52100"
+	r.Header.Set("User-Agent", "audit-client/1.2")
+	r.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, auditRequest{
+		ID:        "7",
+		Method:    http.MethodPut,
+		Path:      "/documents/7",
+		RemoteIP:  "198.51.100.4",
+		UserAgent: "audit-client/1.2",
+	}, handler.got, "X-Forwarded-For is ignored without trusted proxies")
+}
+
+func TestMeta_RemoteIPHonorsTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expectedIP   string
+	}{
+		{
+			name:         "direct client",
+			remoteAddr:   "203.0.113.9:4000",
+			forwardedFor: []string{"192.0.2.1"},
+			expectedIP:   "203.0.113.9",
+		},
+		{
+			name:         "single trusted proxy",
+			remoteAddr:   "10.0.0.2:4000",
+			forwardedFor: []string{"203.0.113.9"},
+			expectedIP:   "203.0.113.9",
+		},
+		{
+			name:         "spoofed entries before the proxy chain",
+			remoteAddr:   "10.0.0.2:4000",
+			forwardedFor: []string{"192.0.2.1, 203.0.113.9", "10.0.0.3"},
+			expectedIP:   "203.0.113.9",
+		},
+		{
+			name:         "only proxies",
+			remoteAddr:   "10.0.0.2:4000",
+			forwardedFor: []string{"10.0.0.5, 10.0.0.3"},
+			expectedIP:   "10.0.0.5",
+		},
+		{
+			name:       "trusted proxy without header",
+			remoteAddr: "10.0.0.2:4000",
+			expectedIP: "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &auditHandler{}
+			router := NewRouter(WithTrustedProxies(netip.MustParsePrefix("10.0.0.0/8")))
+			GET(router, "/documents/{id}", handler)
+
+			r := httptest.NewRequest(http.MethodGet, "/documents/7", http.NoBody)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedIP, handler.got.RemoteIP)
+		})
+	}
+}
+
+type metaBodyRequest struct {
+	Name     string `json:"name"`
+	RemoteIP string `meta:"remote_ip"`
+}
+
+type metaBodyHandler struct {
+	got metaBodyRequest
+}
+
+func (h *metaBodyHandler) Handle(_ context.Context, req metaBodyRequest) (struct{}, error) {
+	h.got = req
+
+	return struct{}{}, nil
+}
+
+func TestMeta_BodyCannotOverride(t *testing.T) {
+	handler := &metaBodyHandler{}
+	router := NewRouter()
+	POST(router, "/comments", handler)
+
+	body, _ := json.Marshal(map[string]string{"name": "ada", "RemoteIP": "192.0.2.66"})
+	r := httptest.NewRequest(http.MethodPost, "/comments", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+	r.RemoteAddr = "198.51.100.4:52100"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, metaBodyRequest{Name: "ada", RemoteIP: "198.51.100.4"}, handler.got)
+}
+
+func TestMeta_UnknownField(t *testing.T) {
+	type request struct {
+		Host string `meta:"hostname"`
+	}
+
+	_, err := NewCombinedDecoder[request](nil).Decode(httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.ErrorIs(t, err, ErrUnknownMetaField)
+}
@@ -0,0 +1,43 @@
+package typedhttp
+
+import "net/http"
+
+// WithHide405 answers requests to the route's path with an unregistered method with
+// 404 instead of 405, so the response reveals neither that the resource exists nor
+// which methods it accepts. Automatic OPTIONS responses are suppressed for the path
+// too. Use it for admin and other endpoints where method probing aids reconnaissance.
+func WithHide405() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Hide405 = true
+	}
+}
+
+// hidesMethods reports whether req matches no route for its method but targets a path
+// with a route registered WithHide405.
+func (r *TypedRouter) hidesMethods(req *http.Request) bool {
+	r.mu.RLock()
+	hide405, handlers := r.hide405, r.handlers
+	r.mu.RUnlock()
+
+	if !hide405 {
+		return false
+	}
+	if _, pattern := r.mux.Handler(req); pattern != "" {
+		return false
+	}
+
+	for i := range handlers {
+		if !handlers[i].Config.Hide405 {
+			continue
+		}
+
+		hidden := RoutePattern{Method: handlers[i].Method, Path: handlers[i].Path}
+		probe := req.Clone(req.Context())
+		probe.Method = hidden.Method
+		if _, pattern := r.mux.Handler(probe); pattern == hidden.String() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMethodProbeRouter() *TypedRouter {
+	router := NewRouter(WithAutoOptions())
+	GET(router, "/items/{id}", &metricsTestHandler{})
+	PUT(router, "/items/{id}", &metricsTestHandler{})
+	GET(router, "/admin/items/{id}", &metricsTestHandler{}, WithHide405())
+	DELETE(router, "/admin/items/{id}", &metricsTestHandler{}, WithHide405())
+
+	return router
+}
+
+func TestMethodNotAllowed_ListsRegisteredMethods(t *testing.T) {
+	router := newMethodProbeRouter()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/items/42", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	assert.Equal(t, "GET, HEAD, PUT", rr.Header().Get("Allow"))
+}
+
+func TestWithHide405(t *testing.T) {
+	router := newMethodProbeRouter()
+
+	unknown := httptest.NewRecorder()
+	router.ServeHTTP(unknown, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	for _, method := range []string{http.MethodPost, http.MethodPatch, http.MethodOptions} {
+		t.Run(method, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(method, "/admin/items/42", nil))
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			assert.Empty(t, rr.Header().Get("Allow"))
+			assert.Equal(t, unknown.Body.String(), rr.Body.String(), "indistinguishable from an unknown path")
+		})
+	}
+
+	t.Run("registered methods are served", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/admin/items/42", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,48 @@
+package typedhttp
+
+import (
+	"net/http"
+	"time"
+)
+
+// RouteMetricsCollector receives RED metrics for each request served by a route.
+// Routes are identified by their registration template, e.g. "GET /users/{id}",
+// so the number of distinct keys is bounded by the route table. Collectors that also
+// implement RouteSizeCollector receive request and response body sizes.
+type RouteMetricsCollector interface {
+	// IncRequests counts a request handled by the route.
+	IncRequests(route string)
+	// IncErrors counts a request that ended with a 5xx status.
+	IncErrors(route string)
+	// ObserveLatency records how long the route took to serve the request.
+	ObserveLatency(route string, duration time.Duration)
+}
+
+// metricsHandler records route metrics around the wrapped handler.
+type metricsHandler struct {
+	route     string
+	collector RouteMetricsCollector
+	next      http.Handler
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	sizes, observeSizes := h.collector.(RouteSizeCollector)
+	var body *countingBody
+	if observeSizes {
+		body = countRequestBody(r)
+	}
+	recorder := NewResponseRecorder(w)
+
+	h.next.ServeHTTP(recorder, r)
+
+	h.collector.IncRequests(h.route)
+	if recorder.Status() >= http.StatusInternalServerError {
+		h.collector.IncErrors(h.route)
+	}
+	h.collector.ObserveLatency(h.route, time.Since(start))
+	if observeSizes {
+		sizes.ObserveRequestSize(h.route, requestBodySize(r, body))
+		sizes.ObserveResponseSize(h.route, recorder.BytesWritten())
+	}
+}
@@ -0,0 +1,105 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRouteMetrics struct {
+	mu        sync.Mutex
+	requests  map[string]int
+	errors    map[string]int
+	latencies map[string][]time.Duration
+}
+
+func newMockRouteMetrics() *mockRouteMetrics {
+	return &mockRouteMetrics{
+		requests:  make(map[string]int),
+		errors:    make(map[string]int),
+		latencies: make(map[string][]time.Duration),
+	}
+}
+
+func (m *mockRouteMetrics) IncRequests(route string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[route]++
+}
+
+func (m *mockRouteMetrics) IncErrors(route string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[route]++
+}
+
+func (m *mockRouteMetrics) ObserveLatency(route string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies[route] = append(m.latencies[route], duration)
+}
+
+type metricsTestRequest struct {
+	ID string `path:"id"`
+}
+
+type metricsTestResponse struct {
+	ID string `json:"id"`
+}
+
+type metricsTestHandler struct {
+	err error
+}
+
+func (h *metricsTestHandler) Handle(_ context.Context, req metricsTestRequest) (metricsTestResponse, error) {
+	if h.err != nil {
+		return metricsTestResponse{}, h.err
+	}
+
+	return metricsTestResponse{ID: req.ID}, nil
+}
+
+func TestWithRouteMetrics_RecordsPerRouteTemplate(t *testing.T) {
+	collector := newMockRouteMetrics()
+	router := NewRouter(WithRouteMetrics(collector))
+	GET(router, "/users/{id}", &metricsTestHandler{})
+	GET(router, "/orders/{id}", &metricsTestHandler{err: assert.AnError})
+	DELETE(router, "/users/{id}", &metricsTestHandler{err: NewNotFoundError("user", "1")})
+
+	requests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/users/1"},
+		{http.MethodGet, "/users/2"},
+		{http.MethodGet, "/users/3"},
+		{http.MethodGet, "/orders/1"},
+		{http.MethodGet, "/orders/2"},
+		{http.MethodDelete, "/users/1"},
+	}
+
+	for _, r := range requests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(r.method, r.path, http.NoBody))
+	}
+
+	assert.Equal(t, map[string]int{
+		"GET /users/{id}":    3,
+		"GET /orders/{id}":   2,
+		"DELETE /users/{id}": 1,
+	}, collector.requests)
+	assert.Equal(t, map[string]int{"GET /orders/{id}": 2}, collector.errors, "only 5xx responses count as errors")
+
+	require.Len(t, collector.latencies["GET /users/{id}"], 3)
+	require.Len(t, collector.latencies["GET /orders/{id}"], 2)
+	for _, latency := range collector.latencies["GET /users/{id}"] {
+		assert.GreaterOrEqual(t, latency, time.Duration(0))
+	}
+	assert.NotContains(t, collector.requests, "GET /users/1", "raw paths must not be used as keys")
+}
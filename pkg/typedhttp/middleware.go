@@ -2,13 +2,17 @@ package typedhttp
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
-	"sort"
 	"sync"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// ErrInterceptorTypeMismatch is returned when a ResponseInterceptor[any] changes the response type.
+var ErrInterceptorTypeMismatch = errors.New("response interceptor returned a different type")
+
 // Typed middleware interfaces for different phases
 
 // TypedPreMiddleware operates on decoded request data before handler execution.
@@ -16,6 +20,22 @@ type TypedPreMiddleware[TRequest any] interface {
 	Before(ctx context.Context, req *TRequest) (context.Context, error)
 }
 
+// TypedRequestMiddleware receives the concrete decoded request before handler execution.
+// Unlike TypedPreMiddleware it is invoked by the router itself, so it can safely
+// mutate or enrich the request that the handler will observe.
+type TypedRequestMiddleware[TRequest any] interface {
+	BeforeTyped(ctx context.Context, req *TRequest) error
+}
+
+// ResponseInterceptor transforms the handler's response after it returns and before it is encoded.
+// Interceptors run in registration order, each receiving the previous one's result; an error
+// aborts the chain and is mapped like a handler error. Because the response type cannot change,
+// interceptors never affect the OpenAPI schema: envelope wrapping and other ResponseSchemaModifier
+// transforms still describe the final body. A ResponseInterceptor[any] applies to every response type.
+type ResponseInterceptor[TResponse any] interface {
+	Intercept(ctx context.Context, resp TResponse) (TResponse, error)
+}
+
 // TypedPostMiddleware operates on response data after handler execution.
 type TypedPostMiddleware[TResponse any] interface {
 	After(ctx context.Context, resp *TResponse) (*TResponse, error)
@@ -247,9 +267,7 @@ func (b *MiddlewareBuilder) WithPriority(priority int) *MiddlewareBuilder {
 // Build returns the middleware entries sorted by priority (highest first).
 func (b *MiddlewareBuilder) Build() []MiddlewareEntry {
 	// Sort by priority (higher priority executes first).
-	sort.Slice(b.entries, func(i, j int) bool {
-		return b.entries[i].Config.Priority > b.entries[j].Config.Priority
-	})
+	b.entries = resolveMiddleware(b.entries)
 
 	return b.entries
 }
@@ -282,3 +300,51 @@ func extractTypedMiddleware[TRequest, TResponse any](entries []MiddlewareEntry)
 
 	return chain
 }
+
+// extractResponseInterceptors returns the interceptors applicable to TResponse, preserving order.
+func extractResponseInterceptors[TResponse any](interceptors []interface{}) []ResponseInterceptor[TResponse] {
+	var result []ResponseInterceptor[TResponse]
+
+	for _, interceptor := range interceptors {
+		switch typed := interceptor.(type) {
+		case ResponseInterceptor[TResponse]:
+			result = append(result, typed)
+		case ResponseInterceptor[any]:
+			result = append(result, anyResponseInterceptor[TResponse]{interceptor: typed})
+		}
+	}
+
+	return result
+}
+
+// anyResponseInterceptor adapts a ResponseInterceptor[any] to a concrete response type.
+type anyResponseInterceptor[TResponse any] struct {
+	interceptor ResponseInterceptor[any]
+}
+
+func (a anyResponseInterceptor[TResponse]) Intercept(ctx context.Context, resp TResponse) (TResponse, error) {
+	intercepted, err := a.interceptor.Intercept(ctx, resp)
+	if err != nil {
+		return resp, err
+	}
+
+	typed, ok := intercepted.(TResponse)
+	if !ok {
+		return resp, fmt.Errorf("%w: got %T, want %T", ErrInterceptorTypeMismatch, intercepted, resp)
+	}
+
+	return typed, nil
+}
+
+// extractRequestMiddleware extracts typed request middleware from middleware entries.
+func extractRequestMiddleware[TRequest any](entries []MiddlewareEntry) []TypedRequestMiddleware[TRequest] {
+	var result []TypedRequestMiddleware[TRequest]
+
+	for _, entry := range entries {
+		if mw, ok := entry.Middleware.(TypedRequestMiddleware[TRequest]); ok {
+			result = append(result, mw)
+		}
+	}
+
+	return result
+}
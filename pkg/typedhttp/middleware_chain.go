@@ -0,0 +1,70 @@
+package typedhttp
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// resolveMiddleware returns entries in the order the request pipeline runs them:
+// highest Priority first, and registration order among equal priorities.
+func resolveMiddleware(entries []MiddlewareEntry) []MiddlewareEntry {
+	resolved := make([]MiddlewareEntry, len(entries))
+	copy(resolved, entries)
+	sort.SliceStable(resolved, func(i, j int) bool {
+		return resolved[i].Config.Priority > resolved[j].Config.Priority
+	})
+
+	return resolved
+}
+
+// middlewareName identifies an entry by its configured name, or by its type when unnamed.
+func middlewareName(entry MiddlewareEntry) string {
+	if entry.Config.Name != "" {
+		return entry.Config.Name
+	}
+
+	return fmt.Sprintf("%T", entry.Middleware)
+}
+
+// MiddlewareChain returns the names of the middleware entries that run for the route
+// registered with method and path, in the order the request pipeline runs them.
+// Entries without a MiddlewareConfig.Name are listed by type. It returns nil for
+// routes that are not registered or have no middleware entries.
+func (r *TypedRouter) MiddlewareChain(method, path string) []string {
+	for i := range r.handlers {
+		if r.handlers[i].Method != method || r.handlers[i].Path != path {
+			continue
+		}
+
+		var names []string
+		for _, entry := range resolveMiddleware(r.handlers[i].Config.TypedMiddleware) {
+			names = append(names, middlewareName(entry))
+		}
+
+		return names
+	}
+
+	return nil
+}
+
+// WriteMiddlewareChains writes the middleware chain of every registered route to w,
+// one route per line in registration order, e.g.
+//
+//	GET /orders/{id}: auth -> cache
+func (r *TypedRouter) WriteMiddlewareChains(w io.Writer) error {
+	for i := range r.handlers {
+		reg := &r.handlers[i]
+		chain := r.MiddlewareChain(reg.Method, reg.Path)
+		if len(chain) == 0 {
+			chain = []string{"(none)"}
+		}
+
+		if _, err := fmt.Fprintf(w, "%s %s: %s\n", reg.Method, reg.Path, strings.Join(chain, " -> ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chainRequest struct {
+	ID string `path:"id"`
+}
+
+type chainHandler struct{}
+
+func (h *chainHandler) Handle(_ context.Context, _ chainRequest) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+// recordingMiddleware appends its name to ran when it runs.
+type recordingMiddleware struct {
+	name string
+	ran  *[]string
+}
+
+func (m *recordingMiddleware) BeforeTyped(_ context.Context, _ *chainRequest) error {
+	*m.ran = append(*m.ran, m.name)
+
+	return nil
+}
+
+func TestMiddlewareChain_ResolvedOrderMatchesPipeline(t *testing.T) {
+	var ran []string
+	record := func(name string) *recordingMiddleware {
+		return &recordingMiddleware{name: name, ran: &ran}
+	}
+
+	entries := NewMiddlewareBuilder().
+		Add(record("cache"), WithName("cache"), WithPriority(10)).
+		Add(record("audit"), WithName("audit")).
+		Add(record("auth"), WithName("auth"), WithPriority(90)).
+		Add(record("metrics"), WithName("metrics"), WithPriority(10)).
+		Build()
+
+	router := NewRouter()
+	GET(router, "/orders/{id}", &chainHandler{},
+		WithMiddlewareEntries(entries...),
+		WithMiddlewareEntries(MiddlewareEntry{
+			Middleware: record("tenant"),
+			Config:     MiddlewareConfig{Name: "tenant", Priority: 50},
+		}))
+
+	expected := []string{"auth", "tenant", "cache", "metrics", "audit"}
+	assert.Equal(t, expected, router.MiddlewareChain(http.MethodGet, "/orders/{id}"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/7", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, expected, ran, "the pipeline runs middleware in the reported order")
+}
+
+func TestMiddlewareChain_UnknownRouteAndUnnamedEntries(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/orders/{id}", &chainHandler{},
+		WithMiddlewareEntries(MiddlewareEntry{Middleware: &recordingMiddleware{ran: new([]string)}}))
+
+	assert.Nil(t, router.MiddlewareChain(http.MethodPost, "/orders/{id}"))
+	assert.Equal(t, []string{"*typedhttp.recordingMiddleware"}, router.MiddlewareChain(http.MethodGet, "/orders/{id}"))
+}
+
+func TestWriteMiddlewareChains(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/orders/{id}", &chainHandler{}, WithMiddlewareEntries(
+		MiddlewareEntry{Middleware: &recordingMiddleware{ran: new([]string)}, Config: MiddlewareConfig{Name: "cache"}},
+		MiddlewareEntry{Middleware: &recordingMiddleware{ran: new([]string)}, Config: MiddlewareConfig{Name: "auth", Priority: 1}},
+	))
+	DELETE(router, "/orders/{id}", &chainHandler{})
+
+	var out bytes.Buffer
+	require.NoError(t, router.WriteMiddlewareChains(&out))
+
+	assert.Equal(t, "GET /orders/{id}: auth -> cache\nDELETE /orders/{id}: (none)\n", out.String())
+}
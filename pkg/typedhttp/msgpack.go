@@ -0,0 +1,51 @@
+package typedhttp
+
+import (
+	"bytes"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MediaTypeMsgpack is the content type used for MessagePack bodies.
+const MediaTypeMsgpack = "application/msgpack"
+
+// MsgpackCodec implements BodyCodec for MessagePack.
+// Struct fields use their json tags so the same types serve JSON and msgpack clients.
+type MsgpackCodec struct{}
+
+// ContentType returns the MessagePack media type.
+func (MsgpackCodec) ContentType() string {
+	return MediaTypeMsgpack
+}
+
+// Marshal encodes v as MessagePack.
+func (MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes MessagePack data into v.
+func (MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+
+	return dec.Decode(v)
+}
+
+// NewMsgpackDecoder creates a MessagePack request body decoder with optional validation.
+func NewMsgpackDecoder[T any](validator *validator.Validate) *CodecDecoder[T] {
+	return NewCodecDecoder[T](validator, MsgpackCodec{})
+}
+
+// NewMsgpackEncoder creates a MessagePack response encoder.
+func NewMsgpackEncoder[T any]() *CodecEncoder[T] {
+	return NewCodecEncoder[T](MsgpackCodec{})
+}
@@ -0,0 +1,140 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ShipmentAddress struct {
+	Street string `json:"street" validate:"required"`
+	City   string `json:"city"`
+}
+
+type ShipmentLine struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type CreateShipmentRequest struct {
+	Carrier string          `header:"X-Carrier"`
+	Address ShipmentAddress `json:"address" validate:"required"`
+	Lines   []ShipmentLine  `json:"lines" validate:"required,min=1"`
+}
+
+type ShipmentResponse struct {
+	Carrier string          `json:"carrier"`
+	Address ShipmentAddress `json:"address"`
+	Lines   []ShipmentLine  `json:"lines"`
+	Total   int64           `json:"total"`
+}
+
+type createShipmentHandler struct{}
+
+func (h *createShipmentHandler) Handle(_ context.Context, req CreateShipmentRequest) (ShipmentResponse, error) {
+	var total int64
+	for _, line := range req.Lines {
+		total += int64(line.Quantity)
+	}
+
+	return ShipmentResponse{Carrier: req.Carrier, Address: req.Address, Lines: req.Lines, Total: total}, nil
+}
+
+func newShipmentRouter() *TypedRouter {
+	router := NewRouter()
+	POST(router, "/shipments", &createShipmentHandler{}, WithMsgpack())
+
+	return router
+}
+
+func TestMsgpackCodec_RoundTripNestedStructs(t *testing.T) {
+	original := ShipmentResponse{
+		Carrier: "dhl",
+		Address: ShipmentAddress{Street: "1 Main St", City: "Springfield"},
+		Lines:   []ShipmentLine{{SKU: "a", Quantity: 2}, {SKU: "b", Quantity: 3}},
+		Total:   5,
+	}
+
+	data, err := MsgpackCodec{}.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded ShipmentResponse
+	require.NoError(t, MsgpackCodec{}.Unmarshal(data, &decoded))
+	assert.Equal(t, original, decoded)
+}
+
+func TestWithMsgpack_ServesMsgpackClients(t *testing.T) {
+	body, err := MsgpackCodec{}.Marshal(CreateShipmentRequest{
+		Address: ShipmentAddress{Street: "1 Main St", City: "Springfield"},
+		Lines:   []ShipmentLine{{SKU: "a", Quantity: 2}, {SKU: "b", Quantity: 3}},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/shipments", bytes.NewReader(body))
+	req.Header.Set("Content-Type", MediaTypeMsgpack)
+	req.Header.Set("Accept", MediaTypeMsgpack)
+	req.Header.Set("X-Carrier", "dhl")
+	w := httptest.NewRecorder()
+	newShipmentRouter().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, MediaTypeMsgpack, w.Header().Get("Content-Type"))
+
+	var resp ShipmentResponse
+	require.NoError(t, MsgpackCodec{}.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, ShipmentResponse{
+		Carrier: "dhl",
+		Address: ShipmentAddress{Street: "1 Main St", City: "Springfield"},
+		Lines:   []ShipmentLine{{SKU: "a", Quantity: 2}, {SKU: "b", Quantity: 3}},
+		Total:   5,
+	}, resp)
+}
+
+func TestWithMsgpack_StillServesJSONClients(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/shipments",
+		bytes.NewReader([]byte(`{"address":{"street":"1 Main St"},"lines":[{"sku":"a","quantity":1}]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/html,application/json;q=0.9,*/*;q=0.8")
+	w := httptest.NewRecorder()
+	newShipmentRouter().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var resp ShipmentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(1), resp.Total)
+}
+
+func TestWithMsgpack_ValidatesLikeJSON(t *testing.T) {
+	body, err := MsgpackCodec{}.Marshal(CreateShipmentRequest{Address: ShipmentAddress{City: "Springfield"}})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/shipments", bytes.NewReader(body))
+	req.Header.Set("Content-Type", MediaTypeMsgpack)
+	w := httptest.NewRecorder()
+	newShipmentRouter().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_ERROR")
+}
+
+func TestNewMsgpackDecoder(t *testing.T) {
+	body, err := MsgpackCodec{}.Marshal(map[string]interface{}{"address": map[string]string{"street": "x"}})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/shipments", bytes.NewReader(body))
+	req.Header.Set("Content-Type", MediaTypeMsgpack)
+
+	_, err = NewMsgpackDecoder[CreateShipmentRequest](getGlobalValidator()).Decode(req)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, "required", valErr.Fields["lines"])
+}
@@ -0,0 +1,88 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UploadAttachmentRequest struct {
+	Title string                `form:"title"`
+	File  *multipart.FileHeader `form:"file"`
+}
+
+type UploadAttachmentResponse struct {
+	Title    string `json:"title"`
+	OnDisk   bool   `json:"on_disk"`
+	Filename string `json:"filename"`
+}
+
+type uploadAttachmentHandler struct{}
+
+func (h *uploadAttachmentHandler) Handle(_ context.Context, req UploadAttachmentRequest) (UploadAttachmentResponse, error) {
+	file, err := req.File.Open()
+	if err != nil {
+		return UploadAttachmentResponse{}, err
+	}
+	defer file.Close()
+
+	_, onDisk := file.(*os.File)
+
+	return UploadAttachmentResponse{Title: req.Title, OnDisk: onDisk, Filename: req.File.Filename}, nil
+}
+
+func newAttachmentUpload(t *testing.T, size int) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("title", "report"))
+	part, err := writer.CreateFormFile("file", "report.bin")
+	require.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("a"), size))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/attachments", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestWithMaxMultipartMemory_SpillsLargeFilesToDisk(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/attachments", &uploadAttachmentHandler{}, WithMaxMultipartMemory(1024))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newAttachmentUpload(t, 8*1024))
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"title":"report","on_disk":true,"filename":"report.bin"}`, w.Body.String())
+}
+
+func TestWithMaxMultipartMemory_DefaultKeepsSmallFilesInMemory(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/attachments", &uploadAttachmentHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newAttachmentUpload(t, 8*1024))
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"title":"report","on_disk":false,"filename":"report.bin"}`, w.Body.String())
+}
+
+func TestWithMaxMultipartMemory_RecordedInRegistration(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/attachments", &uploadAttachmentHandler{}, WithMaxMultipartMemory(32<<20))
+
+	handlers := router.GetHandlers()
+	require.Len(t, handlers, 1)
+	assert.Equal(t, int64(32<<20), handlers[0].Config.MaxMultipartMemory)
+}
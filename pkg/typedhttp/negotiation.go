@@ -0,0 +1,89 @@
+package typedhttp
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptedMediaType is a single entry of an Accept header.
+type acceptedMediaType struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept parses an Accept header into media types ordered by preference.
+func parseAccept(header string) []acceptedMediaType {
+	var accepted []acceptedMediaType
+
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		if quality > 0 {
+			accepted = append(accepted, acceptedMediaType{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	return accepted
+}
+
+// negotiateContentType returns the offer that best matches the Accept header.
+// Offers are listed in server preference order; the first offer wins for
+// wildcards and for an empty Accept header. It returns "" when nothing matches.
+func negotiateContentType(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	for _, accepted := range parseAccept(accept) {
+		for _, offer := range offers {
+			if mediaTypeMatches(accepted.mediaType, offer) {
+				return offer
+			}
+		}
+	}
+
+	return ""
+}
+
+// mediaTypeMatches reports whether an accepted media range covers the offered type.
+func mediaTypeMatches(accepted, offer string) bool {
+	if accepted == "*/*" || strings.EqualFold(accepted, offer) {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(accepted, "/*"); ok {
+		return strings.HasPrefix(strings.ToLower(offer), strings.ToLower(prefix)+"/")
+	}
+
+	return false
+}
+
+// mediaTypeOf returns the media type of a Content-Type header without parameters.
+func mediaTypeOf(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
@@ -0,0 +1,31 @@
+package typedhttp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/json", MediaTypeMsgpack}
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{name: "empty accept uses first offer", accept: "", expected: "application/json"},
+		{name: "exact match", accept: MediaTypeMsgpack, expected: MediaTypeMsgpack},
+		{name: "wildcard uses first offer", accept: "*/*", expected: "application/json"},
+		{name: "quality ordering", accept: "application/json;q=0.5, application/msgpack", expected: MediaTypeMsgpack},
+		{name: "subtype wildcard", accept: "application/*", expected: "application/json"},
+		{name: "zero quality is excluded", accept: "application/json;q=0, application/msgpack;q=0.1", expected: MediaTypeMsgpack},
+		{name: "no match", accept: "text/html", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateContentType(tt.accept, offers))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package typedhttp
+
+// Normalizer is implemented by request types that clean up their own values after
+// binding, e.g. trimming strings or lowercasing emails, instead of per-field transform tags.
+//
+// Decoders call Normalize once every field is bound and before validation, so validation
+// rules see the normalized values. The combined decoder may also normalize the body part
+// of a request on its own before merging it, so Normalize should be idempotent.
+// A returned error fails the request and is passed to the error mapper as is.
+type Normalizer interface {
+	Normalize() error
+}
+
+// normalize calls Normalize when the request type implements Normalizer.
+func normalize[T any](result *T) error {
+	if normalizer, ok := any(result).(Normalizer); ok {
+		return normalizer.Normalize()
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type normalizedSignupRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required"`
+}
+
+func (r *normalizedSignupRequest) Normalize() error {
+	r.Email = strings.ToLower(strings.TrimSpace(r.Email))
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	if r.Name == "root" {
+		return NewHTTPError(http.StatusUnprocessableEntity, "RESERVED_NAME", "name is reserved")
+	}
+
+	return nil
+}
+
+type normalizedSignupHandler struct{}
+
+func (h *normalizedSignupHandler) Handle(_ context.Context, req normalizedSignupRequest) (normalizedSignupRequest, error) {
+	return req, nil
+}
+
+func TestNormalizer_RunsBeforeValidation(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/signup", &normalizedSignupHandler{})
+
+	body := `{"email":"  Alice@Example.COM ","name":"  Alice   Smith "}`
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var resp normalizedSignupRequest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "alice@example.com", resp.Email)
+	assert.Equal(t, "Alice Smith", resp.Name)
+}
+
+func TestNormalizer_ErrorFailsRequest(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/signup", &normalizedSignupHandler{})
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"email":"a@b.co","name":"root"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "RESERVED_NAME")
+}
+
+type searchRequest struct {
+	Tenant string `path:"tenant"`
+	Query  string `query:"q" validate:"required"`
+}
+
+func (r *searchRequest) Normalize() error {
+	r.Tenant = strings.ToLower(r.Tenant)
+	r.Query = strings.TrimSpace(r.Query)
+
+	return nil
+}
+
+func TestNormalizer_CombinedDecoder(t *testing.T) {
+	decoder := NewCombinedDecoder[searchRequest](getGlobalValidator())
+
+	result, err := decoder.Decode(httptest.NewRequest(http.MethodGet, "/tenants/ACME?q=+shoes+", http.NoBody))
+	require.NoError(t, err)
+	assert.Equal(t, searchRequest{Tenant: "acme", Query: "shoes"}, result)
+
+	_, err = decoder.Decode(httptest.NewRequest(http.MethodGet, "/tenants/ACME?q=+++", http.NoBody))
+	var valErr *ValidationError
+	assert.ErrorAs(t, err, &valErr, "validation sees the trimmed, empty query")
+}
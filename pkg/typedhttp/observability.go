@@ -0,0 +1,164 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Tracer starts spans for requests served by handlers with tracing enabled.
+// Adapters for OpenTelemetry or other tracing systems implement this interface.
+type Tracer interface {
+	Start(ctx context.Context, operation string) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	SetError(err error)
+	End()
+}
+
+// spanKey is the context key for the active request span.
+type spanKey struct{}
+
+// SpanFromContext returns the span started for the current request.
+// It returns a no-op span when tracing is not enabled for the route.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+
+	return noopSpan{}
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) SetError(error)                   {}
+func (noopSpan) End()                             {}
+
+type noopMetricsCollector struct{}
+
+func (noopMetricsCollector) IncRequests(string)                   {}
+func (noopMetricsCollector) IncErrors(string)                     {}
+func (noopMetricsCollector) ObserveLatency(string, time.Duration) {}
+
+// WithObservabilityLogger sets the logger used when logging is enabled.
+func WithObservabilityLogger(logger *slog.Logger) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Observability.Logger = logger
+	}
+}
+
+// WithObservabilityMetrics sets the collector used when metrics are enabled.
+func WithObservabilityMetrics(collector RouteMetricsCollector) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Observability.MetricsCollector = collector
+	}
+}
+
+// WithObservabilityTracer sets the tracer used when tracing is enabled.
+func WithObservabilityTracer(tracer Tracer) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Observability.Tracer = tracer
+	}
+}
+
+// observabilityHandler logs, counts and traces requests according to an ObservabilityConfig.
+// Features that are disabled or have no provider cost nothing beyond a no-op call.
+type observabilityHandler struct {
+	route   RoutePattern
+	name    string // label for spans and metrics; the route pattern when unset
+	logger  *slog.Logger
+	metrics RouteMetricsCollector
+	tracer  Tracer
+	config  ObservabilityConfig
+	next    http.Handler
+}
+
+// newObservabilityHandler wraps next when any observability feature is enabled.
+func newObservabilityHandler(route RoutePattern, name string, config ObservabilityConfig, next http.Handler) http.Handler {
+	if !config.Logging && !config.Metrics && !config.Tracing {
+		return next
+	}
+
+	if name == "" {
+		name = route.String()
+	}
+
+	h := &observabilityHandler{
+		route:   route,
+		name:    name,
+		metrics: noopMetricsCollector{},
+		tracer:  noopTracer{},
+		config:  config,
+		next:    next,
+	}
+	if config.Logging {
+		h.logger = config.Logger
+		if h.logger == nil {
+			h.logger = slog.Default()
+		}
+	}
+	if config.Metrics && config.MetricsCollector != nil {
+		h.metrics = config.MetricsCollector
+	}
+	if config.Tracing && config.Tracer != nil {
+		h.tracer = config.Tracer
+	}
+
+	return h
+}
+
+func (h *observabilityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	route := h.name
+
+	ctx, span := h.tracer.Start(r.Context(), route)
+	span.SetAttribute("http.method", r.Method)
+	span.SetAttribute("http.route", h.route.Path)
+	for k, v := range h.config.TraceAttributes {
+		span.SetAttribute(k, v)
+	}
+	ctx = context.WithValue(ctx, spanKey{}, span)
+
+	recorder := NewResponseRecorder(w)
+	h.next.ServeHTTP(recorder, r.WithContext(ctx))
+	elapsed := time.Since(start)
+
+	span.SetAttribute("http.status_code", recorder.Status())
+	if recorder.Status() >= http.StatusInternalServerError {
+		span.SetError(errors.New(http.StatusText(recorder.Status())))
+	}
+	span.End()
+
+	h.metrics.IncRequests(route)
+	if recorder.Status() >= http.StatusInternalServerError {
+		h.metrics.IncErrors(route)
+	}
+	h.metrics.ObserveLatency(route, elapsed)
+
+	if h.logger != nil {
+		level := slog.LevelInfo
+		if recorder.Status() >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("route", h.route.Path),
+			slog.String("handler", h.name),
+			slog.Int("status", recorder.Status()),
+			slog.Duration("duration", elapsed),
+		}
+		h.logger.LogAttrs(ctx, level, "request completed", attrs...)
+	}
+}
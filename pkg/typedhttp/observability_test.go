@@ -0,0 +1,117 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSpan struct {
+	operation  string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *recordingSpan) SetError(err error)                         { s.err = err }
+func (s *recordingSpan) End()                                       { s.ended = true }
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, operation string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &recordingSpan{operation: operation, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+
+	return ctx, span
+}
+
+type spanCheckingHandler struct {
+	sawSpan bool
+}
+
+func (h *spanCheckingHandler) Handle(ctx context.Context, req metricsTestRequest) (metricsTestResponse, error) {
+	_, h.sawSpan = SpanFromContext(ctx).(*recordingSpan)
+
+	return metricsTestResponse{ID: req.ID}, nil
+}
+
+func TestWithDefaultObservability_LogsCountsAndTraces(t *testing.T) {
+	var logs bytes.Buffer
+	metrics := newMockRouteMetrics()
+	tracer := &recordingTracer{}
+	handler := &spanCheckingHandler{}
+
+	router := NewRouter()
+	GET(router, "/items/{id}", handler,
+		WithDefaultObservability(),
+		WithObservabilityLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		WithObservabilityMetrics(metrics),
+		WithObservabilityTracer(tracer),
+		WithTraceAttributes(map[string]interface{}{"team": "catalog"}),
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Contains(t, logs.String(), `"msg":"request completed"`)
+	assert.Contains(t, logs.String(), `"route":"/items/{id}"`)
+	assert.Contains(t, logs.String(), `"status":200`)
+
+	assert.Equal(t, 1, metrics.requests["GET /items/{id}"])
+	assert.Len(t, metrics.latencies["GET /items/{id}"], 1)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(t, "GET /items/{id}", span.operation)
+	assert.True(t, span.ended)
+	assert.Equal(t, 200, span.attributes["http.status_code"])
+	assert.Equal(t, "catalog", span.attributes["team"])
+	assert.True(t, handler.sawSpan, "handlers can reach the span through the context")
+}
+
+func TestObservability_IndividualFeatures(t *testing.T) {
+	var logs bytes.Buffer
+	metrics := newMockRouteMetrics()
+	tracer := &recordingTracer{}
+
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{},
+		WithMetrics(),
+		WithObservabilityLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		WithObservabilityMetrics(metrics),
+		WithObservabilityTracer(tracer),
+	)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, 1, metrics.requests["GET /items/{id}"])
+	assert.Empty(t, logs.String(), "logging was not enabled")
+	assert.Empty(t, tracer.spans, "tracing was not enabled")
+}
+
+func TestObservability_NoProvidersIsNoop(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{}, WithTracing(), WithMetrics())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, noopSpan{}, SpanFromContext(context.Background()))
+}
@@ -28,6 +28,60 @@ func WithMiddleware(middleware ...Middleware) HandlerOption {
 	}
 }
 
+// WithBodyCodecs lets the handler accept and produce additional body formats.
+// The request format is chosen by Content-Type and the response format by Accept;
+// JSON remains the default for both.
+func WithBodyCodecs(codecs ...BodyCodec) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.BodyCodecs = append(cfg.BodyCodecs, codecs...)
+	}
+}
+
+// WithMsgpack lets the handler serve application/msgpack alongside JSON.
+func WithMsgpack() HandlerOption {
+	return WithBodyCodecs(MsgpackCodec{})
+}
+
+// WithTranslator localizes validation errors using the request's Accept-Language header.
+func WithTranslator(translator *Translator) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Translator = translator
+	}
+}
+
+// WithMaxMultipartMemory sets how many bytes of a multipart form are kept in memory
+// before file parts are written to temporary files. The default is MaxFormMemory (32MB).
+func WithMaxMultipartMemory(maxMemory int64) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.MaxMultipartMemory = maxMemory
+	}
+}
+
+// WithoutValidation binds request fields without running validate tag rules, for handlers
+// that validate conditionally themselves. The rules are still documented in the OpenAPI spec.
+// It has no effect on a decoder set with WithDecoder.
+func WithoutValidation() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.SkipValidation = true
+	}
+}
+
+// WithResponseStatus sets the success status of the route, replacing the method default.
+// A response implementing StatusCoder still takes precedence.
+func WithResponseStatus(status int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.ResponseStatus = status
+	}
+}
+
+// WithSuccessStatuses declares the success statuses a route may return, typically
+// because its response implements StatusCoder. They are documented in the OpenAPI spec.
+func WithSuccessStatuses(statuses ...int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.SuccessStatuses = append(cfg.SuccessStatuses, statuses...)
+	}
+}
+
 // WithOpenAPI sets OpenAPI metadata for the handler.
 func WithOpenAPI(metadata *OpenAPIMetadata) HandlerOption {
 	return func(cfg *HandlerConfig) {
@@ -42,6 +96,33 @@ func WithTags(tags ...string) HandlerOption {
 	}
 }
 
+// WithProduces documents the response media types the handler can produce, such as
+// "text/csv" from a custom encoder. Media types of body codecs are listed automatically.
+func WithProduces(mediaTypes ...string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Produces = append(cfg.Produces, mediaTypes...)
+	}
+}
+
+// WithConsumes restricts the request body media types the handler accepts, e.g.
+// "multipart/form-data" or "image/*". Requests whose body has another Content-Type are
+// rejected with 415 Unsupported Media Type before decoding. The OpenAPI document lists
+// exactly these media types for the request body, instead of the ones inferred from
+// the field tags.
+func WithConsumes(mediaTypes ...string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Consumes = append(cfg.Consumes, mediaTypes...)
+	}
+}
+
+// WithOperationID sets the OpenAPI operationId for the handler, replacing the one
+// derived from the method and path.
+func WithOperationID(id string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Metadata.OperationID = id
+	}
+}
+
 // WithSummary sets the OpenAPI summary for the handler.
 func WithSummary(summary string) HandlerOption {
 	return func(cfg *HandlerConfig) {
@@ -56,6 +137,14 @@ func WithDescription(description string) HandlerOption {
 	}
 }
 
+// WithHandlerName sets a logical name that observability labels the route by instead
+// of its pattern. Several routes may share a name, e.g. versioned aliases of one handler.
+func WithHandlerName(name string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.Name = name
+	}
+}
+
 // WithObservability sets observability configuration for the handler.
 func WithObservability(config ObservabilityConfig) HandlerOption {
 	return func(cfg *HandlerConfig) {
@@ -63,13 +152,15 @@ func WithObservability(config ObservabilityConfig) HandlerOption {
 	}
 }
 
-// WithDefaultObservability enables default observability features.
+// WithDefaultObservability enables logging, metrics and tracing for the handler.
+// Logs go to slog.Default(); metrics and spans are no-ops until a provider is set
+// with WithObservabilityMetrics or WithObservabilityTracer. Providers already set are kept.
 func WithDefaultObservability() HandlerOption {
-	return WithObservability(ObservabilityConfig{
-		Tracing: true,
-		Metrics: true,
-		Logging: true,
-	})
+	return func(cfg *HandlerConfig) {
+		cfg.Observability.Tracing = true
+		cfg.Observability.Metrics = true
+		cfg.Observability.Logging = true
+	}
 }
 
 // WithTracing enables request tracing for the handler.
@@ -93,6 +184,14 @@ func WithLogging() HandlerOption {
 	}
 }
 
+// WithMiddlewareEntries adds configured middleware entries to the handler, such as
+// those returned by MiddlewareBuilder.Build. Entries run by priority, highest first.
+func WithMiddlewareEntries(entries ...MiddlewareEntry) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.TypedMiddleware = append(cfg.TypedMiddleware, entries...)
+	}
+}
+
 // WithTypedPreMiddleware adds a typed pre-middleware to the handler.
 func WithTypedPreMiddleware[TRequest any](middleware TypedPreMiddleware[TRequest]) HandlerOption {
 	return func(cfg *HandlerConfig) {
@@ -107,6 +206,29 @@ func WithTypedPreMiddleware[TRequest any](middleware TypedPreMiddleware[TRequest
 	}
 }
 
+// WithTypedRequestMiddleware adds a typed request middleware to the handler.
+// Middleware run in registration order after decoding and before the handler.
+func WithTypedRequestMiddleware[TRequest any](middleware TypedRequestMiddleware[TRequest]) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		entry := MiddlewareEntry{
+			Middleware: middleware,
+			Config: MiddlewareConfig{
+				Name:  "typed_request_middleware",
+				Scope: ScopeHandler,
+			},
+		}
+		cfg.TypedMiddleware = append(cfg.TypedMiddleware, entry)
+	}
+}
+
+// WithResponseInterceptor adds a response interceptor to the handler.
+// Handler interceptors run after any registered on the router with WithResponseInterceptors.
+func WithResponseInterceptor[TResponse any](interceptor ResponseInterceptor[TResponse]) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.ResponseInterceptors = append(cfg.ResponseInterceptors, interceptor)
+	}
+}
+
 // WithTypedPostMiddleware adds a typed post-middleware to the handler.
 func WithTypedPostMiddleware[TResponse any](middleware TypedPostMiddleware[TResponse]) HandlerOption {
 	return func(cfg *HandlerConfig) {
@@ -0,0 +1,97 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicError is the error passed to the error mapper when a handler panics.
+// The default error mapper answers it with 500 Internal Server Error.
+type PanicError struct {
+	Value interface{} // Value passed to panic
+	Stack []byte      // Stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
+
+// WithoutPanicRecovery lets handler panics propagate to http.Server, which logs them and
+// drops the connection without a response. Use it when an outer middleware recovers instead.
+func WithoutPanicRecovery() RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.DisablePanicRecovery = true
+	}
+}
+
+// WithPanicLogger sets the logger that records recovered panics. It defaults to slog.Default().
+func WithPanicLogger(logger *slog.Logger) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.PanicLogger = logger
+	}
+}
+
+// recoverPanic turns a panic in the handler or its middleware into an error response.
+// It must be deferred directly. http.ErrAbortHandler is re-raised so that net/http
+// can abort the response as intended.
+func (h *HTTPHandler[TRequest, TResponse]) recoverPanic(w http.ResponseWriter, r *http.Request) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	if err, ok := value.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+		panic(value)
+	}
+
+	panicErr := &PanicError{Value: value, Stack: debug.Stack()}
+
+	logger := h.panicLogger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.LogAttrs(r.Context(), slog.LevelError, "panic recovered",
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Any("panic", value),
+		slog.String("stack", string(panicErr.Stack)),
+	)
+
+	h.handleError(w, r, panicErr)
+}
+
+// recoverPanics wraps a plain http.Handler in the router's panic recovery, unless it is
+// disabled, so it answers panics like typed routes. opts may set the error mapper.
+func (r *TypedRouter) recoverPanics(handler http.Handler, opts ...HandlerOption) http.Handler {
+	if r.config.DisablePanicRecovery {
+		return handler
+	}
+
+	recovery := NewHTTPHandler[struct{}, any](nil, opts...)
+	recovery.panicLogger = r.config.PanicLogger
+
+	return &recoveryHandler{recovery: recovery, next: handler}
+}
+
+// recoveryHandler answers panics of a plain http.Handler with an error response.
+type recoveryHandler struct {
+	recovery *HTTPHandler[struct{}, any] // Logs, maps and writes the panic
+	next     http.Handler
+}
+
+func (h *recoveryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer h.recovery.recoverPanic(w, r)
+
+	h.next.ServeHTTP(w, r)
+}
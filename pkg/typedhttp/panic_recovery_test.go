@@ -0,0 +1,106 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panickingHandler struct {
+	value interface{}
+}
+
+func (h *panickingHandler) Handle(_ context.Context, req metricsTestRequest) (metricsTestResponse, error) {
+	if req.ID == "boom" {
+		panic(h.value)
+	}
+
+	return metricsTestResponse{ID: req.ID}, nil
+}
+
+func TestRouter_RecoversPanicsByDefault(t *testing.T) {
+	var logs bytes.Buffer
+	router := NewRouter(WithPanicLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+	GET(router, "/items/{id}", &panickingHandler{value: "nil map write"})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/boom", http.NoBody))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"Internal server error","code":"INTERNAL_ERROR"}`, w.Body.String())
+	assert.Contains(t, logs.String(), `"msg":"panic recovered"`)
+	assert.Contains(t, logs.String(), `"panic":"nil map write"`)
+	assert.Contains(t, logs.String(), "goroutine", "the stack trace is logged")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/42", http.NoBody))
+	assert.Equal(t, http.StatusOK, w.Code, "the router keeps serving after a panic")
+}
+
+// recordingErrorMapper keeps the last error it mapped.
+type recordingErrorMapper struct {
+	mapped error
+}
+
+func (m *recordingErrorMapper) MapError(err error) (int, interface{}) {
+	m.mapped = err
+
+	return http.StatusServiceUnavailable, ErrorResponse{Error: "try again later"}
+}
+
+func TestRouter_PanicGoesThroughErrorMapper(t *testing.T) {
+	cause := errors.New("inventory unavailable")
+	mapper := &recordingErrorMapper{}
+
+	router := NewRouter(WithPanicLogger(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))))
+	GET(router, "/items/{id}", &panickingHandler{value: cause}, WithErrorMapper(mapper))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/boom", http.NoBody))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var panicErr *PanicError
+	require.ErrorAs(t, mapper.mapped, &panicErr)
+	assert.Equal(t, cause, panicErr.Value)
+	assert.ErrorIs(t, mapper.mapped, cause)
+}
+
+func TestWithoutPanicRecovery(t *testing.T) {
+	router := NewRouter(WithoutPanicRecovery())
+	GET(router, "/items/{id}", &panickingHandler{value: "boom"})
+
+	assert.PanicsWithValue(t, "boom", func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/boom", http.NoBody))
+	})
+}
+
+func TestRouter_ReraisesAbortHandler(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &panickingHandler{value: http.ErrAbortHandler})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/boom", http.NoBody))
+	})
+}
+
+func TestRouter_RecoversPanicsInPlainHandlers(t *testing.T) {
+	var logs bytes.Buffer
+	router := NewRouter(WithPanicLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+	router.Handle(http.MethodGet, "/healthz", http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("probe failed")
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", http.NoBody))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"Internal server error","code":"INTERNAL_ERROR"}`, w.Body.String())
+	assert.Contains(t, logs.String(), `"panic":"probe failed"`)
+}
@@ -6,6 +6,7 @@ import (
 	"mime/multipart"
 	"net"
 	"net/http"
+	"net/netip"
 	"reflect"
 	"strings"
 	"time"
@@ -55,30 +56,38 @@ func (d *PathDecoder[T]) Decode(r *http.Request) (T, error) {
 		}
 
 		// Extract path parameter from URL
-		pathValue := extractPathParam(r.URL.Path, pathName)
+		pathValue := extractPathParam(r, pathName)
 		if pathValue == "" {
 			continue
 		}
 
+		message := decodeErrorMessage(SourcePath, pathName, "")
+
+		// Parse values with an explicit format, such as uuid or a time layout
+		if format := field.Tag.Get("format"); format != "" {
+			formatted, err := applyFormat(format, pathValue, field.Type)
+			if err != nil {
+				return result, newDecodeError(SourcePath, field.Name, pathName, message, err)
+			}
+			fieldValue.Set(reflect.ValueOf(formatted))
+
+			continue
+		}
+
 		// Set the field value based on its type
 		if err := setFieldValueFromString(fieldValue, pathValue); err != nil {
-			return result, fmt.Errorf("failed to set field %s: %w", field.Name, err)
+			return result, newDecodeError(SourcePath, field.Name, pathName, message, err)
 		}
 	}
 
+	if err := normalize(&result); err != nil {
+		return result, err
+	}
+
 	// Perform validation if validator is available
 	if d.validator != nil {
 		if err := d.validator.Struct(result); err != nil {
-			validationErrors := make(map[string]string)
-			var validatorErrs validator.ValidationErrors
-			if errors.As(err, &validatorErrs) {
-				for _, validatorErr := range validatorErrs {
-					field := strings.ToLower(validatorErr.Field())
-					validationErrors[field] = validatorErr.Tag()
-				}
-			}
-
-			return result, NewValidationError("Validation failed", validationErrors)
+			return result, newValidationErrorFromValidator("Validation failed", err)
 		}
 	}
 
@@ -91,14 +100,15 @@ func (d *PathDecoder[T]) ContentTypes() []string {
 }
 
 // extractPathParam extracts a path parameter from a URL path.
-// This is a simple implementation that works with the {param} format.
-func extractPathParam(path, _ string) string {
-	// This is a basic implementation
-	// In a real router, you'd use the router's path matching capabilities
-
-	// For now, we'll extract the last segment
-	// This is a simplified approach for the example
-	segments := strings.Split(strings.Trim(path, "/"), "/")
+// Requests routed by the router carry the wildcards matched by its pattern, and a
+// name the pattern does not have is missing. Requests that were not routed use the
+// last path segment, which works for /users/{id}.
+func extractPathParam(r *http.Request, name string) string {
+	if value := r.PathValue(name); value != "" || r.Pattern != "" {
+		return value
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 	if len(segments) > 0 {
 		// Return the last segment as the parameter value
 		// This works for simple cases like /users/{id}
@@ -128,6 +138,17 @@ type FieldSource struct {
 	Transform string
 	Format    string
 	Required  bool
+	// HeaderValues is the header_values mode used for slice fields of header sources.
+	HeaderValues string
+	// Aliases are alternative names tried in order after Name.
+	Aliases []string
+	// Style is the style tag of slice query sources; see FieldQueryStyle.
+	Style string
+}
+
+// names returns the source name followed by its aliases.
+func (s *FieldSource) names() []string {
+	return append([]string{s.Name}, s.Aliases...)
 }
 
 // FieldExtractor contains information about how to extract a single field from the request.
@@ -141,14 +162,21 @@ type FieldExtractor struct {
 
 // CombinedDecoder combines multiple decoders to handle different types of request data with precedence rules.
 type CombinedDecoder[T any] struct {
-	pathDecoder   *PathDecoder[T]
-	queryDecoder  *QueryDecoder[T]
-	headerDecoder *HeaderDecoder[T]
-	cookieDecoder *CookieDecoder[T]
-	formDecoder   *FormDecoder[T]
-	jsonDecoder   *JSONDecoder[T]
-	extractors    []FieldExtractor // Pre-computed field extraction rules
-	validator     *validator.Validate
+	pathDecoder    *PathDecoder[T]
+	queryDecoder   *QueryDecoder[T]
+	headerDecoder  *HeaderDecoder[T]
+	cookieDecoder  *CookieDecoder[T]
+	formDecoder    *FormDecoder[T]
+	jsonDecoder    *JSONDecoder[T]
+	bodyDecoders   map[string]RequestDecoder[T] // Additional body decoders keyed by media type
+	bodyCodecs     map[string]BodyCodec         // Additional body codecs keyed by media type
+	body           *bodyBinding                 // Body-tagged fields, nil when the whole struct is the body
+	extractors     []FieldExtractor             // Pre-computed field extraction rules
+	namedFields    bool                         // Untagged fields are body fields named by a FieldNameStrategy
+	meta           []metaField                  // Fields bound to request metadata
+	principal      []principalField             // Fields bound to the authenticated principal
+	trustedProxies []netip.Prefix               // Proxies trusted to set X-Forwarded-For
+	validator      *validator.Validate
 }
 
 // NewCombinedDecoder creates a decoder that can handle multiple data sources.
@@ -159,12 +187,15 @@ func NewCombinedDecoder[T any](validator *validator.Validate) *CombinedDecoder[T
 		headerDecoder: NewHeaderDecoder[T](validator),
 		cookieDecoder: NewCookieDecoder[T](validator),
 		formDecoder:   NewFormDecoder[T](validator),
-		jsonDecoder:   NewJSONDecoder[T](validator),
+		jsonDecoder:   NewJSONDecoder[T](nil), // Validated with the other sources once every field is bound
 		validator:     validator,
 	}
 
 	// Pre-compute field extraction rules
 	decoder.extractors = decoder.buildFieldExtractors()
+	decoder.body = newBodyBinding(reflect.TypeOf((*T)(nil)).Elem())
+	decoder.meta = newMetaFields(reflect.TypeOf((*T)(nil)).Elem())
+	decoder.principal = newPrincipalFields(reflect.TypeOf((*T)(nil)).Elem())
 
 	return decoder
 }
@@ -198,8 +229,9 @@ func (d *CombinedDecoder[T]) buildFieldExtractors() []FieldExtractor {
 		// Check for each source type
 		if pathName := field.Tag.Get("path"); pathName != "" {
 			extractor.Sources = append(extractor.Sources, FieldSource{
-				Type: SourcePath,
-				Name: pathName,
+				Type:   SourcePath,
+				Name:   pathName,
+				Format: field.Tag.Get("format"),
 			})
 		}
 
@@ -208,24 +240,31 @@ func (d *CombinedDecoder[T]) buildFieldExtractors() []FieldExtractor {
 				Type:    SourceQuery,
 				Name:    queryName,
 				Default: field.Tag.Get("default"),
+				Format:  field.Tag.Get("format"),
+				Aliases: FieldAliases(field),
+				Style:   field.Tag.Get("style"),
 			})
 		}
 
 		if headerName := field.Tag.Get("header"); headerName != "" {
 			extractor.Sources = append(extractor.Sources, FieldSource{
-				Type:      SourceHeader,
-				Name:      headerName,
-				Default:   field.Tag.Get("default"),
-				Transform: field.Tag.Get("transform"),
-				Format:    field.Tag.Get("format"),
+				Type:         SourceHeader,
+				Name:         headerName,
+				Default:      field.Tag.Get("default"),
+				Transform:    field.Tag.Get("transform"),
+				Format:       field.Tag.Get("format"),
+				HeaderValues: field.Tag.Get("header_values"),
+				Aliases:      FieldAliases(field),
 			})
 		}
 
-		if cookieName := field.Tag.Get("cookie"); cookieName != "" {
+		if cookieName := field.Tag.Get("cookie"); cookieName != "" && cookieName != CookieCatchAll {
 			extractor.Sources = append(extractor.Sources, FieldSource{
 				Type:    SourceCookie,
 				Name:    cookieName,
 				Default: field.Tag.Get("default"),
+				Format:  field.Tag.Get("format"),
+				Aliases: FieldAliases(field),
 			})
 		}
 
@@ -234,6 +273,8 @@ func (d *CombinedDecoder[T]) buildFieldExtractors() []FieldExtractor {
 				Type:    SourceForm,
 				Name:    formName,
 				Default: field.Tag.Get("default"),
+				Format:  field.Tag.Get("format"),
+				Aliases: FieldAliases(field),
 			})
 		}
 
@@ -274,7 +315,8 @@ func (d *CombinedDecoder[T]) buildFieldExtractors() []FieldExtractor {
 func (d *CombinedDecoder[T]) Decode(r *http.Request) (T, error) {
 	var result T
 
-	if err := d.extractFieldsFromSources(r, &result); err != nil {
+	decodeErrs, err := d.extractFieldsFromSources(r, &result)
+	if err != nil {
 		return result, err
 	}
 
@@ -282,7 +324,23 @@ func (d *CombinedDecoder[T]) Decode(r *http.Request) (T, error) {
 		return result, err
 	}
 
-	if err := d.validateCombinedResult(result); err != nil {
+	if len(d.meta) > 0 {
+		if err := bindMeta(r, d.meta, d.trustedProxies, reflect.ValueOf(&result).Elem()); err != nil {
+			return result, err
+		}
+	}
+
+	if len(d.principal) > 0 {
+		if err := bindPrincipal(r.Context(), d.principal, reflect.ValueOf(&result).Elem()); err != nil {
+			return result, err
+		}
+	}
+
+	if err := normalize(&result); err != nil {
+		return result, err
+	}
+
+	if err := d.validateCombinedResult(result, decodeErrs); err != nil {
 		return result, err
 	}
 
@@ -290,8 +348,10 @@ func (d *CombinedDecoder[T]) Decode(r *http.Request) (T, error) {
 }
 
 // extractFieldsFromSources extracts data for each field using the pre-computed extractors.
-func (d *CombinedDecoder[T]) extractFieldsFromSources(r *http.Request, result *T) error {
+// Conversion errors are collected so that every field is attempted.
+func (d *CombinedDecoder[T]) extractFieldsFromSources(r *http.Request, result *T) ([]*DecodeError, error) {
 	resultValue := reflect.ValueOf(result).Elem()
+	var decodeErrs []*DecodeError
 
 	for _, extractor := range d.extractors {
 		fieldValue := resultValue.FieldByName(extractor.FieldName)
@@ -300,26 +360,53 @@ func (d *CombinedDecoder[T]) extractFieldsFromSources(r *http.Request, result *T
 		}
 
 		if err := d.processFieldExtractor(r, &extractor, fieldValue); err != nil {
-			return err
+			if err = collectDecodeError(&decodeErrs, err); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return nil
+	return decodeErrs, nil
 }
 
 // processFieldExtractor processes a single field extractor.
 func (d *CombinedDecoder[T]) processFieldExtractor(
 	r *http.Request, extractor *FieldExtractor, fieldValue reflect.Value,
 ) error {
+	if isHeaderSlice(extractor.FieldType) {
+		if source := d.findSourceConfig(extractor.Sources, SourceHeader); source != nil {
+			return d.processHeaderSlice(r, extractor, source, fieldValue)
+		}
+	}
+
+	if isNestedQueryType(extractor.FieldType) {
+		if source := d.findSourceConfig(extractor.Sources, SourceQuery); source != nil {
+			style, err := parseQueryStyle(source.Style)
+			if err == nil {
+				err = decodeNestedQuery(r.URL.Query(), source.Name, style, fieldValue)
+			}
+			if err != nil {
+				return newDecodeError(SourceQuery, extractor.FieldName, source.Name, "failed to set field "+extractor.FieldName, err)
+			}
+
+			return nil
+		}
+	}
+
 	extractedValue, sourceFound := d.extractValueWithPrecedence(r, extractor)
 
 	if extractedValue == "" {
 		return nil
 	}
 
+	var param string
+	if sourceConfig := d.findSourceConfig(extractor.Sources, sourceFound); sourceConfig != nil {
+		param = sourceConfig.Name
+	}
+
 	processedValue, transformedValue, err := d.processExtractedValue(extractor, extractedValue, sourceFound)
 	if err != nil {
-		return err
+		return newDecodeError(sourceFound, extractor.FieldName, param, decodeErrorMessage(sourceFound, param, ""), err)
 	}
 
 	if processedValue != nil {
@@ -335,12 +422,36 @@ func (d *CombinedDecoder[T]) processFieldExtractor(
 	}
 
 	if err := setFieldValueFromString(fieldValue, valueToUse); err != nil {
-		return fmt.Errorf("failed to set field %s: %w", extractor.FieldName, err)
+		message := decodeErrorMessage(sourceFound, param, "failed to set field "+extractor.FieldName)
+
+		return newDecodeError(sourceFound, extractor.FieldName, param, message, err)
+	}
+
+	return nil
+}
+
+// processHeaderSlice collects every value of the header into a slice field.
+func (d *CombinedDecoder[T]) processHeaderSlice(
+	r *http.Request, extractor *FieldExtractor, source *FieldSource, fieldValue reflect.Value,
+) error {
+	values := headerValues(r, source.names(), source.HeaderValues, source.Default)
+	if err := setHeaderSlice(fieldValue, values, source.Transform); err != nil {
+		return newDecodeError(SourceHeader, extractor.FieldName, source.Name, "failed to set field "+extractor.FieldName, err)
 	}
 
 	return nil
 }
 
+// decodeErrorMessage returns the prefix of a conversion error message. Path parameters
+// are named explicitly, so a malformed id reads as a bad request rather than a missing route.
+func decodeErrorMessage(source SourceType, param, fallback string) string {
+	if source == SourcePath {
+		return "invalid path parameter " + param
+	}
+
+	return fallback
+}
+
 // extractValueWithPrecedence tries each source in precedence order.
 func (d *CombinedDecoder[T]) extractValueWithPrecedence(
 	r *http.Request, extractor *FieldExtractor,
@@ -351,13 +462,15 @@ func (d *CombinedDecoder[T]) extractValueWithPrecedence(
 			continue
 		}
 
-		value, err := d.extractFromSource(r, sourceType, sourceConfig.Name)
-		if err != nil {
-			continue
-		}
+		for _, name := range sourceConfig.names() {
+			value, err := d.extractFromSource(r, sourceType, name)
+			if err != nil {
+				break
+			}
 
-		if value != "" {
-			return value, sourceType
+			if value != "" {
+				return value, sourceType
+			}
 		}
 	}
 
@@ -465,33 +578,55 @@ func (d *CombinedDecoder[T]) applyFormatForSource(
 	return nil, nil
 }
 
-// validateCombinedResult validates the final combined result.
-func (d *CombinedDecoder[T]) validateCombinedResult(result T) error {
-	if d.validator == nil {
-		return nil
+// validateCombinedResult validates the final combined result, reporting conversion errors with it.
+func (d *CombinedDecoder[T]) validateCombinedResult(result T, decodeErrs []*DecodeError) error {
+	var validationErr error
+	if d.validator != nil {
+		validationErr = d.validator.Struct(result)
 	}
 
-	if err := d.validator.Struct(result); err != nil {
-		validationErrors := make(map[string]string)
-		var validatorErrs validator.ValidationErrors
-		if errors.As(err, &validatorErrs) {
-			for _, validatorErr := range validatorErrs {
-				field := strings.ToLower(validatorErr.Field())
-				validationErrors[field] = validatorErr.Tag()
-			}
-		}
+	return aggregateRequestErrors("Multi-source validation failed", decodeErrs, validationErr)
+}
+
+// addBodyCodec enables decoding request bodies sent in the codec's content type.
+func (d *CombinedDecoder[T]) addBodyCodec(codec BodyCodec) {
+	if d.bodyDecoders == nil {
+		d.bodyDecoders = make(map[string]RequestDecoder[T])
+		d.bodyCodecs = make(map[string]BodyCodec)
+	}
+	d.bodyDecoders[codec.ContentType()] = NewCodecDecoder[T](nil, codec)
+	d.bodyCodecs[codec.ContentType()] = codec
+}
 
-		return NewValidationError("Multi-source validation failed", validationErrors)
+// bodyDecoderFor returns the decoder for a request body content type, or nil if unsupported.
+func (d *CombinedDecoder[T]) bodyDecoderFor(contentType string) RequestDecoder[T] {
+	if strings.Contains(contentType, "application/json") {
+		return d.jsonDecoder
+	}
+
+	if decoder, ok := d.bodyDecoders[mediaTypeOf(contentType)]; ok {
+		return decoder
 	}
 
 	return nil
 }
 
+// setFieldNames names untagged body fields with a FieldNameStrategy.
+func (d *CombinedDecoder[T]) setFieldNames(names *fieldNamer) {
+	d.jsonDecoder.names = names
+	d.namedFields = hasStrategyNamedFields(reflect.TypeOf((*T)(nil)).Elem(), names.strategy)
+}
+
+// setMaxMultipartMemory sets the in-memory limit used when parsing multipart forms.
+func (d *CombinedDecoder[T]) setMaxMultipartMemory(maxMemory int64) {
+	d.formDecoder.maxMemory = maxMemory
+}
+
 // extractFromSource extracts a value from a specific source type.
 func (d *CombinedDecoder[T]) extractFromSource(r *http.Request, sourceType SourceType, name string) (string, error) {
 	switch sourceType {
 	case SourcePath:
-		return extractPathParam(r.URL.Path, name), nil
+		return extractPathParam(r, name), nil
 
 	case SourceQuery:
 		return r.URL.Query().Get(name), nil
@@ -507,7 +642,7 @@ func (d *CombinedDecoder[T]) extractFromSource(r *http.Request, sourceType Sourc
 		return "", nil
 
 	case SourceForm:
-		if err := r.ParseForm(); err != nil {
+		if err := d.formDecoder.parseFormData(r); err != nil {
 			return "", fmt.Errorf("failed to parse form: %w", err)
 		}
 
@@ -534,7 +669,7 @@ func (d *CombinedDecoder[T]) handleSpecialCases(r *http.Request, result *T) erro
 	}
 
 	// Check if we need to handle JSON body or file uploads
-	needsJSON := false
+	needsJSON := d.namedFields
 	needsForm := false
 
 	for i := 0; i < resultType.NumField(); i++ {
@@ -548,6 +683,12 @@ func (d *CombinedDecoder[T]) handleSpecialCases(r *http.Request, result *T) erro
 			needsForm = true
 		}
 
+		if field.Tag.Get("cookie") == CookieCatchAll {
+			if err := setCookieCatchAll(r, &field, resultValue.Field(i)); err != nil {
+				return err
+			}
+		}
+
 		// Check for file upload fields
 		if field.Type == reflect.TypeOf((*multipart.FileHeader)(nil)) ||
 			field.Type == reflect.TypeOf([]*multipart.FileHeader{}) {
@@ -555,14 +696,23 @@ func (d *CombinedDecoder[T]) handleSpecialCases(r *http.Request, result *T) erro
 		}
 	}
 
-	// Handle JSON body if needed
+	// Decode only the body-tagged fields when the struct declares them
+	if d.body != nil {
+		needsJSON = false
+		if r.Body != nil && r.ContentLength > 0 {
+			if err := d.body.decode(r, d.bodyCodecs, resultValue); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Handle JSON (or another configured body format) if needed
 	if needsJSON && r.Body != nil && r.ContentLength > 0 {
-		contentType := r.Header.Get("Content-Type")
-		if strings.Contains(contentType, "application/json") {
-			if jsonResult, err := d.jsonDecoder.Decode(r); err != nil {
-				return err // Propagate JSON parsing errors
+		if bodyDecoder := d.bodyDecoderFor(r.Header.Get("Content-Type")); bodyDecoder != nil {
+			if bodyResult, err := bodyDecoder.Decode(r); err != nil {
+				return err // Propagate body parsing errors
 			} else {
-				*result = mergeStructs(*result, jsonResult)
+				*result = mergeStructs(*result, bodyResult)
 			}
 		}
 	}
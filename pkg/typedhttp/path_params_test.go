@@ -0,0 +1,144 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postCommentRequest struct {
+	UserID    uint64    `path:"user_id"`
+	PostID    int64     `path:"post_id"`
+	Score     float64   `path:"score"`
+	Published bool      `path:"published"`
+	Ref       string    `path:"ref" format:"uuid"`
+	Day       time.Time `path:"day" format:"2006-01-02"`
+}
+
+type postCommentHandler struct{}
+
+func (h *postCommentHandler) Handle(_ context.Context, req postCommentRequest) (postCommentRequest, error) {
+	return req, nil
+}
+
+const postCommentPattern = "/users/{user_id}/posts/{post_id}/{score}/{published}/{ref}/{day}"
+
+func servePostComment(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	router := NewRouter()
+	GET(router, postCommentPattern, &postCommentHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	return w
+}
+
+func TestPathParams_ConvertToFieldTypes(t *testing.T) {
+	w := servePostComment(t, "/users/7/posts/42/4.5/true/123e4567-e89b-12d3-a456-426614174000/2024-03-01")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp postCommentRequest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, uint64(7), resp.UserID, "each wildcard binds by name, not position")
+	assert.Equal(t, int64(42), resp.PostID)
+	assert.InDelta(t, 4.5, resp.Score, 0.001)
+	assert.True(t, resp.Published)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", resp.Ref)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), resp.Day)
+}
+
+func TestPathParams_InvalidValues(t *testing.T) {
+	const (
+		ref = "123e4567-e89b-12d3-a456-426614174000"
+		day = "2024-03-01"
+	)
+
+	tests := []struct {
+		name      string
+		path      string
+		wantParam string
+		wantKind  DecodeErrorKind
+	}{
+		{
+			name:      "non-numeric int64",
+			path:      "/users/7/posts/abc/4.5/true/" + ref + "/" + day,
+			wantParam: "post_id",
+			wantKind:  DecodeKindInvalidInteger,
+		},
+		{
+			name:      "negative uint64",
+			path:      "/users/-7/posts/42/4.5/true/" + ref + "/" + day,
+			wantParam: "user_id",
+			wantKind:  DecodeKindInvalidUinteger,
+		},
+		{
+			name:      "malformed uuid",
+			path:      "/users/7/posts/42/4.5/true/not-a-uuid/" + day,
+			wantParam: "ref",
+			wantKind:  DecodeKindInvalidUUID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := servePostComment(t, tt.path)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp struct {
+				Error   string            `json:"error"`
+				Code    string            `json:"code"`
+				Details map[string]string `json:"details"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Contains(t, resp.Error, "invalid path parameter "+tt.wantParam)
+			assert.Equal(t, "DECODE_ERROR", resp.Code)
+			assert.Equal(t, map[string]string{
+				"source": string(SourcePath),
+				"param":  tt.wantParam,
+				"kind":   string(tt.wantKind),
+			}, resp.Details)
+		})
+	}
+}
+
+func TestPathDecoder_InvalidInteger(t *testing.T) {
+	type request struct {
+		PostID int64 `path:"post_id"`
+	}
+
+	_, err := NewPathDecoder[request](nil).Decode(httptest.NewRequest(http.MethodGet, "/posts/abc", http.NoBody))
+
+	var decErr *DecodeError
+	require.ErrorAs(t, err, &decErr)
+	assert.Equal(t, SourcePath, decErr.Source)
+	assert.Equal(t, "post_id", decErr.Param)
+	assert.Equal(t, "invalid path parameter post_id: invalid integer value: abc", err.Error())
+}
+
+type misnamedPathRequest struct {
+	UserID string `path:"user_id"`
+}
+
+type misnamedPathHandler struct{}
+
+func (h *misnamedPathHandler) Handle(_ context.Context, req misnamedPathRequest) (misnamedPathRequest, error) {
+	return req, nil
+}
+
+func TestPathParams_UnknownNameIsMissing(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/users/{id}/posts", &misnamedPathHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/7/posts", http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"UserID":""}`, w.Body.String())
+}
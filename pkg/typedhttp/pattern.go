@@ -0,0 +1,100 @@
+package typedhttp
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// PatternTag is the validation rule that matches a string field against a regular
+// expression, e.g. `validate:"regexp=^[A-Z]{3}$"`. The validator splits rules on
+// commas and pipes, so write them as 0x2C and 0x7C inside the expression.
+const PatternTag = "regexp"
+
+// compiledPatterns caches compiled expressions by their source.
+var compiledPatterns sync.Map
+
+// RegisterPatternValidation adds the regexp rule to v. The router's validator has it
+// already; call this for validators passed to the New*Decoder constructors.
+func RegisterPatternValidation(v *validator.Validate) error {
+	return v.RegisterValidation(PatternTag, validatePattern)
+}
+
+// UnescapePattern turns the parameter of a regexp rule back into the regular expression.
+func UnescapePattern(param string) string {
+	return strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)
+}
+
+// validatePattern reports whether the field matches the rule's expression. Routes
+// reject invalid expressions when they are registered; with a validator used outside
+// a router they never match, so the mistake surfaces as a failed validation.
+func validatePattern(fl validator.FieldLevel) bool {
+	re, err := compilePattern(UnescapePattern(fl.Param()))
+	if err != nil {
+		return false
+	}
+
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return re.MatchString(fmt.Sprint(field.Interface()))
+	}
+
+	return re.MatchString(field.String())
+}
+
+// compilePattern compiles pattern, reusing earlier compilations.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	re, _ := compiledPatterns.LoadOrStore(pattern, compiled)
+
+	return re.(*regexp.Regexp), nil
+}
+
+// mustCompilePatterns compiles the regexp rules of every field reachable from the
+// request type, panicking on the first invalid expression so that a typo fails at
+// registration instead of rejecting every request.
+func mustCompilePatterns(route string, t reflect.Type) {
+	if err := checkPatterns(t, map[reflect.Type]bool{}); err != nil {
+		panic(fmt.Sprintf("typedhttp: route %s: %v", route, err))
+	}
+}
+
+// checkPatterns walks the fields of t and its nested types, compiling regexp rules.
+func checkPatterns(t reflect.Type, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		for _, rule := range strings.FieldsFunc(field.Tag.Get("validate"), func(r rune) bool { return r == ',' || r == '|' }) {
+			param, ok := strings.CutPrefix(rule, PatternTag+"=")
+			if !ok {
+				continue
+			}
+			if _, err := compilePattern(UnescapePattern(param)); err != nil {
+				return fmt.Errorf("invalid %s rule on %s.%s: %w", PatternTag, t, field.Name, err)
+			}
+		}
+		if err := checkPatterns(field.Type, seen); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
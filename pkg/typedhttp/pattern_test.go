@@ -0,0 +1,93 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type airportRequest struct {
+	Code    string `json:"code" validate:"required,regexp=^[A-Z]{3}$"`
+	Carrier string `query:"carrier" validate:"omitempty,regexp=^(AA0x7CBA)$"`
+}
+
+type airportResponse struct {
+	Code string `json:"code"`
+}
+
+type airportHandler struct{}
+
+func (h *airportHandler) Handle(_ context.Context, req airportRequest) (airportResponse, error) {
+	return airportResponse{Code: req.Code}, nil
+}
+
+func TestPattern_EnforcedAtDecode(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/airports", &airportHandler{})
+
+	tests := []struct {
+		name   string
+		query  string
+		body   string
+		status int
+		field  string
+	}{
+		{name: "valid", body: `{"code":"LHR"}`, query: "?carrier=BA", status: http.StatusCreated},
+		{name: "lowercase", body: `{"code":"lhr"}`, status: http.StatusBadRequest, field: "code"},
+		{name: "too long", body: `{"code":"LHRX"}`, status: http.StatusBadRequest, field: "code"},
+		{name: "alternation", body: `{"code":"LHR"}`, query: "?carrier=LH", status: http.StatusBadRequest, field: "carrier"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/airports"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.field != "" {
+				assert.Contains(t, w.Body.String(), `"`+tt.field+`":"regexp"`)
+			}
+		})
+	}
+}
+
+func TestRegisterPatternValidation(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, RegisterPatternValidation(v))
+
+	assert.NoError(t, v.Var("ABC", "regexp=^[A-Z]{20x2C3}$"))
+	assert.Error(t, v.Var("ABCD", "regexp=^[A-Z]{20x2C3}$"))
+	assert.Error(t, v.Var("ABC", "regexp=[unclosed"), "invalid expressions never match")
+}
+
+type badPatternFilter struct {
+	Code string `query:"code" validate:"omitempty,regexp=[unclosed"`
+}
+
+type badPatternRequest struct {
+	Filter badPatternFilter
+}
+
+type badPatternHandler struct{}
+
+func (h *badPatternHandler) Handle(context.Context, badPatternRequest) (airportResponse, error) {
+	return airportResponse{}, nil
+}
+
+func TestPattern_InvalidExpressionPanicsAtRegistration(t *testing.T) {
+	router := NewRouter()
+
+	assert.PanicsWithValue(t,
+		"typedhttp: route GET /airports: invalid regexp rule on typedhttp.badPatternFilter.Code: "+
+			"error parsing regexp: missing closing ]: `[unclosed`",
+		func() { GET(router, "/airports", &badPatternHandler{}) })
+	assert.Empty(t, router.GetHandlers())
+}
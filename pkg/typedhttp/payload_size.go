@@ -0,0 +1,91 @@
+package typedhttp
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// RouteSizeCollector is implemented by a RouteMetricsCollector that also records body
+// sizes, typically as histograms for capacity planning. Routes are labeled like the
+// other route metrics.
+type RouteSizeCollector interface {
+	// ObserveRequestSize records the size of a request body in bytes.
+	ObserveRequestSize(route string, bytes int64)
+	// ObserveResponseSize records the number of response body bytes written.
+	ObserveResponseSize(route string, bytes int64)
+}
+
+// WithPayloadSizeWarning logs a warning for every request or response body larger than
+// threshold bytes, with the route template and the size. The request is served
+// normally. A nil logger means slog.Default().
+func WithPayloadSizeWarning(threshold int64, logger *slog.Logger) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.PayloadWarningThreshold = threshold
+		cfg.PayloadWarningLogger = logger
+	}
+}
+
+// payloadSizeHandler warns about bodies larger than threshold.
+type payloadSizeHandler struct {
+	route     RoutePattern
+	threshold int64
+	logger    *slog.Logger
+	next      http.Handler
+}
+
+func (h *payloadSizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := countRequestBody(r)
+	recorder := NewResponseRecorder(w)
+
+	h.next.ServeHTTP(recorder, r)
+
+	logger := h.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if size := requestBodySize(r, body); size > h.threshold {
+		logger.WarnContext(r.Context(), "large request body",
+			"route", h.route.String(), "bytes", size, "threshold", h.threshold)
+	}
+	if size := recorder.BytesWritten(); size > h.threshold {
+		logger.WarnContext(r.Context(), "large response body",
+			"route", h.route.String(), "bytes", size, "threshold", h.threshold)
+	}
+}
+
+// countingBody counts the bytes read from a request body.
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+
+	return n, err
+}
+
+// countRequestBody replaces the body of r with one that counts the bytes read from it.
+// It returns nil for requests without a body.
+func countRequestBody(r *http.Request) *countingBody {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	body := &countingBody{ReadCloser: r.Body}
+	r.Body = body
+
+	return body
+}
+
+// requestBodySize returns the size of the request body: the bytes read, or the declared
+// Content-Length when the handler stopped reading early.
+func requestBodySize(r *http.Request, body *countingBody) int64 {
+	if body == nil {
+		return 0
+	}
+
+	return max(body.n, r.ContentLength)
+}
@@ -0,0 +1,88 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSizeMetrics struct {
+	*mockRouteMetrics
+	requestSizes  map[string][]int64
+	responseSizes map[string][]int64
+}
+
+func newMockSizeMetrics() *mockSizeMetrics {
+	return &mockSizeMetrics{
+		mockRouteMetrics: newMockRouteMetrics(),
+		requestSizes:     make(map[string][]int64),
+		responseSizes:    make(map[string][]int64),
+	}
+}
+
+func (m *mockSizeMetrics) ObserveRequestSize(route string, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requestSizes[route] = append(m.requestSizes[route], bytes)
+}
+
+func (m *mockSizeMetrics) ObserveResponseSize(route string, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responseSizes[route] = append(m.responseSizes[route], bytes)
+}
+
+type uploadRequest struct {
+	ID   string `path:"id"`
+	Data string `json:"data"`
+}
+
+type uploadHandler struct{}
+
+func (h *uploadHandler) Handle(_ context.Context, req uploadRequest) (Raw, error) {
+	return Raw{ContentType: "text/plain", Body: []byte(strings.Repeat("x", len(req.Data)))}, nil
+}
+
+func TestWithRouteMetrics_ObservesBodySizes(t *testing.T) {
+	collector := newMockSizeMetrics()
+	router := NewRouter(WithRouteMetrics(collector))
+	PUT(router, "/uploads/{id}", &uploadHandler{})
+
+	body := `{"data":"` + strings.Repeat("a", 1000) + `"}`
+	r := httptest.NewRequest(http.MethodPut, "/uploads/7", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []int64{1000}, collector.responseSizes["PUT /uploads/{id}"])
+	assert.Equal(t, []int64{int64(len(body))}, collector.requestSizes["PUT /uploads/{id}"])
+	assert.NotContains(t, collector.responseSizes, "PUT /uploads/7")
+}
+
+func TestWithPayloadSizeWarning(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	router := NewRouter(WithPayloadSizeWarning(512, logger))
+	PUT(router, "/uploads/{id}", &uploadHandler{})
+
+	send := func(size int) {
+		r := httptest.NewRequest(http.MethodPut, "/uploads/7", strings.NewReader(`{"data":"`+strings.Repeat("a", size)+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	send(100)
+	assert.Empty(t, logs.String())
+
+	send(600)
+	assert.Contains(t, logs.String(), `msg="large request body" route="PUT /uploads/{id}" bytes=611 threshold=512`)
+	assert.Contains(t, logs.String(), `msg="large response body" route="PUT /uploads/{id}" bytes=600 threshold=512`)
+}
@@ -0,0 +1,154 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownPrincipalField is returned when a principal tag names an attribute the
+// authenticated principal does not have, or the principal cannot be assigned to the field.
+var ErrUnknownPrincipalField = errors.New("unknown principal field")
+
+// PrincipalSelf is the principal tag option that binds the whole principal, as in
+// `principal:",self"`.
+const PrincipalSelf = "self"
+
+// principalKey is the context key of the authenticated principal.
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated principal, such as
+// the user or the token claims. Authentication middleware call it once the credentials
+// are verified, so that request fields tagged with principal are bound from it.
+func ContextWithPrincipal(ctx context.Context, principal interface{}) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal stored by ContextWithPrincipal.
+func PrincipalFromContext(ctx context.Context) (interface{}, bool) {
+	principal := ctx.Value(principalKey{})
+
+	return principal, principal != nil
+}
+
+// principalField is a struct field bound to the authenticated principal.
+type principalField struct {
+	index int
+	name  string // Attribute of the principal; empty for the whole principal
+}
+
+// newPrincipalFields returns the principal-tagged fields of requestType. A field is
+// bound to an attribute, `principal:"user_id"`, or the whole principal, `principal:",self"`.
+func newPrincipalFields(requestType reflect.Type) []principalField {
+	if requestType == nil || requestType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []principalField
+	for i := 0; i < requestType.NumField(); i++ {
+		field := requestType.Field(i)
+		tag, ok := field.Tag.Lookup("principal")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		name, option, _ := strings.Cut(tag, ",")
+		if option == PrincipalSelf {
+			name = ""
+		}
+		fields = append(fields, principalField{index: i, name: name})
+	}
+
+	return fields
+}
+
+// bindPrincipal sets the principal-tagged fields of result from the principal in ctx.
+// It runs after every client-supplied source is decoded and always assigns the fields,
+// to the zero value when there is no principal, so a client can never set them.
+func bindPrincipal(ctx context.Context, fields []principalField, result reflect.Value) error {
+	principal, authenticated := PrincipalFromContext(ctx)
+
+	for _, field := range fields {
+		target := result.Field(field.index)
+		target.SetZero()
+		if !authenticated {
+			continue
+		}
+
+		value := reflect.ValueOf(principal)
+		if field.name != "" {
+			var ok bool
+			if value, ok = principalAttribute(value, field.name); !ok {
+				return fmt.Errorf("%w: %s", ErrUnknownPrincipalField, field.name)
+			}
+		}
+
+		if err := assignPrincipal(target, value); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrUnknownPrincipalField, field.name, err)
+		}
+	}
+
+	return nil
+}
+
+// principalAttribute returns the attribute name of principal: a map key, or a struct
+// field matched by its JSON name or, ignoring case, its Go name.
+func principalAttribute(principal reflect.Value, name string) (reflect.Value, bool) {
+	for principal.Kind() == reflect.Pointer || principal.Kind() == reflect.Interface {
+		if principal.IsNil() {
+			return reflect.Value{}, false
+		}
+		principal = principal.Elem()
+	}
+
+	switch principal.Kind() {
+	case reflect.Map:
+		if principal.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		value := principal.MapIndex(reflect.ValueOf(name).Convert(principal.Type().Key()))
+
+		return value, value.IsValid()
+	case reflect.Struct:
+		for i := 0; i < principal.NumField(); i++ {
+			field := principal.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if jsonName == name || strings.EqualFold(field.Name, name) {
+				return principal.Field(i), true
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// assignPrincipal sets target to value, converting through its string form when the
+// types differ, e.g. a numeric claim into a string field.
+func assignPrincipal(target, value reflect.Value) error {
+	for value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if !value.IsValid() || value.Kind() == reflect.Interface {
+		return nil
+	}
+
+	switch {
+	case value.Type().AssignableTo(target.Type()):
+		target.Set(value)
+	case value.Kind() == reflect.Pointer && !value.IsNil() && value.Elem().Type().AssignableTo(target.Type()):
+		target.Set(value.Elem())
+	case value.Kind() != reflect.Pointer && reflect.PointerTo(value.Type()).AssignableTo(target.Type()):
+		copied := reflect.New(value.Type())
+		copied.Elem().Set(value)
+		target.Set(copied)
+	default:
+		return setFieldValueFromString(target, fmt.Sprint(value.Interface()))
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accountUser struct {
+	ID    string   `json:"id"`
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+type createNoteRequest struct {
+	Title  string       `json:"title" validate:"required"`
+	UserID string       `json:"user_id" principal:"id" validate:"required"`
+	User   *accountUser `principal:",self"`
+}
+
+type createNoteHandler struct {
+	received createNoteRequest
+}
+
+func (h *createNoteHandler) Handle(_ context.Context, req createNoteRequest) (struct{}, error) {
+	h.received = req
+
+	return struct{}{}, nil
+}
+
+// authenticate stands in for auth middleware, storing the user of the X-Test-User header.
+func authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get("X-Test-User"); id != "" {
+			user := &accountUser{ID: id, Email: id + "@example.com"}
+			r = r.WithContext(ContextWithPrincipal(r.Context(), user))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestPrincipalBinding(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   string
+		target string
+		body   string
+		status int
+		userID string
+	}{
+		{
+			name:   "bound from the principal",
+			user:   "u-1",
+			target: "/notes",
+			body:   `{"title": "Groceries"}`,
+			status: http.StatusCreated,
+			userID: "u-1",
+		},
+		{
+			name:   "client supplied values are ignored",
+			user:   "u-1",
+			target: "/notes?user_id=u-2&UserID=u-2",
+			body:   `{"title": "Groceries", "user_id": "u-2", "User": {"id": "u-2"}}`,
+			status: http.StatusCreated,
+			userID: "u-1",
+		},
+		{
+			name:   "unauthenticated client cannot supply the principal",
+			target: "/notes",
+			body:   `{"title": "Groceries", "user_id": "u-2", "User": {"id": "u-2"}}`,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &createNoteHandler{}
+			router := NewRouter()
+			POST(router, "/notes", handler, WithMiddleware(authenticate))
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.user != "" {
+				req.Header.Set("X-Test-User", tt.user)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+			if tt.status != http.StatusCreated {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+				return
+			}
+
+			assert.Equal(t, "Groceries", handler.received.Title)
+			assert.Equal(t, tt.userID, handler.received.UserID)
+			require.NotNil(t, handler.received.User)
+			assert.Equal(t, tt.userID+"@example.com", handler.received.User.Email)
+		})
+	}
+}
+
+func TestPrincipalBinding_Claims(t *testing.T) {
+	type request struct {
+		Subject string `principal:"sub"`
+		Level   int    `principal:"level"`
+	}
+
+	ctx := ContextWithPrincipal(context.Background(), map[string]interface{}{"sub": "u-1", "level": float64(3)})
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody).WithContext(ctx)
+
+	result, err := NewCombinedDecoder[request](nil).Decode(req)
+	require.NoError(t, err)
+	assert.Equal(t, "u-1", result.Subject)
+	assert.Equal(t, 3, result.Level)
+}
+
+func TestPrincipalBinding_UnknownAttribute(t *testing.T) {
+	type request struct {
+		Tenant string `principal:"tenant"`
+	}
+
+	ctx := ContextWithPrincipal(context.Background(), &accountUser{ID: "u-1"})
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody).WithContext(ctx)
+
+	_, err := NewCombinedDecoder[request](nil).Decode(req)
+	assert.ErrorIs(t, err, ErrUnknownPrincipalField)
+}
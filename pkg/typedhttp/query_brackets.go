@@ -0,0 +1,150 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxQueryDepth limits the bracket segments of a query key bound to a nested field,
+// so filter[a][b] (two levels) is accepted and deeper keys are rejected with 400.
+// The depth a request can populate is further bounded by the field's Go type.
+const MaxQueryDepth = 5
+
+// ErrQueryTooDeep is returned for bracketed query keys nested beyond MaxQueryDepth.
+var ErrQueryTooDeep = errors.New("query parameter nested too deeply")
+
+// isNestedQueryType reports whether a query field binds bracketed keys: string-keyed
+// maps and structs from filter[status]=open, and slices from tags[]=a&tags[]=b.
+func isNestedQueryType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String
+	case reflect.Struct:
+		return t != reflect.TypeOf(time.Time{})
+	case reflect.Slice:
+		return t != reflect.TypeOf(net.IP{})
+	default:
+		return false
+	}
+}
+
+// decodeNestedQuery binds the query keys of name in bracket notation into v.
+// Struct fields are matched by their query tag, or their lower-cased name. Slices
+// serialized without explode are split on the delimiter of style instead.
+func decodeNestedQuery(query url.Values, name string, style QueryStyle, v reflect.Value) error {
+	if separator := style.separator(); separator != "" && v.Kind() == reflect.Slice {
+		var values []string
+		for _, value := range query[name] {
+			values = append(values, strings.Split(value, separator)...)
+		}
+
+		return setQuerySlice(name, values, v)
+	}
+
+	for key := range query {
+		if strings.HasPrefix(key, name+"[") && strings.Count(key, "[") > MaxQueryDepth {
+			return fmt.Errorf("%w: %s has more than %d levels", ErrQueryTooDeep, key, MaxQueryDepth)
+		}
+	}
+
+	return bindQueryKey(query, name, v)
+}
+
+// bindQueryKey binds the value or values under key into v, recursing into brackets.
+func bindQueryKey(query url.Values, key string, v reflect.Value) error {
+	if !isNestedQueryType(v.Type()) {
+		value := query.Get(key)
+		if value == "" {
+			return nil
+		}
+		if err := setFieldValueFromString(v, value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		// Both tags[]=a&tags[]=b and repeated tags=a&tags=b fill the slice; empty values
+		// count as missing, as they do for single-valued parameters
+		return setQuerySlice(key, slices.Concat(query[key+"[]"], query[key]), v)
+
+	case reflect.Map:
+		for _, subkey := range querySubkeys(query, key) {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := bindQueryKey(query, key+"["+subkey+"]", elem); err != nil {
+				return err
+			}
+
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+			v.SetMapIndex(reflect.ValueOf(subkey).Convert(v.Type().Key()), elem)
+		}
+
+	default:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := field.Tag.Get("query")
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			if err := bindQueryKey(query, key+"["+name+"]", v.Field(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setQuerySlice converts the non-empty values of key into the slice v.
+func setQuerySlice(key string, values []string, v reflect.Value) error {
+	values = slices.DeleteFunc(values, func(value string) bool {
+		return value == ""
+	})
+	if len(values) == 0 {
+		return nil
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := setFieldValueFromString(slice.Index(i), value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	v.Set(slice)
+
+	return nil
+}
+
+// querySubkeys returns the distinct, sorted segments that follow key in bracketed
+// keys, so filter[status] and filter[owner][id] yield status and owner.
+func querySubkeys(query url.Values, key string) []string {
+	var subkeys []string
+	for queryKey := range query {
+		rest, ok := strings.CutPrefix(queryKey, key+"[")
+		if !ok {
+			continue
+		}
+
+		subkey, _, ok := strings.Cut(rest, "]")
+		if ok && subkey != "" && !slices.Contains(subkeys, subkey) {
+			subkeys = append(subkeys, subkey)
+		}
+	}
+	slices.Sort(subkeys)
+
+	return subkeys
+}
@@ -0,0 +1,70 @@
+package typedhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type issueFilter struct {
+	Status string `query:"status"`
+	Owner  string `query:"owner"`
+	Labels []string
+}
+
+type issueSearchRequest struct {
+	Filter   map[string]string            `query:"filter"`
+	Range    map[string]map[string]string `query:"range"`
+	Issue    issueFilter                  `query:"issue"`
+	Tags     []string                     `query:"tags"`
+	IDs      []int                        `query:"ids"`
+	PageSize int                          `query:"page_size"`
+}
+
+func TestQueryBrackets(t *testing.T) {
+	target := "/issues?filter[status]=open&filter[owner]=me" +
+		"&range[created][gte]=2026-01-01" +
+		"&issue[status]=closed&issue[labels][]=bug&issue[labels][]=ui" +
+		"&tags[]=a&tags[]=b&ids=1&ids=2&page_size=10"
+
+	for name, decoder := range map[string]RequestDecoder[issueSearchRequest]{
+		"query":    NewQueryDecoder[issueSearchRequest](nil),
+		"combined": NewCombinedDecoder[issueSearchRequest](nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := decoder.Decode(httptest.NewRequest(http.MethodGet, target, nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]string{"status": "open", "owner": "me"}, result.Filter)
+			assert.Equal(t, map[string]map[string]string{"created": {"gte": "2026-01-01"}}, result.Range)
+			assert.Equal(t, issueFilter{Status: "closed", Labels: []string{"bug", "ui"}}, result.Issue)
+			assert.Equal(t, []string{"a", "b"}, result.Tags)
+			assert.Equal(t, []int{1, 2}, result.IDs)
+			assert.Equal(t, 10, result.PageSize)
+		})
+	}
+}
+
+func TestQueryBrackets_Errors(t *testing.T) {
+	t.Run("too deep", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/issues?range[a][b][c][d][e][f]=1", nil)
+
+		_, err := NewCombinedDecoder[issueSearchRequest](nil).Decode(req)
+		require.ErrorIs(t, err, ErrQueryTooDeep)
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/issues?ids[]=1&ids[]=x", nil)
+
+		_, err := NewQueryDecoder[issueSearchRequest](nil).Decode(req)
+
+		var decodeErr *DecodeError
+		require.True(t, errors.As(err, &decodeErr), "got %v", err)
+		assert.Equal(t, "ids", decodeErr.Param)
+		assert.Equal(t, DecodeKindInvalidInteger, decodeErr.Kind)
+	})
+}
@@ -0,0 +1,82 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidQueryStyle is returned for style tags the query decoder cannot honor.
+var ErrInvalidQueryStyle = errors.New("invalid query style")
+
+// Serialization styles of slice query parameters, named as in OpenAPI.
+const (
+	QueryStyleForm           = "form"           // tags=a&tags=b, or tags=a,b without explode
+	QueryStyleSpaceDelimited = "spaceDelimited" // tags=a%20b
+	QueryStylePipeDelimited  = "pipeDelimited"  // tags=a|b
+)
+
+// QueryStyle is the serialization of a slice query parameter.
+type QueryStyle struct {
+	Style   string
+	Explode bool
+}
+
+// FieldQueryStyle returns the serialization of a slice query field, declared with the
+// style tag, e.g. `query:"tags" style:"form,explode=false"` for tags=a,b. Without a
+// tag it is form with explode, i.e. repeated keys. spaceDelimited and pipeDelimited
+// default to explode=false.
+func FieldQueryStyle(field reflect.StructField) (QueryStyle, error) {
+	return parseQueryStyle(field.Tag.Get("style"))
+}
+
+// parseQueryStyle parses the value of a style tag.
+func parseQueryStyle(tag string) (QueryStyle, error) {
+	if tag == "" {
+		return QueryStyle{Style: QueryStyleForm, Explode: true}, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	style := QueryStyle{Style: strings.TrimSpace(parts[0])}
+	switch style.Style {
+	case QueryStyleForm:
+		style.Explode = true
+	case QueryStyleSpaceDelimited, QueryStylePipeDelimited:
+	default:
+		return QueryStyle{}, fmt.Errorf("%w: unknown style %q", ErrInvalidQueryStyle, style.Style)
+	}
+
+	for _, option := range parts[1:] {
+		value, ok := strings.CutPrefix(strings.TrimSpace(option), "explode=")
+		if !ok {
+			return QueryStyle{}, fmt.Errorf("%w: unknown option %q", ErrInvalidQueryStyle, option)
+		}
+
+		explode, err := strconv.ParseBool(value)
+		if err != nil {
+			return QueryStyle{}, fmt.Errorf("%w: explode=%s", ErrInvalidQueryStyle, value)
+		}
+		style.Explode = explode
+	}
+
+	return style, nil
+}
+
+// separator returns the delimiter between values in a single query value, or "" when
+// each value is sent under its own key.
+func (s QueryStyle) separator() string {
+	if s.Explode {
+		return ""
+	}
+
+	switch s.Style {
+	case QueryStyleSpaceDelimited:
+		return " "
+	case QueryStylePipeDelimited:
+		return "|"
+	default:
+		return ","
+	}
+}
@@ -0,0 +1,80 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type delimitedQueryRequest struct {
+	Tags    []string `query:"tags" style:"form,explode=false"`
+	IDs     []int    `query:"ids" style:"pipeDelimited"`
+	Fields  []string `query:"fields" style:"spaceDelimited"`
+	Regions []string `query:"regions"`
+}
+
+func TestQueryStyle_Delimited(t *testing.T) {
+	target := "/items?tags=a,b,,c&ids=1|2|3&fields=id+name&regions=eu&regions=us"
+
+	for name, decoder := range map[string]RequestDecoder[delimitedQueryRequest]{
+		"query":    NewQueryDecoder[delimitedQueryRequest](nil),
+		"combined": NewCombinedDecoder[delimitedQueryRequest](nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := decoder.Decode(httptest.NewRequest(http.MethodGet, target, http.NoBody))
+			require.NoError(t, err)
+
+			assert.Equal(t, delimitedQueryRequest{
+				Tags:    []string{"a", "b", "c"},
+				IDs:     []int{1, 2, 3},
+				Fields:  []string{"id", "name"},
+				Regions: []string{"eu", "us"},
+			}, result)
+		})
+	}
+}
+
+func TestQueryStyle_DelimitedConversionError(t *testing.T) {
+	_, err := NewQueryDecoder[delimitedQueryRequest](nil).
+		Decode(httptest.NewRequest(http.MethodGet, "/items?ids=1|x", http.NoBody))
+
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, SourceQuery, decodeErr.Source)
+	assert.ErrorIs(t, err, ErrInvalidIntegerValue)
+}
+
+func TestFieldQueryStyle(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected QueryStyle
+		wantErr  bool
+	}{
+		{tag: ``, expected: QueryStyle{Style: QueryStyleForm, Explode: true}},
+		{tag: `style:"form,explode=false"`, expected: QueryStyle{Style: QueryStyleForm}},
+		{tag: `style:"pipeDelimited"`, expected: QueryStyle{Style: QueryStylePipeDelimited}},
+		{tag: `style:"spaceDelimited,explode=true"`, expected: QueryStyle{Style: QueryStyleSpaceDelimited, Explode: true}},
+		{tag: `style:"deepObject"`, wantErr: true},
+		{tag: `style:"form,explode=maybe"`, wantErr: true},
+		{tag: `style:"form,allowReserved"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			field := reflect.StructField{Name: "Tags", Type: reflect.TypeOf([]string{}), Tag: reflect.StructTag(tt.tag)}
+			style, err := FieldQueryStyle(field)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidQueryStyle)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, style)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package typedhttp
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Raw is a response whose body is already serialized, such as a cached payload or a
+// proxied upstream body. The router writes Body verbatim with ContentType, bypassing
+// the response encoder; an empty ContentType is sent as application/octet-stream.
+// The status is resolved like any other success response.
+//
+// Raw bodies are opaque to the OpenAPI generator, so document their media types with
+// WithProduces.
+type Raw struct {
+	ContentType string
+	Body        []byte
+}
+
+// writeRawResponse writes resp verbatim if it is a Raw. It reports whether the
+// response was handled.
+func writeRawResponse(w http.ResponseWriter, resp interface{}, statusCode int) bool {
+	var raw Raw
+
+	switch v := resp.(type) {
+	case Raw:
+		raw = v
+	case *Raw:
+		if v == nil {
+			return false
+		}
+		raw = *v
+	default:
+		return false
+	}
+
+	contentType := raw.ContentType
+	if contentType == "" {
+		contentType = DefaultStreamContentType
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(raw.Body)))
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(raw.Body)
+
+	return true
+}
@@ -0,0 +1,68 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cachedPayloadHandler struct {
+	raw *Raw
+}
+
+func (h *cachedPayloadHandler) Handle(_ context.Context, _ struct{}) (*Raw, error) {
+	return h.raw, nil
+}
+
+func TestRaw_WrittenVerbatim(t *testing.T) {
+	body := []byte("<report id=\"7\">\n  <total>42</total>\n</report>")
+
+	router := NewRouter()
+	GET(router, "/report", &cachedPayloadHandler{raw: &Raw{ContentType: "application/xml; charset=utf-8", Body: body}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, body, w.Body.Bytes())
+	assert.Equal(t, strconv.Itoa(len(body)), w.Header().Get("Content-Length"))
+}
+
+func TestRaw_JSONIsNotReencoded(t *testing.T) {
+	// Key order and spacing survive because the body is never decoded
+	body := []byte(`{"z": 1, "a": [1,2]}`)
+
+	router := NewRouter()
+	POST(router, "/cached", &cachedPayloadHandler{raw: &Raw{ContentType: "application/json", Body: body}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/cached", http.NoBody))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, string(body), w.Body.String())
+}
+
+func TestRaw_DefaultsAndStatus(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/blob", &cachedPayloadHandler{raw: &Raw{Body: []byte{0x00, 0xff}}},
+		WithResponseStatus(http.StatusAccepted))
+	GET(router, "/nil", &cachedPayloadHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blob", http.NoBody))
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, DefaultStreamContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, []byte{0x00, 0xff}, w.Body.Bytes())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nil", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "null\n", w.Body.String())
+}
@@ -0,0 +1,186 @@
+package typedhttp
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RolesFunc returns the roles of the caller associated with ctx.
+type RolesFunc func(ctx context.Context) []string
+
+// RedactionInterceptor zeroes response fields the caller is not permitted to see.
+//
+// Fields are restricted with a redact tag listing the roles allowed to see them:
+//
+//	Email string `json:"email,omitempty" redact:"role:admin,role:support"`
+//
+// A caller holding any listed role sees the field; for everyone else it is set to
+// its zero value, so combine it with omitempty to drop it from the body. Nested
+// structs, pointers, slices, arrays and maps are walked recursively. The handler's
+// value is never modified; redaction works on a copy.
+type RedactionInterceptor struct {
+	roles RolesFunc
+}
+
+// NewRedactionInterceptor creates a redaction interceptor using roles to identify the caller.
+// Register it for all routes with WithResponseInterceptors.
+func NewRedactionInterceptor(roles RolesFunc) *RedactionInterceptor {
+	return &RedactionInterceptor{roles: roles}
+}
+
+// Intercept implements ResponseInterceptor[any].
+func (i *RedactionInterceptor) Intercept(ctx context.Context, resp any) (any, error) {
+	value := reflect.ValueOf(resp)
+	if !value.IsValid() || !typeHasRedactTags(value.Type()) {
+		return resp, nil
+	}
+
+	roles := make(map[string]bool)
+	for _, role := range i.roles(ctx) {
+		roles[role] = true
+	}
+
+	return redactValue(value, roles).Interface(), nil
+}
+
+// redactValue returns a copy of v with restricted fields zeroed.
+func redactValue(v reflect.Value, roles map[string]bool) reflect.Value {
+	if !typeHasRedactTags(v.Type()) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redactValue(v.Elem(), roles))
+
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactValue(v.Elem(), roles))
+
+		return out
+	case reflect.Struct:
+		return redactStruct(v, roles)
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i), roles))
+		}
+
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i), roles))
+		}
+
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redactValue(iter.Value(), roles))
+		}
+
+		return out
+	default:
+		return v
+	}
+}
+
+// redactStruct copies a struct, zeroing fields whose redact tag the caller does not satisfy.
+func redactStruct(v reflect.Value, roles map[string]bool) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("redact"); ok && !redactAllows(tag, roles) {
+			out.Field(i).Set(reflect.Zero(field.Type))
+
+			continue
+		}
+
+		out.Field(i).Set(redactValue(v.Field(i), roles))
+	}
+
+	return out
+}
+
+// redactAllows reports whether any role required by the tag is held by the caller.
+func redactAllows(tag string, roles map[string]bool) bool {
+	for _, rule := range strings.Split(tag, ",") {
+		if role, ok := strings.CutPrefix(strings.TrimSpace(rule), "role:"); ok && roles[role] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactTagCache memoizes whether a type contains redact tags anywhere.
+var redactTagCache sync.Map
+
+// typeHasRedactTags reports whether t or any type reachable from it has a redact tag.
+func typeHasRedactTags(t reflect.Type) bool {
+	if cached, ok := redactTagCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	result := scanRedactTags(t, make(map[reflect.Type]bool))
+	redactTagCache.Store(t, result)
+
+	return result
+}
+
+// scanRedactTags walks t looking for redact tags, guarding against recursive types.
+func scanRedactTags(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		// The dynamic type is only known at runtime
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return scanRedactTags(t.Elem(), visited)
+	case reflect.Map:
+		return scanRedactTags(t.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := field.Tag.Lookup("redact"); ok {
+				return true
+			}
+			if scanRedactTags(field.Type, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
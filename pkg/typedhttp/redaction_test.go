@@ -0,0 +1,136 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rolesContextKey struct{}
+
+func rolesFromTestContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesContextKey{}).([]string)
+
+	return roles
+}
+
+type RedactedAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street,omitempty" redact:"role:admin"`
+}
+
+type RedactedMember struct {
+	Name    string           `json:"name"`
+	Email   string           `json:"email,omitempty" redact:"role:admin,role:support"`
+	Salary  int              `json:"salary,omitempty" redact:"role:admin"`
+	Address *RedactedAddress `json:"address,omitempty"`
+}
+
+type RedactedTeam struct {
+	Name    string           `json:"name"`
+	Members []RedactedMember `json:"members"`
+	Budget  float64          `json:"budget,omitempty" redact:"role:admin"`
+}
+
+type teamHandler struct {
+	team RedactedTeam
+}
+
+func (h *teamHandler) Handle(_ context.Context, _ struct{}) (RedactedTeam, error) {
+	return h.team, nil
+}
+
+func newRedactedTeam() RedactedTeam {
+	return RedactedTeam{
+		Name:   "core",
+		Budget: 1000,
+		Members: []RedactedMember{
+			{
+				Name: "ada", Email: "ada@example.com", Salary: 10,
+				Address: &RedactedAddress{City: "London", Street: "1 Main St"},
+			},
+			{Name: "bob", Email: "bob@example.com", Salary: 20},
+		},
+	}
+}
+
+func TestRedactionInterceptor_NestedStructsAndSlices(t *testing.T) {
+	interceptor := NewRedactionInterceptor(rolesFromTestContext)
+
+	tests := []struct {
+		name     string
+		roles    []string
+		expected string
+	}{
+		{
+			name:  "anonymous caller sees public fields only",
+			roles: nil,
+			expected: `{"name":"core","members":[
+				{"name":"ada","address":{"city":"London"}},
+				{"name":"bob"}]}`,
+		},
+		{
+			name:  "support sees emails",
+			roles: []string{"support"},
+			expected: `{"name":"core","members":[
+				{"name":"ada","email":"ada@example.com","address":{"city":"London"}},
+				{"name":"bob","email":"bob@example.com"}]}`,
+		},
+		{
+			name:  "admin sees everything",
+			roles: []string{"admin"},
+			expected: `{"name":"core","budget":1000,"members":[
+				{"name":"ada","email":"ada@example.com","salary":10,"address":{"city":"London","street":"1 Main St"}},
+				{"name":"bob","email":"bob@example.com","salary":20}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(WithResponseInterceptors(interceptor))
+			GET(router, "/team", &teamHandler{team: newRedactedTeam()})
+
+			req := httptest.NewRequest(http.MethodGet, "/team", http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), rolesContextKey{}, tt.roles))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.expected, w.Body.String())
+		})
+	}
+}
+
+func TestRedactionInterceptor_DoesNotModifyOriginal(t *testing.T) {
+	team := newRedactedTeam()
+	interceptor := NewRedactionInterceptor(rolesFromTestContext)
+
+	result, err := interceptor.Intercept(context.Background(), &team)
+	require.NoError(t, err)
+
+	redacted, ok := result.(*RedactedTeam)
+	require.True(t, ok)
+	assert.Empty(t, redacted.Members[0].Email)
+	assert.Empty(t, redacted.Members[0].Address.Street)
+
+	assert.Equal(t, "ada@example.com", team.Members[0].Email)
+	assert.Equal(t, "1 Main St", team.Members[0].Address.Street)
+}
+
+func TestRedactionInterceptor_MapsAndUntaggedTypes(t *testing.T) {
+	interceptor := NewRedactionInterceptor(rolesFromTestContext)
+
+	members := map[string]RedactedMember{"ada": {Name: "ada", Salary: 10}}
+	result, err := interceptor.Intercept(context.Background(), members)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]RedactedMember{"ada": {Name: "ada"}}, result)
+
+	plain := ProfileResponse{ID: "1", Email: "user@example.com"}
+	result, err = interceptor.Intercept(context.Background(), plain)
+	require.NoError(t, err)
+	assert.Equal(t, plain, result)
+}
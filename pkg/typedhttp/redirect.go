@@ -0,0 +1,66 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidRedirect is returned when a handler produces an unusable redirect.
+var ErrInvalidRedirect = errors.New("invalid redirect")
+
+// RedirectResponse is implemented by response types that redirect the client.
+// The router writes the Location header and status without a body.
+type RedirectResponse interface {
+	RedirectTo() (url string, status int)
+}
+
+// Redirect is a response that redirects the client to URL.
+// Status must be 301, 302, 303, 307 or 308; zero means 302 Found.
+type Redirect struct {
+	URL    string
+	Status int
+}
+
+// RedirectTo implements RedirectResponse.
+func (r Redirect) RedirectTo() (string, int) {
+	return r.URL, r.Status
+}
+
+// IsRedirectStatus reports whether status is a redirect status usable with a Location header.
+func IsRedirectStatus(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+
+	return false
+}
+
+// writeRedirectResponse writes resp as a redirect if it implements RedirectResponse.
+// It reports whether the response was a redirect; the error is set if it was invalid.
+func writeRedirectResponse(w http.ResponseWriter, resp interface{}) (bool, error) {
+	redirect, ok := resp.(RedirectResponse)
+	if !ok || isNilPointer(resp) {
+		return false, nil
+	}
+
+	url, status := redirect.RedirectTo()
+	if status == 0 {
+		status = http.StatusFound
+	}
+
+	if url == "" {
+		return true, fmt.Errorf("%w: empty URL", ErrInvalidRedirect)
+	}
+
+	if !IsRedirectStatus(status) {
+		return true, fmt.Errorf("%w: status %d is not a redirect", ErrInvalidRedirect, status)
+	}
+
+	w.Header().Set("Location", url)
+	w.WriteHeader(status)
+
+	return true, nil
+}
@@ -0,0 +1,115 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ShortLinkRequest struct {
+	Code string `path:"code"`
+}
+
+type shortLinkHandler struct {
+	redirect Redirect
+}
+
+func (h *shortLinkHandler) Handle(_ context.Context, _ ShortLinkRequest) (Redirect, error) {
+	return h.redirect, nil
+}
+
+// movedResponse is a custom response type implementing RedirectResponse.
+type movedResponse struct {
+	Target string `json:"target"`
+}
+
+func (m *movedResponse) RedirectTo() (string, int) {
+	return m.Target, http.StatusMovedPermanently
+}
+
+type movedHandler struct{}
+
+func (h *movedHandler) Handle(_ context.Context, _ ShortLinkRequest) (*movedResponse, error) {
+	return &movedResponse{Target: "/new"}, nil
+}
+
+func followLink(t *testing.T, router *TypedRouter, method string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/l/abc", http.NoBody))
+
+	return w
+}
+
+func TestRedirect_Statuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected int
+	}{
+		{name: "default", status: 0, expected: http.StatusFound},
+		{name: "moved permanently", status: http.StatusMovedPermanently, expected: http.StatusMovedPermanently},
+		{name: "found", status: http.StatusFound, expected: http.StatusFound},
+		{name: "see other", status: http.StatusSeeOther, expected: http.StatusSeeOther},
+		{name: "temporary redirect", status: http.StatusTemporaryRedirect, expected: http.StatusTemporaryRedirect},
+		{name: "permanent redirect", status: http.StatusPermanentRedirect, expected: http.StatusPermanentRedirect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+			GET(router, "/l/{code}", &shortLinkHandler{redirect: Redirect{URL: "https://example.com/x", Status: tt.status}})
+
+			w := followLink(t, router, http.MethodGet)
+
+			assert.Equal(t, tt.expected, w.Code)
+			assert.Equal(t, "https://example.com/x", w.Header().Get("Location"))
+			assert.Empty(t, w.Header().Get("Content-Type"))
+			assert.Zero(t, w.Body.Len())
+		})
+	}
+}
+
+func TestRedirect_PostIsNotCreated(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/l/{code}", &shortLinkHandler{redirect: Redirect{URL: "/done", Status: http.StatusSeeOther}})
+
+	w := followLink(t, router, http.MethodPost)
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/done", w.Header().Get("Location"))
+}
+
+func TestRedirect_CustomResponseType(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/l/{code}", &movedHandler{})
+
+	w := followLink(t, router, http.MethodGet)
+
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/new", w.Header().Get("Location"))
+	assert.Zero(t, w.Body.Len())
+}
+
+func TestRedirect_Invalid(t *testing.T) {
+	for name, redirect := range map[string]Redirect{
+		"empty URL":      {Status: http.StatusFound},
+		"not a redirect": {URL: "/x", Status: http.StatusOK},
+		"not modified":   {URL: "/x", Status: http.StatusNotModified},
+	} {
+		t.Run(name, func(t *testing.T) {
+			router := NewRouter()
+			GET(router, "/l/{code}", &shortLinkHandler{redirect: redirect})
+
+			w := followLink(t, router, http.MethodGet)
+
+			require.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Empty(t, w.Header().Get("Location"))
+		})
+	}
+}
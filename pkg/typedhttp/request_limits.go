@@ -0,0 +1,45 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors returned for requests rejected by the router's size limits.
+var (
+	ErrQueryTooLong   = errors.New("query string too long")
+	ErrTooManyHeaders = errors.New("too many request headers")
+)
+
+// checkRequestLimits rejects requests exceeding the configured limits before any
+// decoding happens. It reports whether the request may proceed.
+func (r *TypedRouter) checkRequestLimits(w http.ResponseWriter, req *http.Request) bool {
+	if limit := r.config.MaxQueryLength; limit > 0 && len(req.URL.RawQuery) > limit {
+		writeErrorResponse(w, http.StatusRequestURITooLong,
+			fmt.Errorf("%w: %d bytes, limit is %d", ErrQueryTooLong, len(req.URL.RawQuery), limit))
+
+		return false
+	}
+
+	if limit := r.config.MaxHeaderCount; limit > 0 {
+		if count := headerCount(req.Header); count > limit {
+			writeErrorResponse(w, http.StatusRequestHeaderFieldsTooLarge,
+				fmt.Errorf("%w: %d headers, limit is %d", ErrTooManyHeaders, count, limit))
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// headerCount counts header lines, so a repeated header counts once per value.
+func headerCount(header http.Header) int {
+	count := 0
+	for _, values := range header {
+		count += len(values)
+	}
+
+	return count
+}
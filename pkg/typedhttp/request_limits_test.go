@@ -0,0 +1,64 @@
+package typedhttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxQueryLength(t *testing.T) {
+	router := NewRouter(WithMaxQueryLength(16))
+	GET(router, "/profiles/{id}", &profileHandler{})
+
+	atLimit := "q=" + strings.Repeat("a", 14)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/profiles/7?"+atLimit, http.NoBody))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/profiles/7?"+atLimit+"a", http.NoBody))
+	require.Equal(t, http.StatusRequestURITooLong, w.Code)
+	assert.Contains(t, w.Body.String(), ErrQueryTooLong.Error())
+}
+
+func TestWithMaxHeaderCount(t *testing.T) {
+	router := NewRouter(WithMaxHeaderCount(3))
+	GET(router, "/profiles/{id}", &profileHandler{})
+
+	newRequest := func(headers int) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/profiles/7", http.NoBody)
+		for i := 0; i < headers; i++ {
+			req.Header.Add("X-Trace", fmt.Sprint(i))
+		}
+
+		return req
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(3))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newRequest(4))
+	require.Equal(t, http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), ErrTooManyHeaders.Error())
+}
+
+func TestRequestLimits_UnlimitedByDefault(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/profiles/{id}", &profileHandler{})
+
+	req := httptest.NewRequest(http.MethodGet, "/profiles/7?q="+strings.Repeat("a", 10000), http.NoBody)
+	for i := 0; i < 200; i++ {
+		req.Header.Add("X-Trace", fmt.Sprint(i))
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,95 @@
+package typedhttp
+
+import (
+	"context"
+	"sync"
+)
+
+// Values is a request-scoped store for passing data from middleware to handlers.
+// Entries are addressed by ValueKey, so values are typed and keys from different
+// packages cannot collide. The map is only allocated when the first value is set.
+type Values struct {
+	mu     sync.RWMutex
+	values map[any]any
+}
+
+// ValueKey identifies a value of type T in a request's Values. Keys are compared by
+// identity, so declare each one once, typically as a package-level variable.
+type ValueKey[T any] struct {
+	name string
+}
+
+// NewValueKey creates a key for values of type T. The name is only used for debugging.
+func NewValueKey[T any](name string) *ValueKey[T] {
+	return &ValueKey[T]{name: name}
+}
+
+// String returns the key name.
+func (k *ValueKey[T]) String() string {
+	return k.name
+}
+
+// Get returns the value stored for the key, or the zero value and false.
+// It is safe to call with the nil Values of a context that has no store.
+func (k *ValueKey[T]) Get(values *Values) (T, bool) {
+	var zero T
+	if values == nil {
+		return zero, false
+	}
+
+	values.mu.RLock()
+	defer values.mu.RUnlock()
+
+	value, ok := values.values[k].(T)
+	if !ok {
+		return zero, false
+	}
+
+	return value, true
+}
+
+// Set stores value for the key and reports whether it was stored. It does nothing and
+// returns false if values is nil, which means the context was not created by a
+// TypedRouter or ContextWithRequestValues, e.g. in middleware wrapping the router.
+func (k *ValueKey[T]) Set(values *Values, value T) bool {
+	if values == nil {
+		return false
+	}
+
+	values.mu.Lock()
+	defer values.mu.Unlock()
+
+	if values.values == nil {
+		values.values = make(map[any]any)
+	}
+	values.values[k] = value
+
+	return true
+}
+
+// RequestValues returns the store of the current request. The router provides one
+// for every matched route; it is shared by middleware, enrichers and the handler.
+// It returns nil for contexts that did not come from a TypedRouter.
+func RequestValues(ctx context.Context) *Values {
+	scope, ok := ctx.Value(routePatternKey{}).(*requestScope)
+	if !ok {
+		return nil
+	}
+
+	return scope.values
+}
+
+// ContextWithRequestValues returns a context with an empty store, for code that runs
+// handlers outside a TypedRouter, such as unit tests, and for middleware wrapping the
+// router: the route reuses the store, so values set there reach the handler. A context
+// that already has a store is returned unchanged.
+func ContextWithRequestValues(ctx context.Context) context.Context {
+	if RequestValues(ctx) != nil {
+		return ctx
+	}
+
+	scope := &requestScope{}
+	scope.values = &scope.ownValues
+
+	return context.WithValue(ctx, routePatternKey{}, scope)
+}
@@ -0,0 +1,128 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type authInfo struct {
+	Subject string
+	Scopes  []string
+}
+
+var (
+	authInfoKey  = NewValueKey[authInfo]("auth_info")
+	startTimeKey = NewValueKey[time.Time]("start_time")
+)
+
+type whoAmIRequest struct {
+	ID string `path:"id"`
+}
+
+type whoAmIResponse struct {
+	Subject  string   `json:"subject"`
+	Scopes   []string `json:"scopes"`
+	HasStart bool     `json:"has_start"`
+}
+
+type whoAmIHandler struct{}
+
+func (h *whoAmIHandler) Handle(ctx context.Context, _ whoAmIRequest) (whoAmIResponse, error) {
+	info, ok := authInfoKey.Get(RequestValues(ctx))
+	if !ok {
+		return whoAmIResponse{}, NewUnauthorizedError("no auth info")
+	}
+	_, hasStart := startTimeKey.Get(RequestValues(ctx))
+
+	return whoAmIResponse{Subject: info.Subject, Scopes: info.Scopes, HasStart: hasStart}, nil
+}
+
+func TestRequestValues_MiddlewareToHandler(t *testing.T) {
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			values := RequestValues(r.Context())
+			authInfoKey.Set(values, authInfo{Subject: "alice", Scopes: []string{"read"}})
+			startTimeKey.Set(values, time.Now())
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	router := NewRouter()
+	GET(router, "/me/{id}", &whoAmIHandler{}, WithMiddleware(auth))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/1", http.NoBody))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"subject":"alice","scopes":["read"],"has_start":true}`, w.Body.String())
+}
+
+func TestRequestValues_Unset(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/me/{id}", &whoAmIHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/1", http.NoBody))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequestValues_KeysAreDistinctByIdentity(t *testing.T) {
+	ctx := ContextWithRequestValues(context.Background())
+	values := RequestValues(ctx)
+	other := NewValueKey[authInfo]("auth_info")
+
+	authInfoKey.Set(values, authInfo{Subject: "alice"})
+
+	_, ok := other.Get(values)
+	assert.False(t, ok, "keys with the same name must not collide")
+
+	_, ok = RoutePatternFromContext(ctx)
+	assert.False(t, ok)
+}
+
+func TestRequestValues_OutsideRouter(t *testing.T) {
+	values := RequestValues(context.Background())
+	assert.Nil(t, values)
+
+	_, ok := authInfoKey.Get(values)
+	assert.False(t, ok)
+	assert.False(t, authInfoKey.Set(values, authInfo{}))
+}
+
+func TestRequestValues_MiddlewareWrappingRouter(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/me/{id}", &whoAmIHandler{})
+
+	tests := []struct {
+		name     string
+		install  bool
+		wantCode int
+	}{
+		{name: "without store", install: false, wantCode: http.StatusUnauthorized},
+		{name: "with store", install: true, wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outer := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				if tt.install {
+					ctx = ContextWithRequestValues(ctx)
+				}
+				authInfoKey.Set(RequestValues(ctx), authInfo{Subject: "alice"})
+				router.ServeHTTP(w, r.WithContext(ctx))
+			})
+
+			w := httptest.NewRecorder()
+			outer.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/1", http.NoBody))
+
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+		})
+	}
+}
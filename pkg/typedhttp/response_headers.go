@@ -0,0 +1,36 @@
+package typedhttp
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+// ResponseHeaderSpec documents a header set on a response, for OpenAPI generation.
+type ResponseHeaderSpec struct {
+	// Status is the response status the header is sent with. Zero means every success
+	// response of the route.
+	Status      int
+	Name        string
+	Schema      *openapi3.Schema
+	Description string
+}
+
+// ResponseHeaderModifier is implemented by middleware that sets response headers, so the
+// OpenAPI document lists them on the operations the middleware is registered for.
+type ResponseHeaderModifier interface {
+	ResponseHeaders() []ResponseHeaderSpec
+}
+
+// WithResponseHeader documents a header the handler sets on responses with the given
+// status, e.g. an ETag or a rate limit header:
+//
+//	typedhttp.WithResponseHeader(http.StatusOK, "ETag", openapi3.NewStringSchema(), "Version of the resource")
+//
+// A status of zero applies to every success response. A nil schema means a string.
+func WithResponseHeader(status int, name string, schema *openapi3.Schema, description string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.ResponseHeaders = append(cfg.ResponseHeaders, ResponseHeaderSpec{
+			Status:      status,
+			Name:        name,
+			Schema:      schema,
+			Description: description,
+		})
+	}
+}
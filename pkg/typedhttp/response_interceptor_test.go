@@ -0,0 +1,137 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ProfileRequest struct {
+	ID string `path:"id"`
+}
+
+type ProfileResponse struct {
+	ID         string `json:"id"`
+	Email      string `json:"email,omitempty"`
+	ServerTime string `json:"server_time,omitempty"`
+}
+
+type profileHandler struct{}
+
+func (h *profileHandler) Handle(_ context.Context, req ProfileRequest) (ProfileResponse, error) {
+	return ProfileResponse{ID: req.ID, Email: "user@example.com"}, nil
+}
+
+// stampInterceptor adds a server timestamp and records its position in the chain.
+type stampInterceptor struct {
+	calls *[]string
+}
+
+func (i stampInterceptor) Intercept(_ context.Context, resp ProfileResponse) (ProfileResponse, error) {
+	*i.calls = append(*i.calls, "stamp")
+	resp.ServerTime = "2024-01-01T00:00:00Z"
+
+	return resp, nil
+}
+
+// redactEmailInterceptor hides the email unless the caller is an admin.
+type redactEmailInterceptor struct {
+	calls *[]string
+}
+
+func (i redactEmailInterceptor) Intercept(_ context.Context, resp ProfileResponse) (ProfileResponse, error) {
+	*i.calls = append(*i.calls, "redact")
+	resp.Email = ""
+
+	return resp, nil
+}
+
+// auditInterceptor applies to every response type.
+type auditInterceptor struct {
+	calls *[]string
+}
+
+func (i auditInterceptor) Intercept(_ context.Context, resp any) (any, error) {
+	*i.calls = append(*i.calls, "audit")
+
+	return resp, nil
+}
+
+type failingInterceptor struct{}
+
+func (failingInterceptor) Intercept(_ context.Context, resp ProfileResponse) (ProfileResponse, error) {
+	return resp, NewForbiddenError("response not allowed")
+}
+
+type retypingInterceptor struct{}
+
+func (retypingInterceptor) Intercept(_ context.Context, _ any) (any, error) {
+	return "not a profile", nil
+}
+
+func getProfile(t *testing.T, router *TypedRouter) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/profiles/7", http.NoBody))
+
+	return w
+}
+
+func TestResponseInterceptor_RunsInOrderBeforeEncoding(t *testing.T) {
+	var calls []string
+	router := NewRouter(WithResponseInterceptors(auditInterceptor{calls: &calls}))
+	GET(router, "/profiles/{id}", &profileHandler{},
+		WithResponseInterceptor[ProfileResponse](stampInterceptor{calls: &calls}),
+		WithResponseInterceptor[ProfileResponse](redactEmailInterceptor{calls: &calls}),
+	)
+
+	w := getProfile(t, router)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"7","server_time":"2024-01-01T00:00:00Z"}`, w.Body.String())
+	assert.Equal(t, []string{"audit", "stamp", "redact"}, calls)
+}
+
+func TestResponseInterceptor_RouterInterceptorSkipsOtherTypes(t *testing.T) {
+	var calls []string
+	router := NewRouter(WithResponseInterceptors(stampInterceptor{calls: &calls}))
+	GET(router, "/profiles/{id}", &profileHandler{})
+	GET(router, "/other/{id}", &metricsTestHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/other/1", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, calls)
+
+	w = getProfile(t, router)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"stamp"}, calls)
+}
+
+func TestResponseInterceptor_ErrorIsMapped(t *testing.T) {
+	var calls []string
+	router := NewRouter()
+	GET(router, "/profiles/{id}", &profileHandler{},
+		WithResponseInterceptor[ProfileResponse](failingInterceptor{}),
+		WithResponseInterceptor[ProfileResponse](stampInterceptor{calls: &calls}),
+	)
+
+	w := getProfile(t, router)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, calls, "later interceptors must not run after an error")
+}
+
+func TestResponseInterceptor_AnyInterceptorMustKeepType(t *testing.T) {
+	router := NewRouter(WithResponseInterceptors(retypingInterceptor{}))
+	GET(router, "/profiles/{id}", &profileHandler{})
+
+	w := getProfile(t, router)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,104 @@
+package typedhttp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ResponseRecorder wraps an http.ResponseWriter and records the status code and number
+// of body bytes written through it, for middleware that logs or measures responses.
+// Writes go straight to the wrapped writer; nothing is buffered.
+//
+// Flush, Hijack and Push are passed through when the wrapped writer supports them,
+// and Unwrap exposes it to http.ResponseController.
+type ResponseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// NewResponseRecorder wraps w.
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+// Status returns the status code sent to the client. It is 200 until the handler writes
+// a header, matching what net/http sends for handlers that only write a body.
+func (rw *ResponseRecorder) Status() int {
+	return rw.status
+}
+
+// BytesWritten returns the number of body bytes written so far.
+func (rw *ResponseRecorder) BytesWritten() int64 {
+	return rw.bytes
+}
+
+// WroteHeader reports whether the response headers have been sent.
+func (rw *ResponseRecorder) WroteHeader() bool {
+	return rw.wroteHeader
+}
+
+// WriteHeader records the first final status code. Informational 1xx responses are
+// passed through without being recorded.
+func (rw *ResponseRecorder) WriteHeader(code int) {
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		rw.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *ResponseRecorder) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+
+	return n, err
+}
+
+// Flush implements http.Flusher. It is a no-op when the wrapped writer cannot flush.
+func (rw *ResponseRecorder) Flush() {
+	rw.wroteHeader = true
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (rw *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: hijack", http.ErrNotSupported)
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err == nil {
+		rw.wroteHeader = true
+	}
+
+	return conn, buf, err
+}
+
+// Push implements http.Pusher.
+func (rw *ResponseRecorder) Push(target string, opts *http.PushOptions) error {
+	pusher, ok := rw.ResponseWriter.(http.Pusher)
+	if !ok {
+		return fmt.Errorf("%w: push", http.ErrNotSupported)
+	}
+
+	return pusher.Push(target, opts)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (rw *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,124 @@
+package typedhttp
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseRecorder_CapturesStatusAndBytes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	recorder := NewResponseRecorder(rec)
+
+	assert.Equal(t, http.StatusOK, recorder.Status())
+	assert.False(t, recorder.WroteHeader())
+
+	recorder.WriteHeader(http.StatusCreated)
+	_, err := recorder.Write([]byte(`{"id":`))
+	require.NoError(t, err)
+	_, err = recorder.Write([]byte(`"42"}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, recorder.Status())
+	assert.Equal(t, int64(11), recorder.BytesWritten())
+	assert.True(t, recorder.WroteHeader())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `{"id":"42"}`, rec.Body.String())
+}
+
+func TestResponseRecorder_KeepsFirstStatus(t *testing.T) {
+	recorder := NewResponseRecorder(httptest.NewRecorder())
+
+	_, err := recorder.Write([]byte("ok"))
+	require.NoError(t, err)
+	recorder.WriteHeader(http.StatusInternalServerError)
+
+	assert.Equal(t, http.StatusOK, recorder.Status())
+}
+
+func TestResponseRecorder_SkipsInformationalStatus(t *testing.T) {
+	recorder := NewResponseRecorder(httptest.NewRecorder())
+
+	recorder.WriteHeader(http.StatusEarlyHints)
+	recorder.WriteHeader(http.StatusAccepted)
+
+	assert.Equal(t, http.StatusAccepted, recorder.Status())
+}
+
+func TestResponseRecorder_Flush(t *testing.T) {
+	rec := httptest.NewRecorder()
+	recorder := NewResponseRecorder(rec)
+
+	var w http.ResponseWriter = recorder
+	flusher, ok := w.(http.Flusher)
+	require.True(t, ok)
+	flusher.Flush()
+	assert.True(t, rec.Flushed)
+
+	require.NoError(t, http.NewResponseController(recorder).Flush())
+}
+
+// hijackableRecorder is an httptest.ResponseRecorder whose connection can be taken over.
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (h *hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return h.conn, bufio.NewReadWriter(bufio.NewReader(h.conn), bufio.NewWriter(h.conn)), nil
+}
+
+func TestResponseRecorder_Hijack(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	recorder := NewResponseRecorder(&hijackableRecorder{ResponseRecorder: httptest.NewRecorder(), conn: server})
+
+	var w http.ResponseWriter = recorder
+	hijacker, ok := w.(http.Hijacker)
+	require.True(t, ok)
+	conn, _, err := hijacker.Hijack()
+	require.NoError(t, err)
+	assert.Same(t, server, conn)
+	assert.True(t, recorder.WroteHeader())
+
+	conn, _, err = http.NewResponseController(recorder).Hijack()
+	require.NoError(t, err)
+	assert.Same(t, server, conn)
+}
+
+func TestResponseRecorder_UnsupportedHijackAndPush(t *testing.T) {
+	recorder := NewResponseRecorder(httptest.NewRecorder())
+
+	_, _, err := recorder.Hijack()
+	assert.True(t, errors.Is(err, http.ErrNotSupported))
+	assert.True(t, errors.Is(recorder.Push("/app.js", nil), http.ErrNotSupported))
+	assert.False(t, recorder.WroteHeader())
+}
+
+func TestResponseRecorder_ThroughRouter(t *testing.T) {
+	var captured *ResponseRecorder
+	capture := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			captured = NewResponseRecorder(w)
+			next.ServeHTTP(captured, r)
+		})
+	}
+
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{}, WithMiddleware(capture))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/7", http.NoBody))
+
+	require.NotNil(t, captured)
+	assert.Equal(t, rec.Code, captured.Status())
+	assert.Equal(t, int64(rec.Body.Len()), captured.BytesWritten())
+}
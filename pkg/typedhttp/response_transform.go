@@ -0,0 +1,410 @@
+package typedhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidResponseTransform is returned when a response transform cannot be applied
+// to the value of its field.
+var ErrInvalidResponseTransform = errors.New("invalid response transform")
+
+// ResponseTransform returns the value encoded in place of a response field. arg is the
+// text after "=" in the tag, e.g. the layout in `response_transform:"format_time=2006-01-02"`.
+// The result may have another type than the field, such as a string for a time.Time.
+type ResponseTransform func(value any, arg string) (any, error)
+
+// Built-in response transforms.
+const (
+	ResponseTransformMaskCard   = "mask_card"   // Every digit but the last four replaced with *
+	ResponseTransformToLower    = "to_lower"    // Lowercased string
+	ResponseTransformToUpper    = "to_upper"    // Uppercased string
+	ResponseTransformTrimSpace  = "trim_space"  // String without surrounding white space
+	ResponseTransformFormatTime = "format_time" // Time in the layout or registered format named by arg
+)
+
+// builtinResponseTransforms are the transforms registered by the package.
+var builtinResponseTransforms = map[string]ResponseTransform{
+	ResponseTransformMaskCard:   stringTransform(maskCard),
+	ResponseTransformToLower:    stringTransform(strings.ToLower),
+	ResponseTransformToUpper:    stringTransform(strings.ToUpper),
+	ResponseTransformTrimSpace:  stringTransform(strings.TrimSpace),
+	ResponseTransformFormatTime: formatTime,
+}
+
+// responseTransforms holds the registered response transforms, keyed by name.
+var responseTransforms sync.Map // string -> ResponseTransform
+
+// RegisterResponseTransform makes transform available to response fields tagged with
+// name, e.g. `response_transform:"initials"`. Built-in names are reserved. Register
+// transforms at init time; registering a name again replaces its transform.
+func RegisterResponseTransform(name string, transform ResponseTransform) {
+	if name == "" || transform == nil {
+		panic("typedhttp: response transform needs a name and a function")
+	}
+	if _, ok := builtinResponseTransforms[name]; ok {
+		panic(fmt.Sprintf("typedhttp: response transform %q is built in", name))
+	}
+
+	responseTransforms.Store(name, transform)
+}
+
+// lookupResponseTransform returns the transform registered under name.
+func lookupResponseTransform(name string) (ResponseTransform, bool) {
+	if transform, ok := builtinResponseTransforms[name]; ok {
+		return transform, true
+	}
+	if transform, ok := responseTransforms.Load(name); ok {
+		return transform.(ResponseTransform), true
+	}
+
+	return nil, false
+}
+
+// WithResponseTransforms applies the response_transform tags of the response type to
+// the JSON body, centralizing presentation logic such as masking or date layouts:
+//
+//	CardNumber string    `json:"card_number" response_transform:"mask_card"`
+//	IssuedAt   time.Time `json:"issued_at" response_transform:"format_time=02 Jan 2006"`
+//
+// Transforms are computed by a response interceptor from the handler's values, before
+// encoding, and replace the fields in the encoded JSON body, found by the names the
+// router's FieldNameStrategy gives them. The handler's value is never modified. A
+// failing transform answers 500, and so does a response that is not JSON or lacks a
+// transformed field, so that fields such as masked card numbers are never sent
+// untransformed. Error responses are left alone; the OpenAPI document keeps describing
+// the field types.
+func WithResponseTransforms() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		transforms := &responseTransformer{names: cfg.FieldNameStrategy}
+		cfg.Middleware = append(cfg.Middleware, transforms.middleware)
+		cfg.ResponseInterceptors = append(cfg.ResponseInterceptors, ResponseInterceptor[any](transforms))
+	}
+}
+
+// responseTransformKey is the context key for the transformed fields of the current request.
+type responseTransformKey struct{}
+
+// transformedField is the encoded value of a field at a JSON path of object keys and
+// array indices.
+type transformedField struct {
+	path  []any
+	value any
+}
+
+// transformedFields holds the transformed fields of one request.
+type transformedFields struct {
+	fields []transformedField
+}
+
+// responseTransformer computes transformed fields as an interceptor and writes them into
+// the encoded body as HTTP middleware.
+type responseTransformer struct {
+	names FieldNameStrategy // Field names of the route, nil for encoding/json names
+}
+
+func (t *responseTransformer) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		transformed := &transformedFields{}
+		buffer := newBatchResponseWriter()
+		next.ServeHTTP(buffer, r.WithContext(context.WithValue(r.Context(), responseTransformKey{}, transformed)))
+
+		body := buffer.body.Bytes()
+		if len(transformed.fields) > 0 && len(body) > 0 {
+			// Fail closed: a body the transforms cannot be applied to is never sent as is
+			if !isJSONMediaType(buffer.header.Get("Content-Type")) {
+				writeTransformFailure(w)
+
+				return
+			}
+
+			patched, err := patchJSON(body, transformed.fields)
+			if err != nil {
+				writeTransformFailure(w)
+
+				return
+			}
+			body = patched
+			buffer.header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+
+		for name, values := range buffer.header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(buffer.status)
+		_, _ = w.Write(body)
+	})
+}
+
+// writeTransformFailure answers a response whose transforms could not be applied.
+func writeTransformFailure(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "Internal server error", Code: "INTERNAL_ERROR"})
+}
+
+// Intercept implements ResponseInterceptor[any].
+func (t *responseTransformer) Intercept(ctx context.Context, resp any) (any, error) {
+	transformed, ok := ctx.Value(responseTransformKey{}).(*transformedFields)
+	if !ok {
+		return resp, nil
+	}
+
+	fields, err := collectTransforms(reflect.ValueOf(resp), nil, t.names)
+	if err != nil {
+		return resp, err
+	}
+	transformed.fields = fields
+
+	return resp, nil
+}
+
+// collectTransforms returns the transformed fields of v, found at path. Fields left out
+// of the encoding by omitempty or omitzero are skipped, as are the fields of values that
+// encode themselves.
+func collectTransforms(v reflect.Value, path []any, names FieldNameStrategy) ([]transformedField, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	var fields []transformedField
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, embedded, ok := wireFieldName(field, names)
+			if !ok || omittedField(field, v.Field(i)) {
+				continue
+			}
+
+			fieldPath := append(path[:len(path):len(path)], name)
+			if embedded {
+				fieldPath = path
+			}
+
+			if tag, ok := field.Tag.Lookup("response_transform"); ok {
+				value, err := applyResponseTransform(tag, v.Field(i))
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", field.Name, err)
+				}
+				fields = append(fields, transformedField{path: fieldPath, value: value})
+
+				continue
+			}
+
+			if customJSON(field.Type) {
+				continue
+			}
+			nested, err := collectTransforms(v.Field(i), fieldPath, names)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			nested, err := collectTransforms(v.Index(i), append(path[:len(path):len(path)], i), names)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			nested, err := collectTransforms(iter.Value(), append(path[:len(path):len(path)], iter.Key().String()), names)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+		}
+	}
+
+	return fields, nil
+}
+
+// omittedField reports whether encoding/json leaves field out because of its omitempty
+// or omitzero option.
+func omittedField(field reflect.StructField, value reflect.Value) bool {
+	_, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+	for _, option := range strings.Split(options, ",") {
+		switch option {
+		case "omitzero":
+			if value.IsZero() {
+				return true
+			}
+		case "omitempty":
+			switch value.Kind() {
+			case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+				if value.Len() == 0 {
+					return true
+				}
+			case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+				if value.IsZero() {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// applyResponseTransform runs the transform named by tag on value.
+func applyResponseTransform(tag string, value reflect.Value) (any, error) {
+	name, arg, _ := strings.Cut(tag, "=")
+
+	transform, ok := lookupResponseTransform(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTransformation, name)
+	}
+
+	return transform(value.Interface(), arg)
+}
+
+// patchJSON replaces the values at the paths of fields in body. It fails when a path is
+// missing from the body, as the field would then be sent under another name.
+func patchJSON(body []byte, fields []transformedField) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if !patchPath(document, field.path, field.value) {
+			return nil, fmt.Errorf("%w: no field at %v in the encoded response", ErrInvalidResponseTransform, field.path)
+		}
+	}
+
+	return json.Marshal(document)
+}
+
+// patchPath sets the value at path in document and reports whether the path exists.
+func patchPath(document any, path []any, value any) bool {
+	for i, step := range path {
+		last := i == len(path)-1
+
+		switch key := step.(type) {
+		case string:
+			object, ok := document.(map[string]any)
+			if !ok {
+				return false
+			}
+			current, exists := object[key]
+			if !exists {
+				return false
+			}
+			if last {
+				object[key] = value
+
+				return true
+			}
+			document = current
+		case int:
+			array, ok := document.([]any)
+			if !ok || key >= len(array) {
+				return false
+			}
+			if last {
+				array[key] = value
+
+				return true
+			}
+			document = array[key]
+		}
+	}
+
+	return false
+}
+
+// stringTransform adapts a string function to a ResponseTransform for string fields.
+func stringTransform(transform func(string) string) ResponseTransform {
+	return func(value any, _ string) (any, error) {
+		v := reflect.ValueOf(value)
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %T is not a string", ErrInvalidResponseTransform, value)
+		}
+
+		return transform(v.String()), nil
+	}
+}
+
+// maskCard replaces every digit of a card number but the last four with *, keeping
+// separators: "4242 4242 4242 4242" becomes "**** **** **** 4242".
+func maskCard(number string) string {
+	digits := 0
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	masked := []rune(number)
+	for i, r := range masked {
+		if r < '0' || r > '9' {
+			continue
+		}
+		if digits > 4 {
+			masked[i] = '*'
+		}
+		digits--
+	}
+
+	return string(masked)
+}
+
+// formatTime formats a time.Time field in the layout named by arg: a Go layout, a
+// format registered with RegisterTimeFormat, or one of "rfc3339", "rfc822" and "unix".
+func formatTime(value any, arg string) (any, error) {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		t = *v
+	default:
+		return nil, fmt.Errorf("%w: %T is not a time.Time", ErrInvalidResponseTransform, value)
+	}
+
+	switch arg {
+	case "":
+		return nil, fmt.Errorf("%w: %s needs a layout", ErrInvalidResponseTransform, ResponseTransformFormatTime)
+	case "unix":
+		return t.Unix(), nil
+	case "rfc3339":
+		return t.Format(time.RFC3339), nil
+	case "rfc822":
+		return t.Format(time.RFC822), nil
+	default:
+		return t.Format(timeLayout(arg)), nil
+	}
+}
@@ -0,0 +1,201 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transformedCard struct {
+	CardNumber string    `json:"card_number" response_transform:"mask_card"`
+	Holder     string    `json:"holder" response_transform:"to_upper"`
+	IssuedAt   time.Time `json:"issued_at" response_transform:"format_time=02 Jan 2006"`
+	Nickname   string    `json:"nickname,omitempty" response_transform:"to_lower"`
+}
+
+type transformedWallet struct {
+	Owner string            `json:"owner" response_transform:"initials"`
+	Cards []transformedCard `json:"cards"`
+}
+
+type transformedWalletHandler struct {
+	wallet transformedWallet
+}
+
+func (h *transformedWalletHandler) Handle(_ context.Context, _ struct{}) (transformedWallet, error) {
+	return h.wallet, nil
+}
+
+func init() {
+	RegisterResponseTransform("initials", func(value any, _ string) (any, error) {
+		var initials strings.Builder
+		for _, word := range strings.Fields(value.(string)) {
+			initials.WriteString(word[:1])
+		}
+
+		return initials.String(), nil
+	})
+}
+
+func serveTransformedWallet(t *testing.T, wallet transformedWallet) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := NewRouter()
+	GET(router, "/wallet", &transformedWalletHandler{wallet: wallet}, WithResponseTransforms())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/wallet", nil))
+
+	return rr
+}
+
+func TestWithResponseTransforms(t *testing.T) {
+	issued := time.Date(2025, time.March, 5, 10, 30, 0, 0, time.UTC)
+	wallet := transformedWallet{
+		Owner: "Jane Doe",
+		Cards: []transformedCard{
+			{CardNumber: "4242 4242 4242 4242", Holder: "Jane Doe", IssuedAt: issued},
+			{CardNumber: "5555555555554444", Holder: "jane", IssuedAt: issued, Nickname: "Travel"},
+		},
+	}
+
+	rr := serveTransformedWallet(t, wallet)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"owner": "JD",
+		"cards": [
+			{"card_number": "**** **** **** 4242", "holder": "JANE DOE", "issued_at": "05 Mar 2025"},
+			{"card_number": "************4444", "holder": "JANE", "issued_at": "05 Mar 2025", "nickname": "travel"}
+		]
+	}`, rr.Body.String())
+	assert.Equal(t, "4242 4242 4242 4242", wallet.Cards[0].CardNumber, "handler value must not change")
+}
+
+type maskedCount struct {
+	Count int `json:"count" response_transform:"mask_card"`
+}
+
+type maskedCountHandler struct{}
+
+func (h *maskedCountHandler) Handle(_ context.Context, _ struct{}) (maskedCount, error) {
+	return maskedCount{Count: 3}, nil
+}
+
+func TestWithResponseTransforms_InvalidField(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/bad", &maskedCountHandler{}, WithResponseTransforms())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/bad", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestMaskCard(t *testing.T) {
+	assert.Equal(t, "**** **** **** 4242", maskCard("4242 4242 4242 4242"))
+	assert.Equal(t, "***-1234", maskCard("987-1234"))
+	assert.Equal(t, "123", maskCard("123"))
+}
+
+func TestFormatTime(t *testing.T) {
+	at := time.Date(2025, time.March, 5, 10, 30, 0, 0, time.UTC)
+
+	RegisterTimeFormat("transform-day", "2006/01/02")
+
+	tests := []struct {
+		arg  string
+		want any
+	}{
+		{arg: "rfc3339", want: "2025-03-05T10:30:00Z"},
+		{arg: "unix", want: at.Unix()},
+		{arg: "transform-day", want: "2025/03/05"},
+		{arg: "15:04", want: "10:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := formatTime(at, tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := formatTime("yesterday", "rfc3339")
+	assert.ErrorIs(t, err, ErrInvalidResponseTransform)
+}
+
+func TestRegisterResponseTransform_RejectsBuiltIn(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterResponseTransform(ResponseTransformMaskCard, func(value any, _ string) (any, error) { return value, nil })
+	})
+}
+
+type transformedAccount struct {
+	ID     uint64 `json:"id"`
+	Holder string `json:"holder" response_transform:"to_upper"`
+}
+
+type transformedAccountHandler struct{}
+
+func (h *transformedAccountHandler) Handle(_ context.Context, _ struct{}) (transformedAccount, error) {
+	return transformedAccount{ID: 12345678901234567890, Holder: "jane"}, nil
+}
+
+func TestWithResponseTransforms_KeepsLargeIntegers(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/account", &transformedAccountHandler{}, WithResponseTransforms())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/account", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"holder":"JANE","id":12345678901234567890}`, rr.Body.String())
+}
+
+func TestWithResponseTransforms_FailsClosedForOtherFormats(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/account", &transformedAccountHandler{}, WithMsgpack(), WithResponseTransforms())
+
+	req := httptest.NewRequest(http.MethodGet, "/account", nil)
+	req.Header.Set("Accept", MediaTypeMsgpack)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "jane")
+}
+
+type namedCard struct {
+	CardNumber string `response_transform:"mask_card"`
+	Label      string `json:",omitempty" response_transform:"to_upper"`
+}
+
+type namedCardHandler struct{}
+
+func (h *namedCardHandler) Handle(_ context.Context, _ struct{}) (namedCard, error) {
+	return namedCard{CardNumber: "4111111111111111"}, nil
+}
+
+func TestWithResponseTransforms_FieldNameStrategy(t *testing.T) {
+	router := NewRouter(WithFieldNameStrategy(SnakeCase))
+	GET(router, "/card", &namedCardHandler{}, WithResponseTransforms())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/card", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.JSONEq(t, `{"card_number":"************1111"}`, rr.Body.String())
+}
+
+func TestPatchJSON_MissingFieldFails(t *testing.T) {
+	_, err := patchJSON([]byte(`{"cardNumber":"4111111111111111"}`),
+		[]transformedField{{path: []any{"card_number"}, value: "************1111"}})
+
+	assert.ErrorIs(t, err, ErrInvalidResponseTransform)
+}
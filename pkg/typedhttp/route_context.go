@@ -0,0 +1,77 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+)
+
+// routePatternKey is the context key for the requestScope of a matched route.
+type routePatternKey struct{}
+
+// requestScope holds the per-request state the router adds to the context. Keeping
+// it in one value costs a single allocation per request.
+type requestScope struct {
+	pattern   RoutePattern
+	name      string
+	values    *Values // ownValues, or the store of an enclosing scope
+	ownValues Values
+}
+
+// RoutePattern identifies the registered route that matched a request.
+type RoutePattern struct {
+	Method string
+	Path   string // Route template, e.g. "/users/{id}"
+}
+
+// String returns the pattern in "METHOD /path" form.
+func (p RoutePattern) String() string {
+	return p.Method + " " + p.Path
+}
+
+// RoutePatternFromContext returns the route pattern matched for the current request.
+// It is available to handlers and to middleware registered with WithMiddleware.
+func RoutePatternFromContext(ctx context.Context) (RoutePattern, bool) {
+	scope, ok := ctx.Value(routePatternKey{}).(*requestScope)
+	if !ok || scope.pattern == (RoutePattern{}) {
+		return RoutePattern{}, false
+	}
+
+	return scope.pattern, true
+}
+
+// HandlerNameFromContext returns the name set with WithHandlerName for the matched
+// route, or the route pattern when none was set. It returns "" outside a TypedRouter.
+func HandlerNameFromContext(ctx context.Context) string {
+	scope, ok := ctx.Value(routePatternKey{}).(*requestScope)
+	if !ok || scope.pattern == (RoutePattern{}) {
+		return ""
+	}
+	if scope.name != "" {
+		return scope.name
+	}
+
+	return scope.pattern.String()
+}
+
+// contextWithRoutePattern returns a copy of ctx carrying the route pattern and handler
+// name. The request values of ctx, if any, are kept.
+func contextWithRoutePattern(ctx context.Context, pattern RoutePattern, name string) context.Context {
+	scope := &requestScope{pattern: pattern, name: name}
+	scope.values = &scope.ownValues
+	if enclosing, ok := ctx.Value(routePatternKey{}).(*requestScope); ok {
+		scope.values = enclosing.values
+	}
+
+	return context.WithValue(ctx, routePatternKey{}, scope)
+}
+
+// routePatternHandler stores the route pattern and handler name in the request context.
+type routePatternHandler struct {
+	pattern RoutePattern
+	name    string
+	next    http.Handler
+}
+
+func (h *routePatternHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.next.ServeHTTP(w, r.WithContext(contextWithRoutePattern(r.Context(), h.pattern, h.name)))
+}
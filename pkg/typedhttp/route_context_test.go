@@ -0,0 +1,59 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type routePatternTestRequest struct {
+	ID string `path:"id"`
+}
+
+type routePatternTestHandler struct {
+	seen RoutePattern
+	ok   bool
+}
+
+func (h *routePatternTestHandler) Handle(ctx context.Context, _ routePatternTestRequest) (map[string]string, error) {
+	h.seen, h.ok = RoutePatternFromContext(ctx)
+
+	return map[string]string{"status": "ok"}, nil
+}
+
+func TestRoutePatternFromContext(t *testing.T) {
+	handler := &routePatternTestHandler{}
+
+	var afterPattern RoutePattern
+	var afterOK bool
+	postMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			afterPattern, afterOK = RoutePatternFromContext(r.Context())
+		})
+	}
+
+	router := NewRouter()
+	GET(router, "/users/{id}", handler, WithMiddleware(postMiddleware))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.True(t, handler.ok)
+	assert.Equal(t, RoutePattern{Method: http.MethodGet, Path: "/users/{id}"}, handler.seen)
+	assert.Equal(t, "GET /users/{id}", handler.seen.String())
+
+	require.True(t, afterOK)
+	assert.Equal(t, handler.seen, afterPattern)
+}
+
+func TestRoutePatternFromContext_Missing(t *testing.T) {
+	_, ok := RoutePatternFromContext(context.Background())
+
+	assert.False(t, ok)
+}
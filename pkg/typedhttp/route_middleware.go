@@ -0,0 +1,66 @@
+package typedhttp
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// RoutePredicate selects routes by their registration.
+type RoutePredicate func(reg HandlerRegistration) bool
+
+// routeMiddleware is middleware applied to the routes a predicate selects.
+type routeMiddleware struct {
+	applies    RoutePredicate
+	middleware []Middleware
+}
+
+// WithRouteMiddleware applies middleware to every route for which applies returns true,
+// e.g. authentication everywhere except public endpoints. The predicate runs once per
+// route at registration, so requests pay nothing for routes it rejects. Route
+// middleware runs before handler-level middleware, in the order it was added, with
+// the route pattern already in the context.
+func WithRouteMiddleware(applies RoutePredicate, middleware ...Middleware) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.routeMiddleware = append(cfg.routeMiddleware, routeMiddleware{applies: applies, middleware: middleware})
+	}
+}
+
+// PathMatches selects routes whose path template matches any of the patterns. A
+// pattern ending in "/*" matches every path below its prefix, so "/admin/*" selects
+// "/admin/users" and "/admin/users/{id}"; other patterns use path.Match, where
+// "*" stays within one segment.
+func PathMatches(patterns ...string) RoutePredicate {
+	return func(reg HandlerRegistration) bool {
+		for _, pattern := range patterns {
+			if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+				if strings.HasPrefix(reg.Path, prefix+"/") {
+					return true
+				}
+
+				continue
+			}
+
+			if matched, _ := path.Match(pattern, reg.Path); matched {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// applyRouteMiddleware wraps handler with the route middleware selected for reg.
+func (r *TypedRouter) applyRouteMiddleware(reg HandlerRegistration, handler http.Handler) http.Handler {
+	for i := len(r.config.routeMiddleware) - 1; i >= 0; i-- {
+		scoped := r.config.routeMiddleware[i]
+		if !scoped.applies(reg) {
+			continue
+		}
+		for j := len(scoped.middleware) - 1; j >= 0; j-- {
+			handler = scoped.middleware[j](handler)
+		}
+	}
+
+	return handler
+}
@@ -0,0 +1,99 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// requireToken rejects requests without the admin token.
+func requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer admin" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestWithRouteMiddleware(t *testing.T) {
+	evaluated := 0
+	adminOnly := func(reg HandlerRegistration) bool {
+		evaluated++
+
+		return PathMatches("/admin/*")(reg)
+	}
+
+	router := NewRouter(WithRouteMiddleware(adminOnly, requireToken))
+	GET(router, "/admin/users/{id}", &metricsTestHandler{})
+	GET(router, "/public/items/{id}", &metricsTestHandler{})
+	assert.Equal(t, 2, evaluated, "evaluated once per route at registration")
+
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		status int
+	}{
+		{name: "admin without token", path: "/admin/users/1", status: http.StatusUnauthorized},
+		{name: "admin with token", path: "/admin/users/1", token: "Bearer admin", status: http.StatusOK},
+		{name: "public without token", path: "/public/items/1", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+
+	assert.Equal(t, 2, evaluated, "not evaluated per request")
+}
+
+func TestWithRouteMiddleware_RunsBeforeHandlerMiddleware(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	all := func(HandlerRegistration) bool { return true }
+
+	router := NewRouter(
+		WithRouteMiddleware(all, record("route-1"), record("route-2")),
+		WithRouteMiddleware(all, record("route-3")),
+	)
+	GET(router, "/items/{id}", &metricsTestHandler{}, WithMiddleware(record("handler")))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+	assert.Equal(t, []string{"route-1", "route-2", "route-3", "handler"}, order)
+}
+
+func TestPathMatches(t *testing.T) {
+	matches := func(path string, patterns ...string) bool {
+		return PathMatches(patterns...)(HandlerRegistration{Path: path})
+	}
+
+	assert.True(t, matches("/admin/users", "/admin/*"))
+	assert.True(t, matches("/admin/users/{id}", "/admin/*"))
+	assert.False(t, matches("/admin", "/admin/*"))
+	assert.False(t, matches("/administrators", "/admin/*"))
+	assert.True(t, matches("/v1/users", "/v*/users"))
+	assert.False(t, matches("/v1/users/{id}", "/v*/users"))
+	assert.True(t, matches("/health", "/admin/*", "/health"))
+}
@@ -0,0 +1,90 @@
+package typedhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors returned by the route kill switch.
+var (
+	ErrRouteNotRegistered = errors.New("route not registered")
+	ErrRouteDisabled      = errors.New("route temporarily unavailable")
+)
+
+// SetRouteEnabled enables or disables a registered route at runtime.
+// Disabled routes answer with the response configured by WithDisabledRouteResponse,
+// 503 Service Unavailable by default, and stay in the route table and OpenAPI spec.
+// It is safe to call while the router is serving requests.
+func (r *TypedRouter) SetRouteEnabled(method, path string, enabled bool) error {
+	route := RoutePattern{Method: method, Path: path}
+	if !r.hasRoute(route) {
+		return fmt.Errorf("%w: %s", ErrRouteNotRegistered, route)
+	}
+
+	if enabled {
+		r.disabled.Delete(route)
+	} else {
+		r.disabled.Store(route, struct{}{})
+	}
+
+	return nil
+}
+
+// RouteEnabled reports whether a route is currently enabled. Unknown routes are reported as enabled.
+func (r *TypedRouter) RouteEnabled(method, path string) bool {
+	_, disabled := r.disabled.Load(RoutePattern{Method: method, Path: path})
+
+	return !disabled
+}
+
+// hasRoute reports whether a handler is registered for the route.
+func (r *TypedRouter) hasRoute(route RoutePattern) bool {
+	handlers := r.routes()
+	for i := range handlers {
+		if handlers[i].Method == route.Method && handlers[i].Path == route.Path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithDisabledRouteResponse sets the status and JSON body returned by disabled routes.
+// A nil body sends the default error response.
+func WithDisabledRouteResponse(status int, body interface{}) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.DisabledRouteStatus = status
+		cfg.DisabledRouteBody = body
+	}
+}
+
+// routeSwitchHandler short-circuits requests to disabled routes.
+type routeSwitchHandler struct {
+	router *TypedRouter
+	route  RoutePattern
+	next   http.Handler
+}
+
+func (h *routeSwitchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, disabled := h.router.disabled.Load(h.route); !disabled {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	status := h.router.config.DisabledRouteStatus
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+
+	body := h.router.config.DisabledRouteBody
+	if body == nil {
+		writeErrorResponse(w, status, fmt.Errorf("%w: %s", ErrRouteDisabled, h.route))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,110 @@
+package typedhttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveItem(router *TypedRouter) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/1", http.NoBody))
+
+	return w
+}
+
+func TestSetRouteEnabled_TogglesRoute(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{})
+	GET(router, "/other/{id}", &metricsTestHandler{})
+
+	require.NoError(t, router.SetRouteEnabled(http.MethodGet, "/items/{id}", false))
+	assert.False(t, router.RouteEnabled(http.MethodGet, "/items/{id}"))
+
+	w := serveItem(router)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), ErrRouteDisabled.Error())
+
+	other := httptest.NewRecorder()
+	router.ServeHTTP(other, httptest.NewRequest(http.MethodGet, "/other/1", http.NoBody))
+	assert.Equal(t, http.StatusOK, other.Code, "other routes are unaffected")
+
+	require.NoError(t, router.SetRouteEnabled(http.MethodGet, "/items/{id}", true))
+	assert.Equal(t, http.StatusOK, serveItem(router).Code)
+}
+
+func TestSetRouteEnabled_UnknownRoute(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{})
+
+	err := router.SetRouteEnabled(http.MethodPost, "/items/{id}", false)
+
+	assert.ErrorIs(t, err, ErrRouteNotRegistered)
+}
+
+func TestWithDisabledRouteResponse(t *testing.T) {
+	router := NewRouter(WithDisabledRouteResponse(http.StatusGone, map[string]string{"status": "maintenance"}))
+	GET(router, "/items/{id}", &metricsTestHandler{})
+	require.NoError(t, router.SetRouteEnabled(http.MethodGet, "/items/{id}", false))
+
+	w := serveItem(router)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.JSONEq(t, `{"status":"maintenance"}`, w.Body.String())
+}
+
+func TestSetRouteEnabled_ConcurrentToggling(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.NoError(t, router.SetRouteEnabled(http.MethodGet, "/items/{id}", j%2 == 0))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				code := serveItem(router).Code
+				assert.Contains(t, []int{http.StatusOK, http.StatusServiceUnavailable}, code)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, router.SetRouteEnabled(http.MethodGet, "/items/{id}", true))
+	assert.Equal(t, http.StatusOK, serveItem(router).Code)
+}
+
+func TestSetRouteEnabled_ConcurrentRegistration(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &metricsTestHandler{})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			GET(router, fmt.Sprintf("/items/{id}/v%d", i), &metricsTestHandler{})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			assert.NoError(t, router.SetRouteEnabled(http.MethodGet, "/items/{id}", true))
+			assert.Contains(t, []int{http.StatusOK, http.StatusNotFound}, serveItem(router).Code)
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, router.GetHandlers(), 51)
+}
@@ -1,8 +1,12 @@
 package typedhttp
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
@@ -18,18 +22,25 @@ var (
 func getGlobalValidator() *validator.Validate {
 	globalValidatorOnce.Do(func() {
 		globalValidator = validator.New()
+		_ = RegisterPatternValidation(globalValidator)
 	})
 	return globalValidator
 }
 
 // getOptimalDecoder returns the most efficient decoder for the given request type.
 func getOptimalDecoder[T any]() RequestDecoder[T] {
+	return newOptimalDecoder[T](getGlobalValidator())
+}
+
+// newOptimalDecoder returns the most efficient decoder for the request type, validating
+// with v. A nil validator binds fields without validating them.
+func newOptimalDecoder[T any](v *validator.Validate) RequestDecoder[T] {
 	var result T
 	resultType := reflect.TypeOf(result)
 	
 	// Handle case where T is interface{} or similar
 	if resultType == nil || resultType.Kind() != reflect.Struct {
-		return NewCombinedDecoder[T](getGlobalValidator())
+		return NewCombinedDecoder[T](v)
 	}
 
 	hasPathTags := false
@@ -46,6 +57,18 @@ func getOptimalDecoder[T any]() RequestDecoder[T] {
 			continue
 		}
 
+		if _, ok := field.Tag.Lookup("body"); ok {
+			// Body-tagged fields are only bound by the combined decoder
+			return NewCombinedDecoder[T](v)
+		}
+		if field.Tag.Get("meta") != "" {
+			// So are request metadata fields
+			return NewCombinedDecoder[T](v)
+		}
+		if _, ok := field.Tag.Lookup("principal"); ok {
+			// And fields bound to the authenticated principal
+			return NewCombinedDecoder[T](v)
+		}
 		if field.Tag.Get("path") != "" {
 			hasPathTags = true
 		}
@@ -69,16 +92,16 @@ func getOptimalDecoder[T any]() RequestDecoder[T] {
 	// Optimize for common cases
 	if hasPathTags && !hasJSONTags && !hasQueryTags && !hasHeaderTags && !hasCookieTags && !hasFormTags {
 		// Path-only requests (like GET /users/{id})
-		return NewPathDecoder[T](getGlobalValidator())
+		return NewPathDecoder[T](v)
 	}
 	
 	if hasJSONTags && !hasPathTags && !hasQueryTags && !hasHeaderTags && !hasCookieTags && !hasFormTags {
 		// JSON-only requests (like simple POST with JSON body)
-		return NewJSONDecoder[T](getGlobalValidator())
+		return NewJSONDecoder[T](v)
 	}
 
 	// Fall back to combined decoder for complex cases
-	return NewCombinedDecoder[T](getGlobalValidator())
+	return NewCombinedDecoder[T](v)
 }
 
 // Core router types and functionality
@@ -100,6 +123,11 @@ type HandlerRegistration struct {
 	Metadata          OpenAPIMetadata
 	Config            HandlerConfig
 	MiddlewareEntries []MiddlewareEntry
+	// Name is the logical handler name set with WithHandlerName, or "" when unset.
+	Name string
+
+	// stub builds a handler that decodes like the route but answers with a fixed response.
+	stub func(response interface{}) http.Handler
 }
 
 // HTTPHandler wraps a typed handler with HTTP-specific functionality.
@@ -109,20 +137,41 @@ type HTTPHandler[TRequest, TResponse any] struct {
 	encoder        ResponseEncoder[TResponse]
 	errorMapper    ErrorMapper
 	middleware     []Middleware
+	requestMW      []TypedRequestMiddleware[TRequest]
+	interceptors   []ResponseInterceptor[TResponse]
 	metadata       OpenAPIMetadata
 	config         ObservabilityConfig
+	handlerConfig  HandlerConfig
 	cachedDecoder  RequestDecoder[TRequest]  // Cached decoder to avoid per-request creation
 	cachedEncoder  ResponseEncoder[TResponse] // Cached encoder to avoid per-request creation
+	codecEncoders  map[string]ResponseEncoder[TResponse] // Negotiated encoders keyed by media type
+	recoverPanics  bool                                  // Set by the router unless WithoutPanicRecovery is used
+	panicLogger    *slog.Logger
 }
 
 // ServeHTTP implements http.Handler for the typed handler.
 func (h *HTTPHandler[TRequest, TResponse]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.recoverPanics {
+		defer h.recoverPanic(w, r)
+	}
+
 	var req TRequest
 	var resp TResponse
 	var err error
 
 	// Apply middleware
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = enrichContext(r, h.handlerConfig.ContextEnrichers)
+		h.addVary(w.Header())
+
+		if len(h.handlerConfig.Consumes) > 0 {
+			if err = checkConsumes(r, h.handlerConfig.Consumes); err != nil {
+				h.handleError(w, r, err)
+
+				return
+			}
+		}
+
 		// Decode request using cached decoder
 		if h.decoder != nil {
 			req, err = h.decoder.Decode(r)
@@ -130,13 +179,36 @@ func (h *HTTPHandler[TRequest, TResponse]) ServeHTTP(w http.ResponseWriter, r *h
 			req, err = h.cachedDecoder.Decode(r)
 		} else {
 			// Fallback to creating decoder (should not happen with proper initialization)
-			v := validator.New()
+			v := h.handlerConfig.Validator
+			if v == nil {
+				v = getGlobalValidator()
+			}
 			decoder := NewCombinedDecoder[TRequest](v)
 			req, err = decoder.Decode(r)
 		}
 
+		if len(h.handlerConfig.StructValidators) > 0 && !h.handlerConfig.SkipValidation {
+			err = runStructValidators(h.handlerConfig.StructValidators, req, err)
+		}
+
 		if err != nil {
-			h.handleError(w, err)
+			h.translateValidationError(r, err)
+			h.handleError(w, r, err)
+
+			return
+		}
+
+		// Let typed request middleware mutate the decoded request
+		for _, mw := range h.requestMW {
+			if err = mw.BeforeTyped(r.Context(), &req); err != nil {
+				h.handleError(w, r, err)
+
+				return
+			}
+		}
+
+		if h.handlerConfig.DryRunParam != "" && isDryRun(r, h.handlerConfig.DryRunParam) {
+			h.writeDryRun(w, r, req)
 
 			return
 		}
@@ -144,19 +216,59 @@ func (h *HTTPHandler[TRequest, TResponse]) ServeHTTP(w http.ResponseWriter, r *h
 		// Call business logic handler
 		resp, err = h.handler.Handle(r.Context(), req)
 		if err != nil {
-			h.handleError(w, err)
+			h.handleError(w, r, err)
 
 			return
 		}
 
-		// Encode response using cached encoder
-		statusCode := http.StatusOK
-		if r.Method == http.MethodPost {
-			statusCode = http.StatusCreated
+		// Let interceptors transform the response before encoding
+		for _, interceptor := range h.interceptors {
+			if resp, err = interceptor.Intercept(r.Context(), resp); err != nil {
+				h.handleError(w, r, err)
+
+				return
+			}
+		}
+
+		if clientGone(r) {
+			return
+		}
+
+		// Redirects carry no body
+		if redirected, err := writeRedirectResponse(w, resp); redirected {
+			if err != nil {
+				h.handleError(w, r, err)
+			}
+
+			return
+		}
+
+		// Stream file downloads directly, bypassing the encoder
+		if streamed := writeStreamingResponse(r.Context(), w, resp); streamed {
+			return
+		}
+
+		// Write JSON array streams element by element
+		if streamed, err := writeJSONStreamResponse(r.Context(), w, resp); streamed {
+			if err != nil {
+				h.handleError(w, r, err)
+			}
+
+			return
+		}
+
+		statusCode := successStatusCode(r.Method, h.handlerConfig, resp)
+
+		// Pre-serialized bodies are written verbatim
+		if writeRawResponse(w, resp, statusCode) {
+			return
 		}
 
+		// Encode response using cached encoder
 		if h.encoder != nil {
 			err = h.encoder.Encode(w, resp, statusCode)
+		} else if encoder := h.negotiatedEncoder(r); encoder != nil {
+			err = encoder.Encode(w, resp, statusCode)
 		} else if h.cachedEncoder != nil {
 			err = h.cachedEncoder.Encode(w, resp, statusCode)
 		} else {
@@ -166,7 +278,7 @@ func (h *HTTPHandler[TRequest, TResponse]) ServeHTTP(w http.ResponseWriter, r *h
 		}
 
 		if err != nil {
-			h.handleError(w, err)
+			h.handleError(w, r, err)
 
 			return
 		}
@@ -181,8 +293,74 @@ func (h *HTTPHandler[TRequest, TResponse]) ServeHTTP(w http.ResponseWriter, r *h
 	finalHandler.ServeHTTP(w, r)
 }
 
-// handleError handles errors using the configured error mapper.
-func (h *HTTPHandler[TRequest, TResponse]) handleError(w http.ResponseWriter, err error) {
+// addVary lists the request headers used to select the representation.
+func (h *HTTPHandler[TRequest, TResponse]) addVary(header http.Header) {
+	if len(h.codecEncoders) > 0 {
+		AddVary(header, "Accept")
+	}
+
+	if h.handlerConfig.Translator != nil {
+		AddVary(header, "Accept-Language")
+	}
+}
+
+// negotiatedEncoder returns the codec encoder preferred by the Accept header,
+// or nil when JSON should be used.
+func (h *HTTPHandler[TRequest, TResponse]) negotiatedEncoder(r *http.Request) ResponseEncoder[TResponse] {
+	if len(h.codecEncoders) == 0 {
+		return nil
+	}
+
+	return h.codecEncoders[h.negotiatedMediaType(r)]
+}
+
+// negotiatedCodec returns the body codec preferred by the Accept header,
+// or nil when JSON should be used.
+func (h *HTTPHandler[TRequest, TResponse]) negotiatedCodec(r *http.Request) BodyCodec {
+	if len(h.handlerConfig.BodyCodecs) == 0 {
+		return nil
+	}
+
+	mediaType := h.negotiatedMediaType(r)
+	for _, codec := range h.handlerConfig.BodyCodecs {
+		if codec.ContentType() == mediaType {
+			return codec
+		}
+	}
+
+	return nil
+}
+
+// negotiatedMediaType picks the response media type from JSON and the enabled codecs.
+func (h *HTTPHandler[TRequest, TResponse]) negotiatedMediaType(r *http.Request) string {
+	offers := []string{"application/json"}
+	for _, codec := range h.handlerConfig.BodyCodecs {
+		offers = append(offers, codec.ContentType())
+	}
+
+	return negotiateContentType(r.Header.Get("Accept"), offers)
+}
+
+// translateValidationError localizes validation errors when a translator is configured.
+func (h *HTTPHandler[TRequest, TResponse]) translateValidationError(r *http.Request, err error) {
+	if h.handlerConfig.Translator == nil {
+		return
+	}
+
+	var valErr *ValidationError
+	if errors.As(err, &valErr) {
+		valErr.Translate(h.handlerConfig.Translator.ForRequest(r))
+	}
+}
+
+// handleError handles errors using the configured error mapper. The error body is
+// encoded in the format negotiated from the Accept header, falling back to JSON
+// when the codec cannot represent it. Nothing is written once the client has disconnected.
+func (h *HTTPHandler[TRequest, TResponse]) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if clientGone(r) {
+		return
+	}
+
 	var statusCode int
 	var response interface{}
 
@@ -194,6 +372,18 @@ func (h *HTTPHandler[TRequest, TResponse]) handleError(w http.ResponseWriter, er
 		statusCode, response = mapper.MapError(err)
 	}
 
+	writeErrorHeaders(w.Header(), err)
+
+	if codec := h.negotiatedCodec(r); codec != nil {
+		if body, marshalErr := codec.Marshal(response); marshalErr == nil {
+			w.Header().Set("Content-Type", codec.ContentType())
+			w.WriteHeader(statusCode)
+			_, _ = w.Write(body)
+
+			return
+		}
+	}
+
 	// Encode error response (this will set content-type and status code)
 	// Note: For error responses, we create a new encoder since it's interface{} type
 	encoder := NewJSONEncoder[interface{}]()
@@ -205,49 +395,178 @@ func (h *HTTPHandler[TRequest, TResponse]) handleError(w http.ResponseWriter, er
 
 // TypedRouter is a concrete implementation of Router with generic methods.
 type TypedRouter struct {
+	mu       sync.RWMutex // Guards handlers and hide405, which grow while requests are served
 	handlers []HandlerRegistration
 	mux      *http.ServeMux
+	config   RouterConfig
+	disabled sync.Map // RoutePattern -> struct{}, toggled by SetRouteEnabled
+	hide405  bool     // Set when any route is registered WithHide405
 }
 
 // NewRouter creates a new typed router.
-func NewRouter() *TypedRouter {
-	return &TypedRouter{
+func NewRouter(opts ...RouterOption) *TypedRouter {
+	router := &TypedRouter{
 		handlers: make([]HandlerRegistration, 0),
 		mux:      http.NewServeMux(),
 	}
+
+	for _, opt := range opts {
+		opt(&router.config)
+	}
+
+	return router
 }
 
 // ServeHTTP implements http.Handler.
 func (r *TypedRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	header := w.Header()
+	for name, value := range r.config.DefaultResponseHeaders {
+		if _, set := header[name]; !set {
+			header[name] = []string{value}
+		}
+	}
+
+	if !r.checkRequestLimits(w, req) {
+		return
+	}
+
+	if r.hidesMethods(req) {
+		http.NotFound(w, req)
+		return
+	}
+
+	if r.config.AutoOptions && req.Method == http.MethodOptions && r.serveAutoOptions(w, req) {
+		return
+	}
+
 	r.mux.ServeHTTP(w, req)
 }
 
+// serveAutoOptions answers an OPTIONS request from the route table.
+// It reports false when an explicit OPTIONS handler exists or no route matches the path.
+func (r *TypedRouter) serveAutoOptions(w http.ResponseWriter, req *http.Request) bool {
+	if _, pattern := r.mux.Handler(req); pattern != "" {
+		return false
+	}
+
+	allowed := r.allowedMethods(req)
+	if len(allowed) == 0 {
+		return false
+	}
+
+	w.Header().Set("Allow", strings.Join(append(allowed, http.MethodOptions), ", "))
+	w.WriteHeader(http.StatusNoContent)
+
+	return true
+}
+
+// allowedMethods returns the sorted registered methods that match the request path.
+func (r *TypedRouter) allowedMethods(req *http.Request) []string {
+	seen := make(map[string]bool)
+	var allowed []string
+
+	for _, registration := range r.routes() {
+		if seen[registration.Method] {
+			continue
+		}
+		seen[registration.Method] = true
+
+		probe := req.Clone(req.Context())
+		probe.Method = registration.Method
+		if _, pattern := r.mux.Handler(probe); pattern != "" {
+			allowed = append(allowed, registration.Method)
+		}
+	}
+
+	sort.Strings(allowed)
+
+	return allowed
+}
+
 // GetHandlers returns all registered handlers.
 func (r *TypedRouter) GetHandlers() []HandlerRegistration {
+	return r.routes()
+}
+
+// routes returns the registered handlers. Registration only appends, so the returned
+// slice can be read without the lock while later routes are added.
+func (r *TypedRouter) routes() []HandlerRegistration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return r.handlers
 }
 
+// Handle registers a plain http.Handler for method and path. Such routes are served
+// like typed ones, with the router's panic recovery, but are not listed by GetHandlers,
+// so they stay out of the OpenAPI document; use them for infrastructure endpoints such
+// as documentation or health checks.
+func (r *TypedRouter) Handle(method, path string, handler http.Handler) {
+	route := RoutePattern{Method: method, Path: path}
+	r.mux.Handle(route.String(), &routePatternHandler{pattern: route, next: r.recoverPanics(handler)})
+}
+
 // registerHandler is an internal method to register handlers.
 func (r *TypedRouter) registerHandler(
 	method, path string,
 	httpHandler http.Handler,
 	requestType, responseType reflect.Type,
-	metadata *OpenAPIMetadata,
+	config *HandlerConfig,
+	stub func(response interface{}) http.Handler,
 ) {
+	mustCompilePatterns(method+" "+path, requestType)
+
 	// Store registration metadata
 	registration := HandlerRegistration{
 		Method:            method,
 		Path:              path,
 		RequestType:       requestType,
 		ResponseType:      responseType,
-		Metadata:          *metadata,
+		Metadata:          config.Metadata,
+		Config:            *config,
 		MiddlewareEntries: []MiddlewareEntry{}, // TODO: Extract from HandlerConfig when implemented
+		Name:              config.Name,
+		stub:              stub,
 	}
 
+	r.mu.Lock()
 	r.handlers = append(r.handlers, registration)
+	if config.Hide405 {
+		r.hide405 = true
+	}
+	r.mu.Unlock()
+	httpHandler = r.config.bodyTransforms.wrap(httpHandler)
+	httpHandler = r.applyRouteMiddleware(registration, httpHandler)
 
 	// Register with HTTP mux
 	pattern := method + " " + path
+	label := pattern
+	if config.Name != "" {
+		label = config.Name
+	}
+	if r.config.Metrics != nil {
+		httpHandler = &metricsHandler{route: label, collector: r.config.Metrics, next: httpHandler}
+	}
+	route := RoutePattern{Method: method, Path: path}
+	if r.config.PayloadWarningThreshold > 0 {
+		httpHandler = &payloadSizeHandler{
+			route:     route,
+			threshold: r.config.PayloadWarningThreshold,
+			logger:    r.config.PayloadWarningLogger,
+			next:      httpHandler,
+		}
+	}
+	httpHandler = newObservabilityHandler(route, config.Name, config.Observability, httpHandler)
+	if r.config.SlowRequestCallback != nil {
+		httpHandler = &slowRequestHandler{
+			route:     route,
+			threshold: r.config.SlowRequestThreshold,
+			callback:  r.config.SlowRequestCallback,
+			next:      httpHandler,
+		}
+	}
+	httpHandler = &routeSwitchHandler{router: r, route: route, next: httpHandler}
+	httpHandler = &routePatternHandler{pattern: route, name: config.Name, next: httpHandler}
 	r.mux.HandleFunc(pattern, httpHandler.ServeHTTP)
 }
 
@@ -260,8 +579,49 @@ func RegisterHandler[TReq, TResp any](
 	handler Handler[TReq, TResp],
 	opts ...HandlerOption,
 ) {
+	// Router-wide interceptors run before handler-level ones
+	if len(router.config.ResponseInterceptors) > 0 {
+		opts = append([]HandlerOption{func(cfg *HandlerConfig) {
+			cfg.ResponseInterceptors = append(cfg.ResponseInterceptors, router.config.ResponseInterceptors...)
+		}}, opts...)
+	}
+
+	if router.config.Validator != nil {
+		opts = append([]HandlerOption{func(cfg *HandlerConfig) {
+			cfg.Validator = router.config.Validator
+		}}, opts...)
+	}
+
+	// Router-wide validators run before handler-level ones
+	if len(router.config.StructValidators) > 0 {
+		opts = append([]HandlerOption{func(cfg *HandlerConfig) {
+			cfg.StructValidators = append(cfg.StructValidators, router.config.StructValidators...)
+		}}, opts...)
+	}
+
+	if len(router.config.TrustedProxies) > 0 {
+		opts = append([]HandlerOption{func(cfg *HandlerConfig) {
+			cfg.TrustedProxies = router.config.TrustedProxies
+		}}, opts...)
+	}
+
+	if router.config.FieldNameStrategy != nil {
+		opts = append([]HandlerOption{func(cfg *HandlerConfig) {
+			cfg.FieldNameStrategy = router.config.FieldNameStrategy
+		}}, opts...)
+	}
+
+	// Router-wide enrichers run before handler-level ones
+	if len(router.config.ContextEnrichers) > 0 {
+		opts = append([]HandlerOption{func(cfg *HandlerConfig) {
+			cfg.ContextEnrichers = append(cfg.ContextEnrichers, router.config.ContextEnrichers...)
+		}}, opts...)
+	}
+
 	// Create HTTP handler wrapper
 	httpHandler := NewHTTPHandler(handler, opts...)
+	httpHandler.recoverPanics = !router.config.DisablePanicRecovery
+	httpHandler.panicLogger = router.config.PanicLogger
 
 	// Register with router
 	router.registerHandler(
@@ -270,7 +630,10 @@ func RegisterHandler[TReq, TResp any](
 		httpHandler,
 		reflect.TypeOf((*TReq)(nil)).Elem(),
 		reflect.TypeOf((*TResp)(nil)).Elem(),
-		&httpHandler.metadata,
+		&httpHandler.handlerConfig,
+		func(response interface{}) http.Handler {
+			return NewHTTPHandler[TReq, TResp](newStubHandler[TReq, TResp](response), opts...)
+		},
 	)
 }
 
@@ -304,6 +667,54 @@ func OPTIONS[TReq, TResp any](router *TypedRouter, path string, handler Handler[
 	RegisterHandler(router, "OPTIONS", path, handler, opts...)
 }
 
+// newCachedDecoder creates the decoder used for requests of type T under config.
+func newCachedDecoder[T any](config *HandlerConfig) RequestDecoder[T] {
+	var zero T
+	if composed, ok := any(zero).(composedRequest); ok {
+		if decoder, ok := composed.newDecoder(config).(RequestDecoder[T]); ok {
+			return decoder
+		}
+	}
+
+	v := config.Validator
+	if v == nil {
+		v = getGlobalValidator()
+	}
+	if config.SkipValidation {
+		v = nil
+	}
+
+	// Create optimal cached decoder based on request type
+	var decoder RequestDecoder[T]
+	if len(config.BodyCodecs) > 0 {
+		// Only the combined decoder can switch body formats per request
+		decoder = NewCombinedDecoder[T](v)
+	} else {
+		decoder = newOptimalDecoder[T](v)
+	}
+
+	if combined, ok := decoder.(*CombinedDecoder[T]); ok {
+		if config.MaxMultipartMemory > 0 {
+			combined.setMaxMultipartMemory(config.MaxMultipartMemory)
+		}
+		for _, codec := range config.BodyCodecs {
+			combined.addBodyCodec(codec)
+		}
+		combined.trustedProxies = config.TrustedProxies
+	}
+
+	if names := newFieldNamer(config.FieldNameStrategy); names != nil {
+		switch typed := decoder.(type) {
+		case *CombinedDecoder[T]:
+			typed.setFieldNames(names)
+		case *JSONDecoder[T]:
+			typed.names = names
+		}
+	}
+
+	return decoder
+}
+
 // NewHTTPHandler creates a new HTTP handler wrapper around a typed handler.
 func NewHTTPHandler[TRequest, TResponse any](
 	handler Handler[TRequest, TResponse],
@@ -315,9 +726,10 @@ func NewHTTPHandler[TRequest, TResponse any](
 	}
 
 	httpHandler := &HTTPHandler[TRequest, TResponse]{
-		handler:  handler,
-		metadata: config.Metadata,
-		config:   config.Observability,
+		handler:       handler,
+		metadata:      config.Metadata,
+		config:        config.Observability,
+		handlerConfig: *config,
 	}
 
 	// Set decoder
@@ -326,8 +738,7 @@ func NewHTTPHandler[TRequest, TResponse any](
 			httpHandler.decoder = decoder
 		}
 	} else {
-		// Create optimal cached decoder based on request type
-		httpHandler.cachedDecoder = getOptimalDecoder[TRequest]()
+		httpHandler.cachedDecoder = newCachedDecoder[TRequest](config)
 	}
 
 	// Set encoder
@@ -337,7 +748,14 @@ func NewHTTPHandler[TRequest, TResponse any](
 		}
 	} else {
 		// Create cached encoder if none provided
-		httpHandler.cachedEncoder = NewJSONEncoder[TResponse]()
+		httpHandler.cachedEncoder = &JSONEncoder[TResponse]{names: newFieldNamer(config.FieldNameStrategy)}
+
+		for _, codec := range config.BodyCodecs {
+			if httpHandler.codecEncoders == nil {
+				httpHandler.codecEncoders = make(map[string]ResponseEncoder[TResponse])
+			}
+			httpHandler.codecEncoders[codec.ContentType()] = NewCodecEncoder[TResponse](codec)
+		}
 	}
 
 	// Set error mapper
@@ -347,6 +765,8 @@ func NewHTTPHandler[TRequest, TResponse any](
 
 	// Set middleware
 	httpHandler.middleware = config.Middleware
+	httpHandler.requestMW = extractRequestMiddleware[TRequest](resolveMiddleware(config.TypedMiddleware))
+	httpHandler.interceptors = extractResponseInterceptors[TResponse](config.ResponseInterceptors)
 
 	return httpHandler
 }
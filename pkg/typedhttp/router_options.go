@@ -0,0 +1,138 @@
+package typedhttp
+
+import (
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RouterOption configures a TypedRouter.
+type RouterOption func(*RouterConfig)
+
+// RouterConfig contains router-wide configuration.
+type RouterConfig struct {
+	// AutoOptions answers OPTIONS requests for registered paths with 204 and an Allow header.
+	AutoOptions bool
+	// ResponseInterceptors are applied to every handler whose response type they accept.
+	ResponseInterceptors []interface{}
+	// Metrics receives per-route request counts, error counts and latencies.
+	Metrics RouteMetricsCollector
+	// SlowRequestThreshold is the soft latency budget after which SlowRequestCallback is invoked.
+	SlowRequestThreshold time.Duration
+	SlowRequestCallback  SlowRequestFunc
+	// PayloadWarningThreshold is the body size in bytes above which PayloadWarningLogger
+	// warns. Zero disables the warning.
+	PayloadWarningThreshold int64
+	PayloadWarningLogger    *slog.Logger
+	// MaxQueryLength limits the raw query string in bytes. Zero means unlimited.
+	MaxQueryLength int
+	// MaxHeaderCount limits the number of request header lines. Zero means unlimited.
+	MaxHeaderCount int
+	// DisabledRouteStatus and DisabledRouteBody are returned by routes turned off with
+	// SetRouteEnabled. They default to 503 and an ErrorResponse.
+	DisabledRouteStatus int
+	DisabledRouteBody   interface{}
+	// ContextEnrichers run for every route before handler-level enrichers.
+	ContextEnrichers []ContextEnricher
+	// DisablePanicRecovery lets handler panics propagate instead of answering 500.
+	DisablePanicRecovery bool
+	// PanicLogger records recovered panics with their stack trace. Nil means slog.Default().
+	PanicLogger *slog.Logger
+	// Validator is shared by the decoders of every registered handler.
+	Validator *validator.Validate
+	// DefaultResponseHeaders are set on every response before the request is routed,
+	// keyed by canonical header name.
+	DefaultResponseHeaders map[string]string
+	// StructValidators run on the requests of every route before handler-level ones.
+	StructValidators []StructValidator
+	// FieldNameStrategy names untagged JSON fields of every route.
+	FieldNameStrategy FieldNameStrategy
+	// TrustedProxies are the proxies whose X-Forwarded-For header is used for meta:"remote_ip".
+	TrustedProxies []netip.Prefix
+	// bodyTransforms rewrite raw JSON bodies around every route.
+	bodyTransforms bodyTransforms
+	// routeMiddleware is applied to the routes selected by its predicate.
+	routeMiddleware []routeMiddleware
+}
+
+// WithAutoOptions synthesizes OPTIONS responses from the route table.
+// Explicitly registered OPTIONS handlers take precedence.
+func WithAutoOptions() RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.AutoOptions = true
+	}
+}
+
+// WithResponseInterceptors registers ResponseInterceptor values for all routes.
+// Each interceptor applies to handlers with a matching response type, and a
+// ResponseInterceptor[any] applies to all of them. They run before handler-level interceptors.
+func WithResponseInterceptors(interceptors ...interface{}) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.ResponseInterceptors = append(cfg.ResponseInterceptors, interceptors...)
+	}
+}
+
+// WithRouteMetrics records RED metrics for every route, keyed by the route template.
+func WithRouteMetrics(collector RouteMetricsCollector) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.Metrics = collector
+	}
+}
+
+// WithSlowRequestThreshold calls callback for every request that takes longer than threshold.
+// The request still completes normally; use it to spot latency regressions before they
+// turn into timeouts. Fast requests only pay for a single time comparison.
+func WithSlowRequestThreshold(threshold time.Duration, callback SlowRequestFunc) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.SlowRequestThreshold = threshold
+		cfg.SlowRequestCallback = callback
+	}
+}
+
+// WithMaxQueryLength rejects requests whose raw query string is longer than n bytes
+// with 414 URI Too Long. Queries are unlimited by default; a few kilobytes is a
+// sensible limit for most APIs.
+func WithMaxQueryLength(n int) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.MaxQueryLength = n
+	}
+}
+
+// WithMaxHeaderCount rejects requests with more than n header lines with
+// 431 Request Header Fields Too Large. Headers are unlimited by default, apart from
+// the byte limit of http.Server; around 100 leaves room for proxies and tracing.
+func WithMaxHeaderCount(n int) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.MaxHeaderCount = n
+	}
+}
+
+// WithValidator makes every handler's decoders validate with v instead of the default
+// validator, so custom validations and tag name functions registered on v apply to
+// path, query, header, cookie, form and body fields alike. The built-in "pattern"
+// validation is registered on v.
+func WithValidator(v *validator.Validate) RouterOption {
+	_ = RegisterPatternValidation(v)
+
+	return func(cfg *RouterConfig) {
+		cfg.Validator = v
+	}
+}
+
+// WithDefaultResponseHeaders sets headers on every response of the router, including
+// errors and 404s. Defaults are written before routing, so middleware and handlers that
+// call Header().Set with the same key override them; Header().Add appends a second
+// value instead. Headers already set by middleware outside the router are kept.
+func WithDefaultResponseHeaders(headers map[string]string) RouterOption {
+	return func(cfg *RouterConfig) {
+		if cfg.DefaultResponseHeaders == nil {
+			cfg.DefaultResponseHeaders = make(map[string]string, len(headers))
+		}
+		for name, value := range headers {
+			cfg.DefaultResponseHeaders[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type optionsTestRequest struct {
+	ID string `path:"id"`
+}
+
+type optionsTestResponse struct {
+	Handled string `json:"handled"`
+}
+
+type optionsTestHandler struct {
+	name string
+}
+
+func (h *optionsTestHandler) Handle(_ context.Context, _ optionsTestRequest) (optionsTestResponse, error) {
+	return optionsTestResponse{Handled: h.name}, nil
+}
+
+func serveOptions(router *TypedRouter, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestWithAutoOptions_ListsRegisteredMethods(t *testing.T) {
+	router := NewRouter(WithAutoOptions())
+	GET(router, "/items/{id}", &optionsTestHandler{name: "get"})
+	POST(router, "/items/{id}", &optionsTestHandler{name: "post"})
+	DELETE(router, "/items/{id}", &optionsTestHandler{name: "delete"})
+	PUT(router, "/other/{id}", &optionsTestHandler{name: "put"})
+
+	w := serveOptions(router, "/items/42")
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "DELETE, GET, POST, OPTIONS", w.Header().Get("Allow"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestWithAutoOptions_ExplicitHandlerWins(t *testing.T) {
+	router := NewRouter(WithAutoOptions())
+	GET(router, "/items/{id}", &optionsTestHandler{name: "get"})
+	OPTIONS(router, "/items/{id}", &optionsTestHandler{name: "options"})
+
+	w := serveOptions(router, "/items/42")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"handled":"options"}`, w.Body.String())
+}
+
+func TestWithAutoOptions_UnknownPath(t *testing.T) {
+	router := NewRouter(WithAutoOptions())
+	GET(router, "/items/{id}", &optionsTestHandler{name: "get"})
+
+	w := serveOptions(router, "/missing")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWithAutoOptions_DisabledByDefault(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/items/{id}", &optionsTestHandler{name: "get"})
+
+	w := serveOptions(router, "/items/42")
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
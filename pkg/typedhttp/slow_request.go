@@ -0,0 +1,30 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// SlowRequestFunc is called for requests that exceed the slow request threshold.
+// The route carries both the method and the path template of the matched route.
+type SlowRequestFunc func(ctx context.Context, route RoutePattern, elapsed time.Duration)
+
+// slowRequestHandler reports requests that take longer than threshold.
+// Unlike a timeout it never interrupts the request.
+type slowRequestHandler struct {
+	route     RoutePattern
+	threshold time.Duration
+	callback  SlowRequestFunc
+	next      http.Handler
+}
+
+func (h *slowRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	h.next.ServeHTTP(w, r)
+
+	if elapsed := time.Since(start); elapsed > h.threshold {
+		h.callback(r.Context(), h.route, elapsed)
+	}
+}
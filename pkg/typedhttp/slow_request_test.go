@@ -0,0 +1,64 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sleepyRequest struct {
+	ID string `path:"id"`
+}
+
+type sleepyHandler struct {
+	delay time.Duration
+}
+
+func (h *sleepyHandler) Handle(_ context.Context, req sleepyRequest) (ProfileResponse, error) {
+	time.Sleep(h.delay)
+
+	return ProfileResponse{ID: req.ID}, nil
+}
+
+type slowRequestRecord struct {
+	route   RoutePattern
+	elapsed time.Duration
+	fromCtx bool
+}
+
+func TestWithSlowRequestThreshold(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		records []slowRequestRecord
+	)
+	callback := func(ctx context.Context, route RoutePattern, elapsed time.Duration) {
+		_, ok := RoutePatternFromContext(ctx)
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, slowRequestRecord{route: route, elapsed: elapsed, fromCtx: ok})
+	}
+
+	router := NewRouter(WithSlowRequestThreshold(20*time.Millisecond, callback))
+	GET(router, "/slow/{id}", &sleepyHandler{delay: 40 * time.Millisecond})
+	GET(router, "/fast/{id}", &sleepyHandler{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast/1", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, records, "fast requests must not be reported")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow/1", http.NoBody))
+	require.Equal(t, http.StatusOK, w.Code, "slow requests still complete")
+
+	require.Len(t, records, 1)
+	assert.Equal(t, RoutePattern{Method: http.MethodGet, Path: "/slow/{id}"}, records[0].route)
+	assert.GreaterOrEqual(t, records[0].elapsed, 40*time.Millisecond)
+	assert.True(t, records[0].fromCtx)
+}
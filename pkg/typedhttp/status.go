@@ -0,0 +1,64 @@
+package typedhttp
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// StatusCoder is implemented by response types that choose their own success status,
+// e.g. an upsert returning 201 when it created a resource and 200 when it updated one.
+//
+// The status of a successful response is resolved in this order:
+//  1. StatusCode() on the response, when it returns a non-zero value
+//  2. WithResponseStatus on the route
+//  3. The method default: 201 for POST, 200 otherwise
+//
+// Declare every status StatusCode() may return with WithSuccessStatuses so the
+// OpenAPI document lists them.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// DefaultStatusCode returns the success status used for method when nothing else is configured.
+func DefaultStatusCode(method string) int {
+	if method == http.MethodPost {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+// SuccessStatuses returns the success statuses documented for a route with the given config.
+func SuccessStatuses(method string, config HandlerConfig) []int {
+	if len(config.SuccessStatuses) > 0 {
+		return config.SuccessStatuses
+	}
+
+	if config.ResponseStatus != 0 {
+		return []int{config.ResponseStatus}
+	}
+
+	return []int{DefaultStatusCode(method)}
+}
+
+// successStatusCode resolves the status for a successful response.
+func successStatusCode(method string, config HandlerConfig, resp interface{}) int {
+	if coder, ok := resp.(StatusCoder); ok && !isNilPointer(resp) {
+		if code := coder.StatusCode(); code != 0 {
+			return code
+		}
+	}
+
+	if config.ResponseStatus != 0 {
+		return config.ResponseStatus
+	}
+
+	return DefaultStatusCode(method)
+}
+
+// isNilPointer reports whether v is a typed nil pointer.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
@@ -0,0 +1,101 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UpsertItemRequest struct {
+	ID   string `path:"id"`
+	Name string `json:"name"`
+}
+
+type UpsertItemResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	created bool
+}
+
+func (r UpsertItemResponse) StatusCode() int {
+	if r.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+type upsertItemHandler struct {
+	mu    sync.Mutex
+	items map[string]string
+}
+
+func (h *upsertItemHandler) Handle(_ context.Context, req UpsertItemRequest) (UpsertItemResponse, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, exists := h.items[req.ID]
+	h.items[req.ID] = req.Name
+
+	return UpsertItemResponse{ID: req.ID, Name: req.Name, created: !exists}, nil
+}
+
+func putItem(t *testing.T, router *TypedRouter, id, name string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPut, "/items/"+id, strings.NewReader(`{"name":"`+name+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestStatusCoder_Upsert(t *testing.T) {
+	router := NewRouter()
+	PUT(router, "/items/{id}", &upsertItemHandler{items: map[string]string{}},
+		WithSuccessStatuses(http.StatusOK, http.StatusCreated))
+
+	w := putItem(t, router, "a", "first")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"id":"a","name":"first"}`, w.Body.String())
+
+	w = putItem(t, router, "a", "second")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"id":"a","name":"second"}`, w.Body.String())
+}
+
+func TestStatusCoder_TakesPrecedenceOverResponseStatus(t *testing.T) {
+	router := NewRouter()
+	PUT(router, "/items/{id}", &upsertItemHandler{items: map[string]string{}},
+		WithResponseStatus(http.StatusAccepted))
+
+	w := putItem(t, router, "a", "first")
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestWithResponseStatus_OverridesMethodDefault(t *testing.T) {
+	router := NewRouter()
+	GET(router, "/profiles/{id}", &profileHandler{}, WithResponseStatus(http.StatusNonAuthoritativeInfo))
+
+	w := getProfile(t, router)
+	assert.Equal(t, http.StatusNonAuthoritativeInfo, w.Code)
+}
+
+func TestSuccessStatuses(t *testing.T) {
+	assert.Equal(t, []int{http.StatusCreated}, SuccessStatuses(http.MethodPost, HandlerConfig{}))
+	assert.Equal(t, []int{http.StatusOK}, SuccessStatuses(http.MethodPut, HandlerConfig{}))
+	assert.Equal(t, []int{http.StatusAccepted},
+		SuccessStatuses(http.MethodPost, HandlerConfig{ResponseStatus: http.StatusAccepted}))
+	assert.Equal(t, []int{http.StatusOK, http.StatusCreated},
+		SuccessStatuses(http.MethodPut, HandlerConfig{
+			ResponseStatus:  http.StatusAccepted,
+			SuccessStatuses: []int{http.StatusOK, http.StatusCreated},
+		}))
+}
@@ -0,0 +1,78 @@
+package typedhttp
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// DefaultStreamContentType is used for streamed responses that do not declare a content type.
+const DefaultStreamContentType = "application/octet-stream"
+
+// writeStreamingResponse writes resp as a raw stream if it is a StreamingResponse
+// or an io.Reader. It reports whether the response was handled.
+func writeStreamingResponse(ctx context.Context, w http.ResponseWriter, resp interface{}) bool {
+	var stream StreamingResponse
+
+	switch v := resp.(type) {
+	case StreamingResponse:
+		stream = v
+	case *StreamingResponse:
+		if v == nil {
+			return false
+		}
+		stream = *v
+	case io.Reader:
+		stream = StreamingResponse{Stream: v}
+	default:
+		return false
+	}
+
+	if closer, ok := stream.Stream.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	contentType := stream.ContentType
+	if contentType == "" {
+		contentType = DefaultStreamContentType
+	}
+	w.Header().Set("Content-Type", contentType)
+
+	if stream.Filename != "" {
+		w.Header().Set("Content-Disposition",
+			mime.FormatMediaType("attachment", map[string]string{"filename": stream.Filename}))
+	}
+
+	if stream.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(stream.Size, 10))
+	}
+
+	statusCode := stream.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+
+	if stream.Stream != nil {
+		// Headers are already committed, so a failed copy can only abort the stream.
+		_, _ = io.Copy(w, &contextReader{ctx: ctx, reader: stream.Stream})
+	}
+
+	return true
+}
+
+// contextReader stops reading from the underlying reader once the context is done.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
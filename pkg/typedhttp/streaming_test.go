@@ -0,0 +1,130 @@
+package typedhttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type DownloadReportRequest struct {
+	ID string `path:"id"`
+}
+
+// trackingReadCloser records whether Close was called.
+type trackingReadCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (t *trackingReadCloser) Close() error {
+	t.closed = true
+
+	return nil
+}
+
+type downloadReportHandler struct {
+	body *trackingReadCloser
+}
+
+func (h *downloadReportHandler) Handle(_ context.Context, req DownloadReportRequest) (StreamingResponse, error) {
+	return StreamingResponse{
+		ContentType: "application/pdf",
+		Filename:    "report-" + req.ID + ".pdf",
+		Stream:      h.body,
+		Size:        int64(len("%PDF-1.4 report")),
+	}, nil
+}
+
+type rawDownloadHandler struct {
+	body *trackingReadCloser
+}
+
+func (h *rawDownloadHandler) Handle(_ context.Context, _ DownloadReportRequest) (io.ReadCloser, error) {
+	return h.body, nil
+}
+
+func TestStreamingResponse_WritesStreamWithMetadata(t *testing.T) {
+	body := &trackingReadCloser{Reader: strings.NewReader("%PDF-1.4 report")}
+	router := NewRouter()
+	GET(router, "/downloads/{id}", &downloadReportHandler{body: body})
+
+	req := httptest.NewRequest(http.MethodGet, "/downloads/42", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=report-42.pdf`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "15", w.Header().Get("Content-Length"))
+	assert.Equal(t, "%PDF-1.4 report", w.Body.String())
+	assert.True(t, body.closed, "stream should be closed after writing")
+}
+
+func TestStreamingResponse_ReadCloserResponseType(t *testing.T) {
+	body := &trackingReadCloser{Reader: strings.NewReader("raw bytes")}
+	router := NewRouter()
+	GET(router, "/raw/{id}", &rawDownloadHandler{body: body})
+
+	req := httptest.NewRequest(http.MethodGet, "/raw/42", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, DefaultStreamContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "raw bytes", w.Body.String())
+	assert.True(t, body.closed)
+}
+
+// endlessReader produces data forever.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+
+	return len(p), nil
+}
+
+// cancelingWriter cancels the request context after the first write.
+type cancelingWriter struct {
+	*httptest.ResponseRecorder
+	cancel context.CancelFunc
+	writes int
+}
+
+func (w *cancelingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	w.cancel()
+
+	return w.ResponseRecorder.Write(p)
+}
+
+func TestStreamingResponse_StopsOnContextCancellation(t *testing.T) {
+	body := &trackingReadCloser{Reader: endlessReader{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &cancelingWriter{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+
+	handled := writeStreamingResponse(ctx, w, StreamingResponse{Stream: body})
+
+	require.True(t, handled)
+	assert.Equal(t, 1, w.writes, "stream should stop once the context is cancelled")
+	assert.True(t, body.closed)
+}
+
+func TestStreamingResponse_IgnoresRegularResponses(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	handled := writeStreamingResponse(context.Background(), w, map[string]string{"a": "b"})
+
+	assert.False(t, handled)
+	assert.Empty(t, w.Body.String())
+}
@@ -0,0 +1,174 @@
+package typedhttp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StructValidator is an additional validator run on every decoded request, after the
+// validate tags. Field failures are reported as FieldErrors and merged into the
+// ValidationError answered with 400; any other error is handled like a handler error.
+type StructValidator interface {
+	ValidateStruct(v interface{}) error
+}
+
+// FieldErrors maps lowercase field names to the rule each failed, like
+// ValidationError.Fields.
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field, rule := range e {
+		fields = append(fields, field+": "+rule)
+	}
+	sort.Strings(fields)
+
+	return "validation failed: " + strings.Join(fields, ", ")
+}
+
+// WithStructValidator adds a validator to the handler's validation chain. Validators run
+// in the order they are added, after router-wide ones. WithoutValidation skips them too.
+func WithStructValidator(v StructValidator) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.StructValidators = append(cfg.StructValidators, v)
+	}
+}
+
+// WithStructValidators adds validators to the validation chain of every route.
+func WithStructValidators(validators ...StructValidator) RouterOption {
+	return func(cfg *RouterConfig) {
+		cfg.StructValidators = append(cfg.StructValidators, validators...)
+	}
+}
+
+// runStructValidators runs the validation chain on a decoded request and merges the
+// failures into err, which is nil or the ValidationError returned by the decoder. Fields
+// that already failed keep their first failure.
+func runStructValidators(validators []StructValidator, req interface{}, err error) error {
+	var validationErr *ValidationError
+	if err != nil && !errors.As(err, &validationErr) {
+		return err
+	}
+
+	for _, v := range validators {
+		verr := v.ValidateStruct(req)
+		if verr == nil {
+			continue
+		}
+
+		var fieldErrs FieldErrors
+		if !errors.As(verr, &fieldErrs) {
+			return verr
+		}
+		if validationErr == nil {
+			validationErr = NewValidationError("Validation failed", make(map[string]string, len(fieldErrs)))
+		}
+		if validationErr.Fields == nil {
+			validationErr.Fields = make(map[string]string, len(fieldErrs))
+		}
+		for field, rule := range fieldErrs {
+			if _, failed := validationErr.Fields[field]; !failed {
+				validationErr.Fields[field] = rule
+			}
+		}
+	}
+
+	if validationErr == nil {
+		return nil
+	}
+
+	return validationErr
+}
+
+// TagRule reports whether a field value satisfies a rule. param is the text after "="
+// in the tag, e.g. "10" for max=10, or "" when there is none.
+type TagRule func(field reflect.Value, param string) bool
+
+// TagValidator is a StructValidator that enforces rules named in its own struct tag,
+// e.g. `biz:"unique_sku,max_discount=30"`. Rules are separated by commas and registered
+// with Rule. Nested structs are validated too.
+type TagValidator struct {
+	tag   string
+	rules map[string]TagRule
+}
+
+// NewTagValidator creates a validator reading the named struct tag.
+func NewTagValidator(tag string) *TagValidator {
+	return &TagValidator{tag: tag, rules: make(map[string]TagRule)}
+}
+
+// Rule registers a rule under name and returns v for chaining.
+func (v *TagValidator) Rule(name string, rule TagRule) *TagValidator {
+	v.rules[name] = rule
+
+	return v
+}
+
+// ValidateStruct implements StructValidator. It returns FieldErrors naming the first
+// failed rule of each field, and an error for rules that are not registered.
+func (v *TagValidator) ValidateStruct(s interface{}) error {
+	fieldErrs := FieldErrors{}
+	if err := v.validate(reflect.ValueOf(s), fieldErrs); err != nil {
+		return err
+	}
+
+	if len(fieldErrs) == 0 {
+		return nil
+	}
+
+	return fieldErrs
+}
+
+func (v *TagValidator) validate(value reflect.Value, fieldErrs FieldErrors) error {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag := field.Tag.Get(v.tag); tag != "" && tag != "-" {
+			if err := v.check(field, value.Field(i), tag, fieldErrs); err != nil {
+				return err
+			}
+		}
+
+		if err := v.validate(value.Field(i), fieldErrs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check applies the rules of one field, recording the first failure.
+func (v *TagValidator) check(field reflect.StructField, value reflect.Value, tag string, fieldErrs FieldErrors) error {
+	for _, spec := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(spec), "=")
+		rule, ok := v.rules[name]
+		if !ok {
+			return fmt.Errorf("%s tag on field %s: unknown rule %q", v.tag, field.Name, name)
+		}
+
+		if !rule(value, param) {
+			fieldErrs[strings.ToLower(field.Name)] = name
+
+			return nil
+		}
+	}
+
+	return nil
+}
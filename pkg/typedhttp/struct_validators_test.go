@@ -0,0 +1,147 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type placeOrderRequest struct {
+	SKU      string `json:"sku" validate:"required" biz:"known_sku"`
+	Quantity int    `json:"quantity" validate:"min=1" biz:"max_per_order=10"`
+	Coupon   string `json:"coupon" biz:"active_coupon"`
+}
+
+type placeOrderHandler struct {
+	calls int
+}
+
+func (h *placeOrderHandler) Handle(_ context.Context, _ placeOrderRequest) (struct{}, error) {
+	h.calls++
+
+	return struct{}{}, nil
+}
+
+func newBusinessRules() *TagValidator {
+	return NewTagValidator("biz").
+		Rule("known_sku", func(field reflect.Value, _ string) bool {
+			return field.String() == "" || strings.HasPrefix(field.String(), "SKU-")
+		}).
+		Rule("max_per_order", func(field reflect.Value, param string) bool {
+			return param == "10" && field.Int() <= 10
+		}).
+		Rule("active_coupon", func(field reflect.Value, _ string) bool {
+			return field.String() != "EXPIRED"
+		})
+}
+
+func placeOrder(t *testing.T, router *TypedRouter, body string) (int, map[string]string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response struct {
+		Details map[string]string `json:"details"`
+	}
+	if w.Code != http.StatusCreated {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+	}
+
+	return w.Code, response.Details
+}
+
+func TestStructValidators_MergeWithValidateTags(t *testing.T) {
+	handler := &placeOrderHandler{}
+	router := NewRouter()
+	POST(router, "/orders", handler, WithStructValidator(newBusinessRules()))
+
+	tests := []struct {
+		name   string
+		body   string
+		fields map[string]string
+	}{
+		{
+			name:   "only validate tag fails",
+			body:   `{"sku":"SKU-1","quantity":0}`,
+			fields: map[string]string{"quantity": "min"},
+		},
+		{
+			name:   "only biz tag fails",
+			body:   `{"sku":"ABC","quantity":2,"coupon":"EXPIRED"}`,
+			fields: map[string]string{"sku": "known_sku", "coupon": "active_coupon"},
+		},
+		{
+			name:   "both fail",
+			body:   `{"quantity":11}`,
+			fields: map[string]string{"sku": "required", "quantity": "max_per_order"},
+		},
+		{
+			name:   "first failure of a field wins",
+			body:   `{"sku":"ABC","quantity":0}`,
+			fields: map[string]string{"sku": "known_sku", "quantity": "min"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, fields := placeOrder(t, router, tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+
+	status, _ := placeOrder(t, router, `{"sku":"SKU-1","quantity":3,"coupon":"SPRING"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, 1, handler.calls)
+}
+
+type failingStructValidator struct{}
+
+func (failingStructValidator) ValidateStruct(interface{}) error {
+	return errors.New("rules service unavailable")
+}
+
+func TestStructValidators_RouterWideAndSkipped(t *testing.T) {
+	router := NewRouter(WithStructValidators(newBusinessRules()))
+	POST(router, "/orders", &placeOrderHandler{})
+	POST(router, "/unchecked", &placeOrderHandler{}, WithoutValidation())
+	POST(router, "/broken", &placeOrderHandler{}, WithStructValidator(failingStructValidator{}))
+
+	status, fields := placeOrder(t, router, `{"sku":"ABC","quantity":1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, map[string]string{"sku": "known_sku"}, fields)
+
+	req := httptest.NewRequest(http.MethodPost, "/unchecked", strings.NewReader(`{"sku":"ABC"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/broken", strings.NewReader(`{"sku":"SKU-1","quantity":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestTagValidator_UnknownRule(t *testing.T) {
+	type request struct {
+		Name string `biz:"no_such_rule"`
+	}
+
+	err := NewTagValidator("biz").ValidateStruct(request{Name: "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown rule "no_such_rule"`)
+}
@@ -0,0 +1,110 @@
+package typedhttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// StubConfig holds configuration for a stub server.
+type StubConfig struct {
+	// Responses overrides the stub response of individual routes, keyed by "METHOD /path".
+	// A value is either the route's response type or an error to return instead.
+	Responses map[string]interface{}
+}
+
+// StubOption configures a stub server.
+type StubOption func(*StubConfig)
+
+// WithStubResponse sets the response the stub server returns for a route.
+// Pass an error such as a NotFoundError to stub a failure.
+func WithStubResponse(method, path string, response interface{}) StubOption {
+	return func(c *StubConfig) {
+		c.Responses[RoutePattern{Method: method, Path: path}.String()] = response
+	}
+}
+
+// NewStubServer builds a router with the same routes as router whose handlers skip the
+// business logic and return a zero-value response, or the one set with WithStubResponse.
+//
+// Requests still go through the real decoding and validation, so consumers get the same
+// 400 responses they would from the real server. It panics if a stub response does not
+// match the route's response type or names an unknown route.
+func NewStubServer(router *TypedRouter, opts ...StubOption) *TypedRouter {
+	config := StubConfig{Responses: make(map[string]interface{})}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	stub := &TypedRouter{
+		handlers: make([]HandlerRegistration, 0, len(router.handlers)),
+		mux:      http.NewServeMux(),
+		config:   router.config,
+	}
+
+	for _, reg := range router.handlers {
+		key := RoutePattern{Method: reg.Method, Path: reg.Path}.String()
+		response, ok := config.Responses[key]
+		if ok {
+			checkStubResponse(key, response, reg.ResponseType)
+			delete(config.Responses, key)
+		}
+
+		stub.registerHandler(reg.Method, reg.Path, reg.stub(response), reg.RequestType, reg.ResponseType,
+			&reg.Config, reg.stub)
+	}
+
+	for key := range config.Responses {
+		panic(fmt.Sprintf("typedhttp: stub response for unknown route %q", key))
+	}
+
+	return stub
+}
+
+// checkStubResponse panics if response cannot be returned by a route with responseType.
+func checkStubResponse(route string, response interface{}, responseType reflect.Type) {
+	if _, isErr := response.(error); isErr || response == nil {
+		return
+	}
+
+	if !reflect.TypeOf(response).AssignableTo(responseType) {
+		panic(fmt.Sprintf("typedhttp: stub response for %s has type %T, want %s", route, response, responseType))
+	}
+}
+
+// stubHandler returns a fixed response for any valid request.
+type stubHandler[TRequest, TResponse any] struct {
+	response TResponse
+	err      error
+}
+
+// newStubHandler creates a stub handler for response, which is nil, an error or a TResponse.
+func newStubHandler[TRequest, TResponse any](response interface{}) *stubHandler[TRequest, TResponse] {
+	h := &stubHandler[TRequest, TResponse]{response: zeroResponse[TResponse]()}
+
+	switch v := response.(type) {
+	case nil:
+	case TResponse:
+		h.response = v
+	case error:
+		h.err = v
+	}
+
+	return h
+}
+
+// Handle implements Handler.
+func (h *stubHandler[TRequest, TResponse]) Handle(_ context.Context, _ TRequest) (TResponse, error) {
+	return h.response, h.err
+}
+
+// zeroResponse returns the zero value of T, allocating pointers so they don't encode as null.
+func zeroResponse[T any]() T {
+	var zero T
+	if t := reflect.TypeOf(zero); t != nil && t.Kind() == reflect.Ptr {
+		zero, _ = reflect.New(t.Elem()).Interface().(T)
+	}
+
+	return zero
+}
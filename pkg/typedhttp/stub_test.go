@@ -0,0 +1,94 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StubUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type StubUserResponse struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+type stubUserHandler struct{}
+
+func (h *stubUserHandler) Handle(_ context.Context, _ StubUserRequest) (*StubUserResponse, error) {
+	return nil, errors.New("business logic must not run")
+}
+
+func newStubSourceRouter() *TypedRouter {
+	router := NewRouter()
+	POST(router, "/users", &stubUserHandler{})
+	GET(router, "/profiles/{id}", &profileHandler{})
+
+	return router
+}
+
+func postStubUser(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestNewStubServer_ReturnsZeroValues(t *testing.T) {
+	stub := NewStubServer(newStubSourceRouter())
+
+	w := postStubUser(t, stub, `{"name":"Jane","email":"jane@example.com"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"id":"","name":"","roles":null}`, w.Body.String())
+
+	w = getProfile(t, stub)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":""}`, w.Body.String())
+
+	assert.Len(t, stub.GetHandlers(), 2)
+}
+
+func TestNewStubServer_ReusesValidation(t *testing.T) {
+	stub := NewStubServer(newStubSourceRouter())
+
+	w := postStubUser(t, stub, `{"name":"Jane","email":"not-an-email"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNewStubServer_Overrides(t *testing.T) {
+	stub := NewStubServer(newStubSourceRouter(),
+		WithStubResponse(http.MethodPost, "/users", &StubUserResponse{ID: "42", Name: "Jane", Roles: []string{"admin"}}),
+		WithStubResponse(http.MethodGet, "/profiles/{id}", NewNotFoundError("profile", "7")),
+	)
+
+	w := postStubUser(t, stub, `{"name":"Jane","email":"jane@example.com"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"id":"42","name":"Jane","roles":["admin"]}`, w.Body.String())
+
+	w = getProfile(t, stub)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNewStubServer_PanicsOnInvalidOverrides(t *testing.T) {
+	assert.Panics(t, func() {
+		NewStubServer(newStubSourceRouter(), WithStubResponse(http.MethodGet, "/profiles/{id}", "wrong type"))
+	})
+	assert.Panics(t, func() {
+		NewStubServer(newStubSourceRouter(), WithStubResponse(http.MethodGet, "/missing", ProfileResponse{}))
+	})
+}
@@ -0,0 +1,46 @@
+package typedhttp
+
+import (
+	"fmt"
+	"sync"
+)
+
+// timeFormats holds the registered time formats, keyed by name.
+var timeFormats sync.Map // string -> string
+
+// builtinFormats are the format tag names with a meaning of their own.
+var builtinFormats = map[string]bool{
+	"unix":    true,
+	"rfc3339": true,
+	"rfc822":  true,
+	"uuid":    true,
+}
+
+// RegisterTimeFormat names a Go time layout so fields can refer to it by name, e.g.
+// `header:"X-Issued-At" format:"ledger_ts"` after
+//
+//	typedhttp.RegisterTimeFormat("ledger_ts", "20060102T150405.000Z0700")
+//
+// The name is shared by the path, query, header, cookie and form decoders. Built-in
+// names such as "unix" and "rfc3339" are reserved. Register formats at init time;
+// registering a name again replaces its layout.
+func RegisterTimeFormat(name, layout string) {
+	if name == "" || layout == "" {
+		panic("typedhttp: time format needs a name and a layout")
+	}
+	if builtinFormats[name] {
+		panic(fmt.Sprintf("typedhttp: time format %q is built in", name))
+	}
+
+	timeFormats.Store(name, layout)
+}
+
+// timeLayout returns the layout registered under format, or format itself, which is
+// then used as a Go layout.
+func timeLayout(format string) string {
+	if layout, ok := timeFormats.Load(format); ok {
+		return layout.(string)
+	}
+
+	return format
+}
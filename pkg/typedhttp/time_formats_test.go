@@ -0,0 +1,82 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ledgerLayout = "20060102T150405Z0700"
+
+func init() {
+	RegisterTimeFormat("ledger_ts", ledgerLayout)
+}
+
+type ledgerRequest struct {
+	Since    time.Time `query:"since" format:"ledger_ts"`
+	IssuedAt time.Time `header:"X-Issued-At" format:"ledger_ts"`
+	Session  time.Time `cookie:"session_start" format:"ledger_ts"`
+}
+
+func TestRegisterTimeFormat(t *testing.T) {
+	want, err := time.Parse(ledgerLayout, "20231225T153045+0000")
+	require.NoError(t, err)
+
+	t.Run("query, header and cookie", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ledger?since=20231225T153045%2B0000", http.NoBody)
+		req.Header.Set("X-Issued-At", "20231225T153045+0000")
+		req.AddCookie(&http.Cookie{Name: "session_start", Value: "20231225T153045+0000"})
+
+		result, err := NewCombinedDecoder[ledgerRequest](nil).Decode(req)
+		require.NoError(t, err)
+
+		assert.True(t, want.Equal(result.Since))
+		assert.True(t, want.Equal(result.IssuedAt))
+		assert.True(t, want.Equal(result.Session))
+	})
+
+	t.Run("form", func(t *testing.T) {
+		type entryForm struct {
+			PostedAt time.Time `form:"posted_at" format:"ledger_ts"`
+		}
+
+		body := url.Values{"posted_at": {"20231225T153045+0000"}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/ledger", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		result, err := NewFormDecoder[entryForm](nil).Decode(req)
+		require.NoError(t, err)
+		assert.True(t, want.Equal(result.PostedAt))
+	})
+
+	t.Run("value not in the layout", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ledger", http.NoBody)
+		req.Header.Set("X-Issued-At", "2023-12-25T15:30:45Z")
+
+		_, err := NewHeaderDecoder[ledgerRequest](nil).Decode(req)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		type unknownRequest struct {
+			IssuedAt time.Time `header:"X-Issued-At" format:"billing_ts"`
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/ledger", http.NoBody)
+		req.Header.Set("X-Issued-At", "20231225T153045+0000")
+
+		_, err := NewHeaderDecoder[unknownRequest](nil).Decode(req)
+		assert.Error(t, err)
+	})
+}
+
+func TestRegisterTimeFormat_Reserved(t *testing.T) {
+	assert.Panics(t, func() { RegisterTimeFormat("unix", "2006-01-02") })
+	assert.Panics(t, func() { RegisterTimeFormat("ledger_date", "") })
+}
@@ -0,0 +1,107 @@
+package typedhttp
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+)
+
+// TranslationRegisterFunc registers validator messages for a translator,
+// e.g. fr_translations.RegisterDefaultTranslations.
+type TranslationRegisterFunc func(v *validator.Validate, trans ut.Translator) error
+
+// Translator localizes validation errors using the client's Accept-Language header.
+// English is the fallback locale.
+type Translator struct {
+	universal *ut.UniversalTranslator
+	validator *validator.Validate
+}
+
+// NewTranslator creates a Translator with English messages registered on the
+// validator used by the default decoders. Create translators during setup,
+// before the router starts serving requests.
+func NewTranslator() (*Translator, error) {
+	return NewTranslatorForValidator(getGlobalValidator())
+}
+
+// NewTranslatorForValidator creates a Translator for a custom validator instance.
+func NewTranslatorForValidator(v *validator.Validate) (*Translator, error) {
+	english := en.New()
+	t := &Translator{
+		universal: ut.New(english, english),
+		validator: v,
+	}
+
+	trans, _ := t.universal.GetTranslator(english.Locale())
+	if err := en_translations.RegisterDefaultTranslations(v, trans); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// AddLocale registers an additional locale and its validator messages.
+func (t *Translator) AddLocale(locale locales.Translator, register TranslationRegisterFunc) error {
+	if err := t.universal.AddTranslator(locale, true); err != nil {
+		return err
+	}
+
+	trans, _ := t.universal.GetTranslator(locale.Locale())
+
+	return register(t.validator, trans)
+}
+
+// ForRequest returns the translator that best matches the request's Accept-Language header.
+func (t *Translator) ForRequest(r *http.Request) ut.Translator {
+	trans, _ := t.universal.FindTranslator(acceptedLanguages(r.Header.Get("Accept-Language"))...)
+
+	return trans
+}
+
+// acceptedLanguages returns locale candidates from an Accept-Language header ordered by
+// preference. Region tags are followed by their base language, e.g. "fr_CA", "fr".
+func acceptedLanguages(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		languages = append(languages, language{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	candidates := make([]string, 0, len(languages)*2)
+	for _, lang := range languages {
+		tag := strings.ReplaceAll(lang.tag, "-", "_")
+		candidates = append(candidates, tag)
+		if base, _, found := strings.Cut(tag, "_"); found {
+			candidates = append(candidates, base)
+		}
+	}
+
+	return candidates
+}
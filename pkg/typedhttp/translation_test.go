@@ -0,0 +1,102 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/locales/fr"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CreateAccountRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email"`
+}
+
+type createAccountHandler struct{}
+
+func (h *createAccountHandler) Handle(_ context.Context, req CreateAccountRequest) (CreateAccountRequest, error) {
+	return req, nil
+}
+
+func newFrenchTranslator(t *testing.T) *Translator {
+	t.Helper()
+
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+	require.NoError(t, translator.AddLocale(fr.New(), fr_translations.RegisterDefaultTranslations))
+
+	return translator
+}
+
+func postAccount(router *TypedRouter, acceptLanguage string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader(`{"email":"a@b.c"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestWithTranslator_LocalizesRequiredFailure(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/accounts", &createAccountHandler{}, WithTranslator(newFrenchTranslator(t)))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "french", acceptLanguage: "fr-FR,fr;q=0.9,en;q=0.5", expected: "Name est un champ obligatoire"},
+		{name: "english default", acceptLanguage: "", expected: "Name is a required field"},
+		{name: "unsupported falls back to english", acceptLanguage: "de-DE", expected: "Name is a required field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postAccount(router, tt.acceptLanguage)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "VALIDATION_ERROR", resp.Code)
+			assert.Equal(t, map[string]interface{}{"name": tt.expected}, resp.Details)
+		})
+	}
+}
+
+func TestValidationError_WithoutTranslatorKeepsTags(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/accounts", &createAccountHandler{})
+
+	w := postAccount(router, "fr-FR")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, map[string]interface{}{"name": "required"}, resp.Details)
+}
+
+func TestValidationError_TranslateIgnoresManualErrors(t *testing.T) {
+	valErr := NewValidationError("Validation failed", map[string]string{"name": "required"})
+
+	valErr.Translate(newFrenchTranslator(t).ForRequest(httptest.NewRequest(http.MethodGet, "/", http.NoBody)))
+
+	assert.Nil(t, valErr.Translations)
+}
+
+func TestAcceptedLanguages(t *testing.T) {
+	assert.Equal(t, []string{"fr_CA", "fr", "en"}, acceptedLanguages("en;q=0.5, fr-CA"))
+	assert.Equal(t, []string{"de"}, acceptedLanguages("*;q=0.1, de;q=0.8"))
+	assert.Empty(t, acceptedLanguages(""))
+}
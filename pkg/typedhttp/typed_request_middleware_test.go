@@ -0,0 +1,99 @@
+package typedhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TenantRequest struct {
+	Name     string `json:"name"`
+	TenantID string `json:"-"`
+}
+
+type TenantResponse struct {
+	Name     string `json:"name"`
+	TenantID string `json:"tenant_id"`
+}
+
+type tenantEchoHandler struct{}
+
+func (h *tenantEchoHandler) Handle(_ context.Context, req TenantRequest) (TenantResponse, error) {
+	return TenantResponse{Name: req.Name, TenantID: req.TenantID}, nil
+}
+
+// tenantMiddleware enriches the decoded request with a tenant identifier.
+type tenantMiddleware struct {
+	tenantID string
+	err      error
+}
+
+func (m *tenantMiddleware) BeforeTyped(_ context.Context, req *TenantRequest) error {
+	if m.err != nil {
+		return m.err
+	}
+	req.TenantID = m.tenantID
+
+	return nil
+}
+
+// upperNameMiddleware normalizes the name field.
+type upperNameMiddleware struct{}
+
+func (m *upperNameMiddleware) BeforeTyped(_ context.Context, req *TenantRequest) error {
+	req.Name = strings.ToUpper(req.Name)
+
+	return nil
+}
+
+func TestTypedRequestMiddleware_MutatesDecodedRequest(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/tenants", &tenantEchoHandler{},
+		WithTypedRequestMiddleware[TenantRequest](&tenantMiddleware{tenantID: "acme"}),
+		WithTypedRequestMiddleware[TenantRequest](&upperNameMiddleware{}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/tenants", strings.NewReader(`{"name":"widgets"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"name":"WIDGETS","tenant_id":"acme"}`, w.Body.String())
+}
+
+func TestTypedRequestMiddleware_ErrorStopsHandler(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/tenants", &tenantEchoHandler{},
+		WithTypedRequestMiddleware[TenantRequest](&tenantMiddleware{
+			err: NewForbiddenError("tenant not allowed"),
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/tenants", strings.NewReader(`{"name":"widgets"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "tenant not allowed")
+}
+
+func TestExtractRequestMiddleware(t *testing.T) {
+	entries := []MiddlewareEntry{
+		{Middleware: &tenantMiddleware{}},
+		{Middleware: &TestPreMiddleware{}},
+		{Middleware: errors.New("not middleware")},
+	}
+
+	result := extractRequestMiddleware[TenantRequest](entries)
+
+	assert.Len(t, result, 1)
+	assert.Empty(t, extractRequestMiddleware[TestRequest](entries))
+}
@@ -0,0 +1,193 @@
+package typedhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrUnknownVariant is returned when a Union value names no registered variant.
+var ErrUnknownVariant = errors.New("unknown union variant")
+
+// unions holds the registered unions, keyed by interface type.
+var unions sync.Map // reflect.Type -> *unionVariants
+
+// unionVariants describes the implementations of a registered union interface.
+type unionVariants struct {
+	discriminator string
+	types         map[string]reflect.Type
+	names         map[reflect.Type]string
+}
+
+// RegisterUnion declares the implementations of the interface I that a Union[I] can
+// hold, keyed by the value of the discriminator property that selects them:
+//
+//	typedhttp.RegisterUnion[PaymentMethod]("type", map[string]PaymentMethod{
+//		"card": CardPayment{},
+//		"bank": BankTransfer{},
+//	})
+//
+// Register unions at init time, before requests are decoded or specs generated.
+// Registering an interface again replaces its variants.
+func RegisterUnion[I any](discriminator string, variants map[string]I) {
+	unionType := reflect.TypeOf((*I)(nil)).Elem()
+	if unionType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("typedhttp: union %s is not an interface", unionType))
+	}
+	if discriminator == "" {
+		panic(fmt.Sprintf("typedhttp: union %s needs a discriminator", unionType))
+	}
+
+	registered := &unionVariants{
+		discriminator: discriminator,
+		types:         make(map[string]reflect.Type, len(variants)),
+		names:         make(map[reflect.Type]string, len(variants)),
+	}
+	for name, variant := range variants {
+		variantType := reflect.TypeOf(variant)
+		if variantType == nil {
+			panic(fmt.Sprintf("typedhttp: union %s variant %q is nil", unionType, name))
+		}
+		registered.types[name] = variantType
+		registered.names[variantType] = name
+	}
+
+	unions.Store(unionType, registered)
+}
+
+// lookupUnion returns the registered variants of the interface I.
+func lookupUnion[I any]() (*unionVariants, error) {
+	unionType := reflect.TypeOf((*I)(nil)).Elem()
+	registered, ok := unions.Load(unionType)
+	if !ok {
+		return nil, fmt.Errorf("typedhttp: union %s is not registered", unionType)
+	}
+
+	return registered.(*unionVariants), nil
+}
+
+// Union is a JSON value that holds one of the registered implementations of I, chosen
+// by a discriminator property, e.g. {"type": "card", "number": "4242..."}. The body
+// is decoded into the variant the discriminator names and validated as that type;
+// unknown variants are rejected as invalid JSON. When encoding, the discriminator is
+// added unless the variant already has it. See RegisterUnion.
+//
+// The OpenAPI document describes a Union as a oneOf of its variants with a
+// discriminator.
+type Union[I any] struct {
+	Value I
+}
+
+// UnionVariants returns the discriminator property and the variant types keyed by
+// discriminator value. Both are empty when I is not registered.
+func (Union[I]) UnionVariants() (discriminator string, variants map[string]reflect.Type) {
+	registered, err := lookupUnion[I]()
+	if err != nil {
+		return "", nil
+	}
+
+	variants = make(map[string]reflect.Type, len(registered.types))
+	for name, variantType := range registered.types {
+		variants[name] = variantType
+	}
+
+	return registered.discriminator, variants
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u Union[I]) MarshalJSON() ([]byte, error) {
+	registered, err := lookupUnion[I]()
+	if err != nil {
+		return nil, err
+	}
+
+	value := reflect.ValueOf(&u.Value).Elem()
+	if value.IsNil() {
+		return []byte("null"), nil
+	}
+
+	data, err := json.Marshal(u.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := registered.names[value.Elem().Type()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnknownVariant, u.Value)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return data, nil //nolint:nilerr // Only objects can carry the discriminator
+	}
+	if _, ok := object[registered.discriminator]; ok {
+		return data, nil
+	}
+
+	tag, err := json.Marshal(map[string]string{registered.discriminator: name})
+	if err != nil {
+		return nil, err
+	}
+	if len(object) == 0 {
+		return tag, nil
+	}
+
+	// Splice the discriminator in front of the variant's own properties
+	var buf bytes.Buffer
+	buf.Write(tag[:len(tag)-1])
+	buf.WriteByte(',')
+	buf.Write(bytes.TrimSpace(data)[1:])
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *Union[I]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		var zero I
+		u.Value = zero
+
+		return nil
+	}
+
+	registered, err := lookupUnion[I]()
+	if err != nil {
+		return err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	var name string
+	if raw, ok := object[registered.discriminator]; !ok || json.Unmarshal(raw, &name) != nil {
+		return fmt.Errorf("%w: %q must be a string naming the variant", ErrUnknownVariant, registered.discriminator)
+	}
+
+	variantType, ok := registered.types[name]
+	if !ok {
+		return fmt.Errorf("%w: %s %q", ErrUnknownVariant, registered.discriminator, name)
+	}
+
+	var variant reflect.Value
+	if variantType.Kind() == reflect.Ptr {
+		variant = reflect.New(variantType.Elem())
+		if err := json.Unmarshal(data, variant.Interface()); err != nil {
+			return err
+		}
+	} else {
+		target := reflect.New(variantType)
+		if err := json.Unmarshal(data, target.Interface()); err != nil {
+			return err
+		}
+		variant = target.Elem()
+	}
+
+	u.Value = variant.Interface().(I)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package typedhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PaymentMethod interface {
+	paymentMethod()
+}
+
+type CardPayment struct {
+	Number string `json:"number" validate:"required,len=16"`
+	Expiry string `json:"expiry" validate:"required"`
+}
+
+func (CardPayment) paymentMethod() {}
+
+type BankTransfer struct {
+	IBAN string `json:"iban" validate:"required"`
+}
+
+func (*BankTransfer) paymentMethod() {}
+
+func init() {
+	RegisterUnion[PaymentMethod]("type", map[string]PaymentMethod{
+		"card": CardPayment{},
+		"bank": &BankTransfer{},
+	})
+}
+
+type checkoutRequest struct {
+	Amount int                  `json:"amount" validate:"required"`
+	Method Union[PaymentMethod] `json:"method"`
+}
+
+type checkoutHandler struct {
+	got checkoutRequest
+}
+
+func (h *checkoutHandler) Handle(_ context.Context, req checkoutRequest) (Union[PaymentMethod], error) {
+	h.got = req
+
+	return req.Method, nil
+}
+
+func TestUnion_DecodesEachVariant(t *testing.T) {
+	handler := &checkoutHandler{}
+	router := NewRouter()
+	POST(router, "/checkout", handler)
+
+	tests := []struct {
+		name     string
+		body     string
+		expected PaymentMethod
+		response string
+	}{
+		{
+			name:     "card",
+			body:     `{"amount": 100, "method": {"type": "card", "number": "4242424242424242", "expiry": "12/30"}}`,
+			expected: CardPayment{Number: "4242424242424242", Expiry: "12/30"},
+			response: `{"type":"card","number":"4242424242424242","expiry":"12/30"}`,
+		},
+		{
+			name:     "bank",
+			body:     `{"amount": 100, "method": {"type": "bank", "iban": "DE89370400440532013000"}}`,
+			expected: &BankTransfer{IBAN: "DE89370400440532013000"},
+			response: `{"type":"bank","iban":"DE89370400440532013000"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/checkout", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			assert.Equal(t, tt.expected, handler.got.Method.Value)
+			assert.JSONEq(t, tt.response, w.Body.String())
+		})
+	}
+}
+
+func TestUnion_RejectsInvalidVariants(t *testing.T) {
+	router := NewRouter()
+	POST(router, "/checkout", &checkoutHandler{})
+
+	for name, body := range map[string]string{
+		"unknown variant":       `{"amount": 100, "method": {"type": "cash"}}`,
+		"missing discriminator": `{"amount": 100, "method": {"iban": "DE89370400440532013000"}}`,
+		"invalid variant":       `{"amount": 100, "method": {"type": "card", "number": "42"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/checkout", strings.NewReader(body))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestUnion_MarshalKeepsDeclaredDiscriminator(t *testing.T) {
+	type tagged struct {
+		Type string `json:"type"`
+	}
+	type shape interface{}
+	RegisterUnion[shape]("type", map[string]shape{"circle": tagged{}})
+
+	data, err := json.Marshal(Union[shape]{Value: tagged{Type: "circle"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"circle"}`, string(data))
+
+	data, err = json.Marshal(Union[shape]{})
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
@@ -0,0 +1,127 @@
+package typedhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWeekdayValidator returns a validator with an is_weekday rule for YYYY-MM-DD dates.
+func newWeekdayValidator(t *testing.T) *validator.Validate {
+	t.Helper()
+
+	v := validator.New()
+	require.NoError(t, v.RegisterValidation("is_weekday", func(fl validator.FieldLevel) bool {
+		day, err := time.Parse("2006-01-02", fl.Field().String())
+		if err != nil {
+			return false
+		}
+
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	}))
+
+	return v
+}
+
+type weekdayHandler[T any] struct{}
+
+func (h *weekdayHandler[T]) Handle(_ context.Context, req T) (T, error) {
+	return req, nil
+}
+
+type weekdayQueryRequest struct {
+	Date string `query:"date" validate:"is_weekday"`
+}
+
+type weekdayPathRequest struct {
+	Date string `path:"date" validate:"is_weekday"`
+}
+
+type weekdayHeaderRequest struct {
+	Date string `header:"X-Date" validate:"is_weekday"`
+}
+
+type weekdayCookieRequest struct {
+	Date string `cookie:"date" validate:"is_weekday"`
+}
+
+type weekdayFormRequest struct {
+	Date string `form:"date" validate:"is_weekday"`
+}
+
+type weekdayBodyRequest struct {
+	Date string `json:"date" validate:"is_weekday"`
+}
+
+func TestWithValidator_QueryParam(t *testing.T) {
+	router := NewRouter(WithValidator(newWeekdayValidator(t)))
+	GET(router, "/reports", &weekdayHandler[weekdayQueryRequest]{})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/reports?date=2026-10-15", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/reports?date=2026-10-17", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "is_weekday")
+}
+
+func TestWithValidator_AllSources(t *testing.T) {
+	router := NewRouter(WithValidator(newWeekdayValidator(t)))
+	GET(router, "/path/{date}", &weekdayHandler[weekdayPathRequest]{})
+	GET(router, "/header", &weekdayHandler[weekdayHeaderRequest]{})
+	GET(router, "/cookie", &weekdayHandler[weekdayCookieRequest]{})
+	POST(router, "/form", &weekdayHandler[weekdayFormRequest]{})
+	POST(router, "/body", &weekdayHandler[weekdayBodyRequest]{})
+
+	requests := map[string]func(date string) *http.Request{
+		"path": func(date string) *http.Request {
+			return httptest.NewRequest(http.MethodGet, "/path/"+date, nil)
+		},
+		"header": func(date string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/header", nil)
+			req.Header.Set("X-Date", date)
+
+			return req
+		},
+		"cookie": func(date string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/cookie", nil)
+			req.AddCookie(&http.Cookie{Name: "date", Value: date})
+
+			return req
+		},
+		"form": func(date string) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader(url.Values{"date": {date}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			return req
+		},
+		"body": func(date string) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/body", strings.NewReader(`{"date":"`+date+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			return req
+		},
+	}
+
+	for source, newRequest := range requests {
+		t.Run(source, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, newRequest("2026-10-15"))
+			assert.Less(t, rr.Code, http.StatusBadRequest, rr.Body.String())
+
+			rr = httptest.NewRecorder()
+			router.ServeHTTP(rr, newRequest("2026-10-18"))
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
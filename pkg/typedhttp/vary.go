@@ -0,0 +1,59 @@
+package typedhttp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// AddVary appends fields to the Vary header, skipping ones already present.
+//
+// Middleware that selects a representation based on a request header should call
+// it so caches key responses correctly. Field names are compared case-insensitively
+// and a Vary of "*" is left untouched.
+func AddVary(header http.Header, fields ...string) {
+	existing := VaryFields(header)
+	for _, field := range existing {
+		if field == "*" {
+			return
+		}
+	}
+
+	for _, field := range fields {
+		field = http.CanonicalHeaderKey(strings.TrimSpace(field))
+		if field == "" || containsFold(existing, field) {
+			continue
+		}
+
+		existing = append(existing, field)
+	}
+
+	if len(existing) > 0 {
+		header.Set("Vary", strings.Join(existing, ", "))
+	}
+}
+
+// VaryFields returns the field names listed in the Vary header.
+func VaryFields(header http.Header) []string {
+	var fields []string
+
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	return fields
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package typedhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddVary(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		add      []string
+		expected string
+	}{
+		{name: "empty header", add: []string{"Accept"}, expected: "Accept"},
+		{name: "appends", existing: []string{"Origin"}, add: []string{"accept-encoding"}, expected: "Origin, Accept-Encoding"},
+		{name: "deduplicates", existing: []string{"Accept, Origin"}, add: []string{"accept", "Origin"}, expected: "Accept, Origin"},
+		{name: "merges multiple values", existing: []string{"Accept", "Origin"}, add: []string{"Cookie"}, expected: "Accept, Origin, Cookie"},
+		{name: "wildcard wins", existing: []string{"*"}, add: []string{"Accept"}, expected: "*"},
+		{name: "nothing to add", add: []string{" "}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for _, v := range tt.existing {
+				header.Add("Vary", v)
+			}
+
+			AddVary(header, tt.add...)
+
+			assert.Equal(t, tt.expected, header.Get("Vary"))
+		})
+	}
+}
+
+func TestRouter_VaryForNegotiatedRepresentations(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	router := newShipmentRouter()
+	GET(router, "/profiles/{id}", &profileHandler{})
+	GET(router, "/localized/{id}", &profileHandler{}, WithTranslator(translator))
+
+	req := httptest.NewRequest(http.MethodPost, "/shipments",
+		strings.NewReader(`{"address":{"street":"1 Main St"},"lines":[{"sku":"a","quantity":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	w = getProfile(t, router)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Vary"), "JSON-only routes do not negotiate")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/localized/1", http.NoBody))
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+}
@@ -0,0 +1,50 @@
+package typedhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithoutValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []HandlerOption
+		wantCode int
+	}{
+		{name: "validated by default", wantCode: http.StatusBadRequest},
+		{name: "validation skipped", opts: []HandlerOption{WithoutValidation()}, wantCode: http.StatusCreated},
+		{
+			name:     "validation skipped with body codecs",
+			opts:     []HandlerOption{WithoutValidation(), WithMsgpack()},
+			wantCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter()
+			POST(router, "/signup", &normalizedSignupHandler{}, tt.opts...)
+
+			req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(`{"email":"not-an-email"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantCode, w.Code, w.Body.String())
+			if tt.wantCode != http.StatusCreated {
+				return
+			}
+
+			var resp normalizedSignupRequest
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "not-an-email", resp.Email, "fields are still bound")
+			assert.Empty(t, resp.Name)
+		})
+	}
+}